@@ -23,9 +23,86 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/audit/accounts": {
+        "/accounts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Customers only see their own accounts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "List accounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only accounts of this user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "active, frozen, blocked, dormant or closed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "checking, savings, wallet or system",
+                        "name": "account_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Accounts, oldest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.Account"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to act for this user",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Audit multiple accounts concurrently to detect fraud",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Opens an empty active account for the user under a generated account number. Customers can only open accounts for themselves. The actor is the authenticated user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -33,35 +110,139 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "audit"
+                    "accounts"
                 ],
-                "summary": "Audit multiple accounts",
+                "summary": "Open an account",
                 "parameters": [
                     {
-                        "description": "Array of Account IDs",
-                        "name": "accountIds",
+                        "description": "Owner, type and currency",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/paygo_internal_api_dto.OpenAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Opened account",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to act for this user",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/balances": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the balances of the given accounts as of the same point in time, for month-end reporting. A date-only as_of means the end of that day in UTC, so entries booked or valued on it are included on either basis.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Get balances of many accounts at a point in time",
+                "parameters": [
+                    {
+                        "description": "Account IDs, point in time and basis",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.BulkBalanceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Array of audit results",
+                        "description": "Balances and unknown account IDs",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/dormant": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Dormant accounts accept incoming transfers but cannot send until they are reactivated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "List dormant accounts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dormant accounts with their last activity",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/paygo_internal_domain_service.AuditResult"
+                                "$ref": "#/definitions/paygo_internal_domain_repository.AccountActivity"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or account IDs",
+                        "description": "Invalid query",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -70,19 +251,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/audit/accounts/{accountId}": {
+        "/accounts/escheatment": {
             "get": {
-                "description": "Audit an account to detect fraud by comparing ledger entries with transaction history",
-                "consumes": [
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists dormant accounts with a non-zero balance and no activity for the escheatment period, with totals per currency.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Report dormant accounts due for escheatment",
+                "responses": {
+                    "200": {
+                        "description": "Escheatment report",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.EscheatmentReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{accountId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "audit"
+                    "accounts"
                 ],
-                "summary": "Audit a specific account",
+                "summary": "Get an account",
                 "parameters": [
                     {
                         "type": "string",
@@ -94,9 +301,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Audit result",
+                        "description": "Account without its ledger entries",
                         "schema": {
-                            "$ref": "#/definitions/paygo_internal_domain_service.AuditResult"
+                            "$ref": "#/definitions/paygo_internal_domain_model.Account"
                         }
                     },
                     "400": {
@@ -105,67 +312,131 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/ping": {
+        "/accounts/{accountId}/balance": {
             "get": {
-                "description": "Returns pong to verify the server is running",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the balance of an account as of the given time, either by booking time or by value date. A date means the end of that day in UTC, so entries booked or valued on it are included on either basis.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "accounts"
+                ],
+                "summary": "Get an account balance at a point in time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time (RFC 3339 or YYYY-MM-DD for the end of that day), defaults to now",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "booking (default) or value",
+                        "name": "basis",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Health check endpoint",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Balance",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.PointInTimeBalance"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/transfers": {
-            "post": {
-                "description": "Transfer money from one account to another",
-                "consumes": [
-                    "application/json"
+        "/accounts/{accountId}/interest-accruals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transfers"
+                    "interest"
                 ],
-                "summary": "Transfer money between accounts",
+                "summary": "List interest accruals of an account",
                 "parameters": [
                     {
-                        "description": "Transfer details",
-                        "name": "transfer",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/paygo_internal_api_dto.TransferRequest"
-                        }
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First accrual date (YYYY-MM-DD), defaults to 30 days ago",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last accrual date (YYYY-MM-DD), defaults to today",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Transfer successful",
+                        "description": "Daily accruals",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.InterestAccrual"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "404": {
+                        "description": "Account not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -173,32 +444,5082 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "paygo_internal_api_dto.TransferRequest": {
-            "type": "object",
-            "required": [
-                "amount",
-                "from_account_id",
-                "to_account_id"
-            ],
-            "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "from_account_id": {
-                    "type": "string"
-                },
+        },
+        "/accounts/{accountId}/ledger-entries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the account's ledger entries in booking order. Pass next_cursor from the response as cursor to fetch the following page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Page through an account's ledger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ledger page",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.LedgerPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{accountId}/reactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a dormant account to active after the owner's identity was verified. The actor is the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Reactivate a dormant account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Verification",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.ReactivateAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reactivated account",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Account is not dormant",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{accountId}/savings-product": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Makes the account a savings account earning the product's interest from the next day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interest"
+                ],
+                "summary": "Attach a savings product to an account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Savings product",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.AssignSavingsProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated account",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Account or product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{accountId}/statements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the opening balance, every ledger entry and the closing balance of an account for the period [from, to). Entries are streamed.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/xml"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Get an account statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period start (RFC 3339 or YYYY-MM-DD), inclusive",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period end (RFC 3339 or YYYY-MM-DD), exclusive",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default), csv or camt053 (ISO 20022 camt.053.001.02)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statement",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{accountId}/status": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves the account between active, frozen, blocked and closed. Closed is final and requires a zero balance. Dormant accounts are returned to active through reactivation. Wallet and system accounts cannot be changed here. The actor is the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Change an account's status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status and reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.ChangeAccountStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated account",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Transition not allowed or balance not zero",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{accountId}/status-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Get an account's status history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status changes, oldest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.AccountStatusChange"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid account ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/aml/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aml"
+                ],
+                "summary": "List AML alerts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "open, dismissed or escalated",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "structuring, pass_through, round_amounts or large_cash_equivalent",
+                        "name": "rule",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only alerts on this account",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alerts, newest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.AMLAlert"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/aml/alerts/{alertId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aml"
+                ],
+                "summary": "Get an AML alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "alertId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alert",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.AMLAlert"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid alert ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Alert not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/aml/alerts/{alertId}/dismiss": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Closes an open alert as not suspicious. Its transactions do not raise the rule again. The actor is the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aml"
+                ],
+                "summary": "Dismiss an AML alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "alertId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.DismissAMLAlertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dismissed alert",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.AMLAlert"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Alert not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Alert is not open",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/aml/reports": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aml"
+                ],
+                "summary": "List suspicious activity reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "draft or filed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reports, newest first, without their alerts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.SuspiciousActivityReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Opens a draft report for the given open alerts and marks them escalated. The actor is the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aml"
+                ],
+                "summary": "Escalate AML alerts into a suspicious activity report",
+                "parameters": [
+                    {
+                        "description": "Alerts and narrative",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.EscalateAMLAlertsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Draft report",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.SuspiciousActivityReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Alert not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Alert is not open",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/aml/reports/{reportId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aml"
+                ],
+                "summary": "Get a suspicious activity report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Report ID",
+                        "name": "reportId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report with its alerts",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.SuspiciousActivityReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid report ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Report not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/aml/reports/{reportId}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exports the report with the involved users, accounts and transactions. CSV has one row per transaction with both parties.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "aml"
+                ],
+                "summary": "Export a suspicious activity report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Report ID",
+                        "name": "reportId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report export",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.SARExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid report ID or format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Report not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/aml/reports/{reportId}/file": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks a draft report as filed with the regulator. Filed reports cannot be changed. The actor is the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aml"
+                ],
+                "summary": "File a suspicious activity report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Report ID",
+                        "name": "reportId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Filed report",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.SuspiciousActivityReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid report ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Report not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Report already filed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/aml/scan": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Evaluates the structuring, pass-through, round-amount and large cash-equivalent rules over their windows ending now. Hits open alerts, or add new transactions to the open alert of the same rule and account.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aml"
+                ],
+                "summary": "Run the AML monitoring rules now",
+                "responses": {
+                    "200": {
+                        "description": "Alerts created or updated",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.AMLScanResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/accounts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Audit multiple accounts concurrently to detect fraud. Results are returned in request order, or with stream=true written as NDJSON lines in completion order as each audit finishes, carrying the index of the account in the request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Audit multiple accounts",
+                "parameters": [
+                    {
+                        "description": "Array of Account IDs",
+                        "name": "accountIds",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Stream results as NDJSON",
+                        "name": "stream",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Array of audit results",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_service.AuditResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or account IDs",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/accounts/{accountId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Audit an account to detect fraud by comparing ledger entries with transaction history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Audit a specific account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit result",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.AuditResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid account ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/accounts/{accountId}/timeline": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replays the account's ledger entries in booking order from its first entry and compares each replayed balance with the stored running balance. Returns the first entry where they diverge and the entries around it with their transactions and counterparties. Pass from to page through the replay instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Replay an account's ledger to find where a discrepancy started",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries on either side of the first divergence (default 5, max 50)",
+                        "name": "context",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return entries starting at this position (1 is the first entry) instead",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries to return with from (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timeline",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.AccountTimeline"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid account ID or query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the fraudulent findings sent to the alert sinks, latest sighting first. A finding alerts once until an audit no longer reports it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "List audit alerts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only resolved (true) or unresolved (false) alerts",
+                        "name": "resolved",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of alerts (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of alerts to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alerts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.AuditAlert"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/balances": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compares each account's stored balance with its latest verified checkpoint plus the entries booked after it, for all accounts in one grouped query. Detectors are not run; start an audit run for the full checks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Check every account balance against the ledger",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only list accounts whose balance or last running balance differs from the ledger (default true)",
+                        "name": "mismatched_only",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Count and balance audits",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/detectors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the registered fraud detectors with their fraud type, whether they are enabled and the severity of their findings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "List audit detectors",
+                "responses": {
+                    "200": {
+                        "description": "Detectors in execution order",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_service.DetectorInfo"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/runs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "List audit runs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of runs (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Runs, latest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.AuditRun"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Audits every account in the background and persists a finding per account. Poll the run for progress. An incremental run only audits the accounts changed since the last completed run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Start a full-ledger audit run",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "full (default) or incremental",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Started run",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.AuditRun"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid mode",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "A run is already in progress",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/runs/{runId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Get the progress of an audit run",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Audit run ID",
+                        "name": "runId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Run with progress counters",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.AuditRun"
+                        }
+                    },
+                    "404": {
+                        "description": "Run not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/runs/{runId}/compare/{targetRunId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the accounts whose findings differ between the base run and the target run: added, removed, regressed, resolved or changed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Compare two audit runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base audit run ID",
+                        "name": "runId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target audit run ID",
+                        "name": "targetRunId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Differences between the runs",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.AuditRunComparison"
+                        }
+                    },
+                    "404": {
+                        "description": "Run not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/runs/{runId}/findings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "List the findings of an audit run",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Audit run ID",
+                        "name": "runId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "VALID, SUSPICIOUS, FRAUDULENT or INCOMPLETE",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only findings with this fraud type",
+                        "name": "fraud_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Total count and page of findings",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Run not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/transactions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Verifies that each transaction's legs net to zero in a single currency and match its amount and currency, and looks for ledger entries without a transaction. Select transactions either by ID or by creation time range [from, to). Only failing transactions are listed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Audit transactions",
+                "parameters": [
+                    {
+                        "description": "Transaction IDs or time range",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.AuditTransactionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit report",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.TransactionAuditReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/audit/wallets/{walletId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Audit the ledger account backing a wallet, exactly as accounts are audited",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Audit a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit result",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.AuditResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid wallet ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Starts a session and returns a short-lived access token with a refresh token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log in with email and password",
+                "parameters": [
+                    {
+                        "description": "Credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tokens",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.TokenPair"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "User is not active",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Revokes the session of the refresh token. Its access tokens stop working immediately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log out",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Logged out"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes every session of the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log out everywhere",
+                "responses": {
+                    "200": {
+                        "description": "Number of revoked tokens",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Not authenticated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get the authenticated user",
+                "responses": {
+                    "200": {
+                        "description": "User ID, email, role and session",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Not authenticated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "The refresh token is single use. Presenting one that was already exchanged ends the session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Exchange a refresh token for new tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New tokens",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.TokenPair"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired refresh token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "User is not active",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register a user",
+                "parameters": [
+                    {
+                        "description": "Email, password and name",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Registered user",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Email already registered",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fraud-cases": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists fraud cases opened by audits, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fraud-cases"
+                ],
+                "summary": "List fraud cases",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "open, investigating or resolved",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cases of this account",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cases assigned to this investigator",
+                        "name": "assigned_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fraud cases",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.FraudCase"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fraud-cases/{caseId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the case with its findings and full history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fraud-cases"
+                ],
+                "summary": "Get a fraud case",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fraud case ID",
+                        "name": "caseId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fraud case",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.FraudCase"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid case ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Case not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fraud-cases/{caseId}/assign": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assigns the case to an investigator. The actor is the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fraud-cases"
+                ],
+                "summary": "Assign a fraud case",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fraud case ID",
+                        "name": "caseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Investigator",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.AssignFraudCaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated case",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.FraudCase"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Case not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Case already resolved",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fraud-cases/{caseId}/comments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a comment to the case history. The actor is the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fraud-cases"
+                ],
+                "summary": "Comment on a fraud case",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fraud case ID",
+                        "name": "caseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.FraudCaseCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated case",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.FraudCase"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Case not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Case already resolved",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fraud-cases/{caseId}/resolve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Closes the case as confirmed fraud or a false positive. A frozen account stays frozen until it is unfrozen. The actor is the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fraud-cases"
+                ],
+                "summary": "Resolve a fraud case",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fraud case ID",
+                        "name": "caseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resolution",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.ResolveFraudCaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resolved case",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.FraudCase"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Case not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Case already resolved",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/fraud-cases/{caseId}/unfreeze": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reactivates the frozen account of the case. The actor is the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fraud-cases"
+                ],
+                "summary": "Unfreeze the account of a fraud case",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fraud case ID",
+                        "name": "caseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.UnfreezeAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated case",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.FraudCase"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Case not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Account is not frozen",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/periods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "periods"
+                ],
+                "summary": "List closed accounting periods",
+                "responses": {
+                    "200": {
+                        "description": "Closed periods, latest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.AccountingPeriod"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/periods/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Closes the day or month containing the given date and snapshots every account balance at its end. Nothing can be booked inside a closed period afterwards.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "periods"
+                ],
+                "summary": "Close an accounting period",
+                "parameters": [
+                    {
+                        "description": "Period to close",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.ClosePeriodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Closed period",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.AccountingPeriod"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Period already closed or not ended",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/periods/{periodId}/snapshots": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "periods"
+                ],
+                "summary": "Get the balance snapshot of a closed period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Period ID",
+                        "name": "periodId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Account balances at the end of the period",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.PeriodBalanceSnapshot"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ping": {
+            "get": {
+                "description": "Returns pong to verify the server is running",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliation/lines/{lineId}/match": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Matches a statement line with a ledger entry of the statement's account. The actor is the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliation"
+                ],
+                "summary": "Match a statement line by hand",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Statement line ID",
+                        "name": "lineId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ledger entry and reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.MatchStatementLineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matched line",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.BankStatementLine"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Line or ledger entry not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Line or ledger entry already matched",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliation/lines/{lineId}/unmatch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Releases the ledger entry of a matched statement line. The actor is the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliation"
+                ],
+                "summary": "Unmatch a statement line",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Statement line ID",
+                        "name": "lineId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.UnmatchStatementLineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unmatched line",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.BankStatementLine"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Line not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Line is not matched",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliation/statements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliation"
+                ],
+                "summary": "List imported bank statements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only statements of this account",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of statements (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statements, newest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.BankStatement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Imports a partner bank statement for a settlement account and matches its lines with ledger entries by reference, then by amount and value date. CSV files use the configured column mapping, overridden by the optional mapping field, e.g. {\"date\":\"Booking Date\",\"delimiter\":\";\"}. The importer is the authenticated user.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliation"
+                ],
+                "summary": "Import a bank statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Settlement account ID",
+                        "name": "account_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or mt940",
+                        "name": "format",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Statement file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CSV column mapping as a JSON object",
+                        "name": "mapping",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Imported statements with their lines",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.BankStatement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file or mapping",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Statement already imported",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliation/statements/{statementId}/rematch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Runs automatic matching again over the unmatched and suspicious lines, e.g. after late ledger postings. Manual matches are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliation"
+                ],
+                "summary": "Match a statement again",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Statement ID",
+                        "name": "statementId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reconciliation report",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.ReconciliationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid statement ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Statement not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliation/statements/{statementId}/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns matched, unmatched and suspicious line counts, the lines, and the ledger entries of the statement period that no line accounts for",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliation"
+                ],
+                "summary": "Get the reconciliation report of a statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Statement ID",
+                        "name": "statementId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only lines in this status: matched, unmatched or suspicious",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reconciliation report",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.ReconciliationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Statement not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/general-ledger": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Opening balance, entries, totals and closing balance per account for the period [from, to). Entries are streamed, account by account, as {\"from\", \"to\", \"accounts\": [{\"account\", \"entries\"}]}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "General ledger detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Period start (RFC 3339 or YYYY-MM-DD), inclusive",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period end (RFC 3339 or YYYY-MM-DD), exclusive",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restrict to one account",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restrict to one currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "General ledger",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/trial-balance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Debit and credit totals and net per account and per currency as of a point in time. Flags every currency whose debits and credits do not net to zero.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Trial balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Point in time (RFC 3339 or YYYY-MM-DD for the end of that day), defaults to now",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trial balance",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.TrialBalance"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/savings-products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interest"
+                ],
+                "summary": "List savings products",
+                "responses": {
+                    "200": {
+                        "description": "Savings products",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.SavingsProduct"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a savings product with an annual rate, a day-count convention and the system account interest is paid from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "interest"
+                ],
+                "summary": "Create a savings product",
+                "parameters": [
+                    {
+                        "description": "Savings product",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.CreateSavingsProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created product",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.SavingsProduct"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{transactionId}/reversal": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Posts a correction in the open period that mirrors every leg of the original transaction and references it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "periods"
+                ],
+                "summary": "Reverse a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Original transaction ID",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the correction",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.CorrectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Reversal transaction",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Already reversed or period closed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Transfer money from one account to another. Customers can only transfer from their own accounts. Every transfer is risk scored first: risky transfers are held for manual review and the riskiest are denied. A transfer with the same accounts, amount and description as a recent one is refused with 409 unless confirm_duplicate is set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Transfer money between accounts",
+                "parameters": [
+                    {
+                        "description": "Transfer details",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.TransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer successful",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "202": {
+                        "description": "Transfer held for review",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Transfer denied by risk checks or source account not owned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Possible duplicate of a recent transfer",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists transfers held by risk scoring, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "List transfers awaiting review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of transfers (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfers pending review",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.Transaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{transactionId}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Books a transfer held for review. The reviewer is the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Approve a held transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Approved transfer",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or transfer can no longer be booked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer is not pending review",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{transactionId}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels a transfer held for review and releases the held amount. The reviewer is the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Reject a held transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "transactionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rejected transfer",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer is not pending review",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "List a user's wallets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallets with their balances",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/paygo_internal_domain_model.Wallet"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to act for this user",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Opens a wallet for a user in a currency, backed by its own ledger account. Customers can only open wallets for themselves.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Create a wallet",
+                "parameters": [
+                    {
+                        "description": "Wallet owner and currency",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.CreateWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created wallet",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.Wallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to act for this user",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Wallet already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets/transfers": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Customers can only transfer from their own wallets. Wallet transfers are risk scored like account transfers: risky ones are held for review and the riskiest are denied.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Transfer between wallets",
+                "parameters": [
+                    {
+                        "description": "Wallets and amount",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.WalletTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer transaction and new balances",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.WalletMovement"
+                        }
+                    },
+                    "202": {
+                        "description": "Transfer held for review",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.WalletMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Transfer denied by risk checks or wallet not owned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets/{walletId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Get a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet with its balance",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_model.Wallet"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of this wallet",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets/{walletId}/top-up": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves money from one of the wallet owner's accounts into the wallet. Top-ups are risk scored like transfers: risky ones are held for review and the riskiest are denied.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Top up a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Source account and amount",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.WalletFundsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Top-up transaction and new balances",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.WalletMovement"
+                        }
+                    },
+                    "202": {
+                        "description": "Top-up held for review",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.WalletMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Top-up denied by risk checks or wallet not owned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets/{walletId}/withdraw": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves money from the wallet to one of its owner's accounts. Withdrawals are risk scored like transfers: risky ones are held for review and the riskiest are denied.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Withdraw from a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "walletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Destination account and amount",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_api_dto.WalletFundsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Withdrawal transaction and new balances",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.WalletMovement"
+                        }
+                    },
+                    "202": {
+                        "description": "Withdrawal held for review",
+                        "schema": {
+                            "$ref": "#/definitions/paygo_internal_domain_service.WalletMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Withdrawal denied by risk checks or wallet not owned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or account not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "paygo_internal_api_dto.AssignFraudCaseRequest": {
+            "type": "object",
+            "required": [
+                "assignee"
+            ],
+            "properties": {
+                "assignee": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "paygo_internal_api_dto.AssignSavingsProductRequest": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_api_dto.AuditTransactionsRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "transaction_ids": {
+                    "type": "array",
+                    "maxItems": 10000,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "paygo_internal_api_dto.BulkBalanceRequest": {
+            "type": "object",
+            "required": [
+                "account_ids"
+            ],
+            "properties": {
+                "account_ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "as_of": {
+                    "description": "AsOf is an RFC 3339 timestamp or a YYYY-MM-DD date, meaning the end\nof that day in UTC. It defaults to now.",
+                    "type": "string"
+                },
+                "basis": {
+                    "type": "string",
+                    "enum": [
+                        "booking",
+                        "value"
+                    ]
+                }
+            }
+        },
+        "paygo_internal_api_dto.ChangeAccountStatusRequest": {
+            "type": "object",
+            "required": [
+                "reason",
+                "status"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "frozen",
+                        "blocked",
+                        "closed"
+                    ]
+                }
+            }
+        },
+        "paygo_internal_api_dto.ClosePeriodRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "period_type"
+            ],
+            "properties": {
+                "date": {
+                    "description": "Date is any day inside the period to close, as YYYY-MM-DD.",
+                    "type": "string"
+                },
+                "period_type": {
+                    "type": "string",
+                    "enum": [
+                        "day",
+                        "month"
+                    ]
+                }
+            }
+        },
+        "paygo_internal_api_dto.CorrectionRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200
+                }
+            }
+        },
+        "paygo_internal_api_dto.CreateSavingsProductRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "currency_code",
+                "day_count",
+                "expense_account_id",
+                "name",
+                "posting_frequency"
+            ],
+            "properties": {
+                "annual_rate": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "day_count": {
+                    "type": "string",
+                    "enum": [
+                        "ACT/365",
+                        "ACT/360",
+                        "30/360"
+                    ]
+                },
+                "expense_account_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "posting_frequency": {
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "monthly"
+                    ]
+                }
+            }
+        },
+        "paygo_internal_api_dto.CreateWalletRequest": {
+            "type": "object",
+            "required": [
+                "currency",
+                "user_id"
+            ],
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_api_dto.DismissAMLAlertRequest": {
+            "type": "object",
+            "required": [
+                "note"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "paygo_internal_api_dto.EscalateAMLAlertsRequest": {
+            "type": "object",
+            "required": [
+                "alert_ids",
+                "narrative"
+            ],
+            "properties": {
+                "alert_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "narrative": {
+                    "type": "string",
+                    "maxLength": 10000
+                }
+            }
+        },
+        "paygo_internal_api_dto.FraudCaseCommentRequest": {
+            "type": "object",
+            "required": [
+                "comment"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "paygo_internal_api_dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_api_dto.MatchStatementLineRequest": {
+            "type": "object",
+            "required": [
+                "ledger_entry_id",
+                "note"
+            ],
+            "properties": {
+                "ledger_entry_id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "paygo_internal_api_dto.OpenAccountRequest": {
+            "type": "object",
+            "required": [
+                "account_type",
+                "currency",
+                "user_id"
+            ],
+            "properties": {
+                "account_type": {
+                    "type": "string",
+                    "enum": [
+                        "checking",
+                        "savings"
+                    ]
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_api_dto.ReactivateAccountRequest": {
+            "type": "object",
+            "required": [
+                "verification_method",
+                "verification_reference"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "verification_method": {
+                    "type": "string",
+                    "enum": [
+                        "id_document",
+                        "in_person",
+                        "video_call"
+                    ]
+                },
+                "verification_reference": {
+                    "type": "string",
+                    "maxLength": 200
+                }
+            }
+        },
+        "paygo_internal_api_dto.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_api_dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 254
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "paygo_internal_api_dto.ResolveFraudCaseRequest": {
+            "type": "object",
+            "required": [
+                "resolution"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "resolution": {
+                    "type": "string",
+                    "enum": [
+                        "confirmed_fraud",
+                        "false_positive"
+                    ]
+                }
+            }
+        },
+        "paygo_internal_api_dto.TransferRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "from_account_id",
+                "to_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "confirm_duplicate": {
+                    "description": "ConfirmDuplicate books the transfer even if it looks like a repeat of\na recent one.",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "from_account_id": {
+                    "type": "string"
+                },
                 "to_account_id": {
                     "type": "string"
                 }
             }
         },
-        "paygo_internal_domain_service.AuditResult": {
+        "paygo_internal_api_dto.UnfreezeAccountRequest": {
+            "type": "object",
+            "required": [
+                "note"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "paygo_internal_api_dto.UnmatchStatementLineRequest": {
+            "type": "object",
+            "required": [
+                "note"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "paygo_internal_api_dto.WalletFundsRequest": {
+            "type": "object",
+            "required": [
+                "account_id",
+                "amount"
+            ],
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_api_dto.WalletTransferRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "from_wallet_id",
+                "to_wallet_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "from_wallet_id": {
+                    "type": "string"
+                },
+                "to_wallet_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.AMLAlert": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "report_id": {
+                    "type": "string"
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"open\", \"dismissed\" or \"escalated\"",
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "transaction_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.Account": {
+            "type": "object",
+            "properties": {
+                "account_number": {
+                    "type": "string"
+                },
+                "account_type": {
+                    "type": "string"
+                },
+                "available_balance": {
+                    "type": "number"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ledger_entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.LedgerEntry"
+                    }
+                },
+                "product_assigned_at": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "description": "savings product, if any",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.AccountStatusChange": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.AccountingPeriod": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
+                "closed_by": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "description": "exclusive",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "period_type": {
+                    "description": "\"day\" or \"month\"",
+                    "type": "string"
+                },
+                "snapshot_count": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.AuditAlert": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "account_number": {
+                    "type": "string"
+                },
+                "detector": {
+                    "type": "string"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
+                "first_seen_at": {
+                    "type": "string"
+                },
+                "fraud_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "notified_at": {
+                    "description": "nil until every sink accepted the alert",
+                    "type": "string"
+                },
+                "occurrences": {
+                    "type": "integer"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.AuditRun": {
+            "type": "object",
+            "properties": {
+                "audited_accounts": {
+                    "type": "integer"
+                },
+                "changed_since": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "fraudulent_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "incomplete_count": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "description": "\"full\" or \"incremental\"",
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "started_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"running\", \"completed\" or \"failed\"",
+                    "type": "string"
+                },
+                "suspicious_count": {
+                    "type": "integer"
+                },
+                "total_accounts": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "paygo_internal_domain_model.BankStatement": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "bank_account": {
+                    "type": "string"
+                },
+                "closing_balance": {
+                    "type": "number"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "file_hash": {
+                    "description": "hex SHA-256 of the file, \":\" and the statement's index in it",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "\"csv\" or \"mt940\"",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imported_at": {
+                    "type": "string"
+                },
+                "imported_by": {
+                    "type": "string"
+                },
+                "line_count": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.BankStatementLine"
+                    }
+                },
+                "opening_balance": {
+                    "type": "number"
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.BankStatementLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "bank_reference": {
+                    "type": "string"
+                },
+                "booking_date": {
+                    "type": "string"
+                },
+                "candidate_entry_id": {
+                    "description": "closest ledger entry of a suspicious line",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "direction": {
+                    "description": "\"credit\" or \"debit\", as seen by the bank account",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "line_number": {
+                    "type": "integer"
+                },
+                "match_method": {
+                    "description": "\"reference\", \"amount_date\" or \"manual\"",
+                    "type": "string"
+                },
+                "match_note": {
+                    "type": "string"
+                },
+                "matched_at": {
+                    "type": "string"
+                },
+                "matched_by": {
+                    "type": "string"
+                },
+                "matched_entry_id": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "statement_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"unmatched\", \"matched\" or \"suspicious\"",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.DetectorFinding": {
+            "type": "object",
+            "properties": {
+                "detector": {
+                    "type": "string"
+                },
+                "fraud_type": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.FraudCase": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "assigned_to": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.FraudCaseEvent"
+                    }
+                },
+                "findings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.DetectorFinding"
+                    }
+                },
+                "fraud_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "description": "\"confirmed_fraud\" or \"false_positive\"",
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"open\", \"investigating\" or \"resolved\"",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.FraudCaseEvent": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "case_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "\"opened\", \"findings_added\", \"frozen\", \"assigned\", \"comment\", \"resolved\" or \"unfrozen\"",
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.InterestAccrual": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "accrual_date": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "annual_rate": {
+                    "type": "number"
+                },
+                "balance": {
+                    "description": "end-of-day value-dated balance",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "day_count": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "posted_at": {
+                    "type": "string"
+                },
+                "posted_transaction_id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"accrued\" or \"posted\"",
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.LedgerEntry": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entry_type": {
+                    "description": "\"debit\" or \"credit\"",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "running_balance": {
+                    "type": "number"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "value_date": {
+                    "description": "nil means the booking date",
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.PeriodBalanceSnapshot": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "entry_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "period_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.SavingsProduct": {
+            "type": "object",
+            "properties": {
+                "annual_rate": {
+                    "description": "0.025 is 2.5%",
+                    "type": "number"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "day_count": {
+                    "description": "\"ACT/365\", \"ACT/360\" or \"30/360\"",
+                    "type": "string"
+                },
+                "expense_account_id": {
+                    "description": "system account interest is paid from",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "posting_frequency": {
+                    "description": "\"daily\" or \"monthly\"",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.SuspiciousActivityReport": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.AMLAlert"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "filed_at": {
+                    "type": "string"
+                },
+                "filed_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "narrative": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"draft\" or \"filed\"",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.Transaction": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "destination_account_id": {
+                    "type": "string"
+                },
+                "duplicate_of_id": {
+                    "description": "set when a suspected duplicate was confirmed",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ledger_entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.LedgerEntry"
+                    }
+                },
+                "original_transaction_id": {
+                    "description": "set on corrections",
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "risk_decision": {
+                    "description": "\"allow\", \"review\" or \"deny\"",
+                    "type": "string"
+                },
+                "risk_reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "risk_score": {
+                    "type": "integer"
+                },
+                "source_account_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction_reference": {
+                    "type": "string"
+                },
+                "transaction_type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_model.User": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.Account"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "paygo_internal_domain_model.Wallet": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_repository.AccountActivity": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "account_number": {
+                    "type": "string"
+                },
+                "account_type": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "dormant_since": {
+                    "type": "string"
+                },
+                "last_activity_at": {
+                    "type": "string"
+                },
+                "owner_email": {
+                    "type": "string"
+                },
+                "owner_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_repository.Counterparty": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "account_number": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "entry_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_repository.ReconciliationEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "booked_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "entry_id": {
+                    "type": "string"
+                },
+                "entry_type": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "value_date": {
+                    "description": "ValueDate is the entry's value date, or its booking date if it has none.",
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_service.AMLScanResult": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.AMLAlert"
+                    }
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "scanned_at": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "paygo_internal_domain_service.AccountTimeline": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "account_number": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "balance_discrepancy": {
+                    "type": "number"
+                },
+                "conclusion": {
+                    "description": "Conclusion says in one sentence what the replay found.",
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_service.TimelineEntry"
+                    }
+                },
+                "entry_count": {
+                    "type": "integer"
+                },
+                "first_divergence": {
+                    "$ref": "#/definitions/paygo_internal_domain_service.TimelineEntry"
+                },
+                "last_running_balance": {
+                    "type": "number"
+                },
+                "ledger_balance": {
+                    "type": "number"
+                }
+            }
+        },
+        "paygo_internal_domain_service.AuditResult": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "account_number": {
+                    "type": "string"
+                },
+                "actual_balance": {
+                    "type": "number"
+                },
+                "audited_at": {
+                    "type": "string"
+                },
+                "balance_discrepancy": {
+                    "type": "number"
+                },
+                "checkpoint_id": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expected_balance": {
+                    "type": "number"
+                },
+                "findings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_service.Finding"
+                    }
+                },
+                "fraud_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_service.FraudType"
+                    }
+                },
+                "last_running_balance": {
+                    "type": "number"
+                },
+                "ledger_entries_count": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/paygo_internal_domain_service.AuditStatus"
+                }
+            }
+        },
+        "paygo_internal_domain_service.AuditRunComparison": {
+            "type": "object",
+            "properties": {
+                "base_run": {
+                    "$ref": "#/definitions/paygo_internal_domain_model.AuditRun"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_service.FindingChange"
+                    }
+                },
+                "summary": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "target_run": {
+                    "$ref": "#/definitions/paygo_internal_domain_model.AuditRun"
+                }
+            }
+        },
+        "paygo_internal_domain_service.AuditStatus": {
+            "type": "string",
+            "enum": [
+                "VALID",
+                "SUSPICIOUS",
+                "FRAUDULENT",
+                "INCOMPLETE"
+            ],
+            "x-enum-varnames": [
+                "AuditStatusValid",
+                "AuditStatusSuspicious",
+                "AuditStatusFraudulent",
+                "AuditStatusIncomplete"
+            ]
+        },
+        "paygo_internal_domain_service.BalanceBasis": {
+            "type": "string",
+            "enum": [
+                "booking",
+                "value"
+            ],
+            "x-enum-varnames": [
+                "BalanceBasisBooking",
+                "BalanceBasisValue"
+            ]
+        },
+        "paygo_internal_domain_service.DetectorInfo": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "fraud_type": {
+                    "$ref": "#/definitions/paygo_internal_domain_service.FraudType"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "severity": {
+                    "$ref": "#/definitions/paygo_internal_domain_service.Severity"
+                }
+            }
+        },
+        "paygo_internal_domain_service.EscheatmentReport": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_repository.AccountActivity"
+                    }
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "inactive_before": {
+                    "type": "string"
+                },
+                "totals_by_currency": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                }
+            }
+        },
+        "paygo_internal_domain_service.Finding": {
+            "type": "object",
+            "properties": {
+                "detector": {
+                    "type": "string"
+                },
+                "fraud_type": {
+                    "$ref": "#/definitions/paygo_internal_domain_service.FraudType"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "severity": {
+                    "$ref": "#/definitions/paygo_internal_domain_service.Severity"
+                }
+            }
+        },
+        "paygo_internal_domain_service.FindingChange": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "account_number": {
+                    "type": "string"
+                },
+                "base_discrepancy": {
+                    "type": "number"
+                },
+                "base_fraud_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "base_status": {
+                    "type": "string"
+                },
+                "change": {
+                    "type": "string"
+                },
+                "target_discrepancy": {
+                    "type": "number"
+                },
+                "target_fraud_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_service.FraudType": {
+            "type": "string",
+            "enum": [
+                "BALANCE_MISMATCH",
+                "CLOSED_PERIOD_ALTERED",
+                "RUNNING_BALANCE_BREAK",
+                "DUPLICATE_LEDGER_ENTRY",
+                "VELOCITY",
+                "DUPLICATE_TRANSFER",
+                "UNBALANCED_TRANSACTION",
+                "MIXED_CURRENCY_TRANSACTION",
+                "TRANSACTION_HEADER_MISMATCH",
+                "ORPHANED_LEDGER_ENTRY"
+            ],
+            "x-enum-varnames": [
+                "FraudTypeBalanceMismatch",
+                "FraudTypeClosedPeriodAltered",
+                "FraudTypeRunningBalanceBreak",
+                "FraudTypeDuplicateLeg",
+                "FraudTypeVelocity",
+                "FraudTypeDuplicateTransfer",
+                "FraudTypeUnbalancedTransaction",
+                "FraudTypeMixedCurrency",
+                "FraudTypeHeaderMismatch",
+                "FraudTypeOrphanedEntry"
+            ]
+        },
+        "paygo_internal_domain_service.LedgerPage": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.LedgerEntry"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_service.PointInTimeBalance": {
             "type": "object",
             "properties": {
                 "account_id": {
@@ -207,59 +5528,356 @@ const docTemplate = `{
                 "account_number": {
                     "type": "string"
                 },
-                "actual_balance": {
+                "as_of": {
+                    "type": "string"
+                },
+                "balance": {
                     "type": "number"
                 },
-                "audited_at": {
+                "basis": {
+                    "$ref": "#/definitions/paygo_internal_domain_service.BalanceBasis"
+                },
+                "currency_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_service.ReconciliationReport": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.BankStatementLine"
+                    }
+                },
+                "matched_count": {
+                    "type": "integer"
+                },
+                "statement": {
+                    "$ref": "#/definitions/paygo_internal_domain_model.BankStatement"
+                },
+                "suspicious_count": {
+                    "type": "integer"
+                },
+                "unmatched_count": {
+                    "type": "integer"
+                },
+                "unmatched_entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_repository.ReconciliationEntry"
+                    }
+                }
+            }
+        },
+        "paygo_internal_domain_service.SARExport": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.Account"
+                    }
+                },
+                "generated_at": {
                     "type": "string"
                 },
-                "balance_discrepancy": {
+                "report": {
+                    "$ref": "#/definitions/paygo_internal_domain_model.SuspiciousActivityReport"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.Transaction"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.User"
+                    }
+                }
+            }
+        },
+        "paygo_internal_domain_service.Severity": {
+            "type": "string",
+            "enum": [
+                "info",
+                "low",
+                "medium",
+                "high",
+                "critical"
+            ],
+            "x-enum-varnames": [
+                "SeverityInfo",
+                "SeverityLow",
+                "SeverityMedium",
+                "SeverityHigh",
+                "SeverityCritical"
+            ]
+        },
+        "paygo_internal_domain_service.TimelineEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
                     "type": "number"
                 },
-                "details": {
+                "counterparties": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_repository.Counterparty"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "diverged": {
+                    "type": "boolean"
+                },
+                "drift": {
+                    "type": "number"
+                },
+                "entry_id": {
+                    "type": "string"
+                },
+                "entry_type": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "replayed_balance": {
+                    "type": "number"
+                },
+                "running_balance": {
+                    "type": "number"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "transaction_reference": {
+                    "type": "string"
+                },
+                "transaction_status": {
+                    "type": "string"
+                },
+                "transaction_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_service.TokenPair": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "paygo_internal_domain_service.TransactionAuditReport": {
+            "type": "object",
+            "properties": {
+                "audited_at": {
+                    "type": "string"
+                },
+                "fraudulent_count": {
+                    "type": "integer"
+                },
+                "not_found": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "expected_balance": {
+                "orphaned_entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_model.LedgerEntry"
+                    }
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_service.TransactionAuditResult"
+                    }
+                },
+                "transactions_checked": {
+                    "type": "integer"
+                },
+                "valid_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "paygo_internal_domain_service.TransactionAuditResult": {
+            "type": "object",
+            "properties": {
+                "amount": {
                     "type": "number"
                 },
+                "currency_code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "fraud_types": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/paygo_internal_domain_service.FraudType"
                     }
                 },
-                "ledger_entries_count": {
+                "leg_count": {
                     "type": "integer"
                 },
                 "status": {
                     "$ref": "#/definitions/paygo_internal_domain_service.AuditStatus"
+                },
+                "total_credits": {
+                    "type": "number"
+                },
+                "total_debits": {
+                    "type": "number"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "transaction_reference": {
+                    "type": "string"
+                },
+                "transaction_type": {
+                    "type": "string"
                 }
             }
         },
-        "paygo_internal_domain_service.AuditStatus": {
-            "type": "string",
-            "enum": [
-                "VALID",
-                "FRAUDULENT",
-                "INCOMPLETE"
-            ],
-            "x-enum-varnames": [
-                "AuditStatusValid",
-                "AuditStatusFraudulent",
-                "AuditStatusIncomplete"
-            ]
+        "paygo_internal_domain_service.TrialBalance": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_service.TrialBalanceAccount"
+                    }
+                },
+                "as_of": {
+                    "type": "string"
+                },
+                "balanced": {
+                    "type": "boolean"
+                },
+                "currencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/paygo_internal_domain_service.TrialBalanceCurrency"
+                    }
+                },
+                "unbalanced_currencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
         },
-        "paygo_internal_domain_service.FraudType": {
-            "type": "string",
-            "enum": [
-                "BALANCE_MISMATCH"
-            ],
-            "x-enum-varnames": [
-                "FraudTypeBalanceMismatch"
-            ]
+        "paygo_internal_domain_service.TrialBalanceAccount": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "account_number": {
+                    "type": "string"
+                },
+                "account_type": {
+                    "type": "string"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "entry_count": {
+                    "type": "integer"
+                },
+                "net": {
+                    "description": "Net is credits minus debits, i.e. the ledger balance of the account.",
+                    "type": "number"
+                },
+                "total_credits": {
+                    "type": "number"
+                },
+                "total_debits": {
+                    "type": "number"
+                }
+            }
+        },
+        "paygo_internal_domain_service.TrialBalanceCurrency": {
+            "type": "object",
+            "properties": {
+                "account_count": {
+                    "type": "integer"
+                },
+                "balanced": {
+                    "type": "boolean"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "entry_count": {
+                    "type": "integer"
+                },
+                "net": {
+                    "type": "number"
+                },
+                "total_credits": {
+                    "type": "number"
+                },
+                "total_debits": {
+                    "type": "number"
+                }
+            }
+        },
+        "paygo_internal_domain_service.WalletMovement": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/paygo_internal_domain_model.Account"
+                },
+                "to_wallet": {
+                    "$ref": "#/definitions/paygo_internal_domain_model.Wallet"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/paygo_internal_domain_model.Transaction"
+                },
+                "wallet": {
+                    "$ref": "#/definitions/paygo_internal_domain_model.Wallet"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Access token from /auth/login, as \"Bearer \u003ctoken\u003e\". Customers act only on their own accounts and wallets; back-office routes (audit, fraud cases, AML, periods, reports, reconciliation, transfer reviews, account status) return 403 unless the user has the staff role.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -4,7 +4,11 @@ import (
 	"log"
 	"paygo/internal/api/route"
 	"paygo/internal/config"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/alert"
 	"paygo/internal/infra/database"
+	"paygo/internal/infra/scheduler"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -27,8 +31,19 @@ import (
 // @host localhost:8080
 // @BasePath /api/v1
 // @schemes http https
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token from /auth/login, as "Bearer <token>". Customers act only on their own accounts and wallets; back-office routes (audit, fraud cases, AML, periods, reports, reconciliation, transfer reviews, account status) return 403 unless the user has the staff role.
 func main() {
 	cfg := config.LoadConfig()
+	if cfg.JWTSecret == config.DefaultJWTSecret {
+		if !cfg.DevMode {
+			log.Fatal("JWT_SECRET is not set; refusing to sign access tokens with the default secret outside DEV_MODE")
+		}
+		log.Println("Warning: JWT_SECRET is not set, access tokens are signed with the default secret")
+	}
 
 	db, err := database.Setup(&cfg, database.WithMaxRetries(5))
 	if err != nil {
@@ -36,7 +51,11 @@ func main() {
 	}
 	defer db.Close()
 
-	r := setupRouter(db)
+	jobs := setupScheduler(&cfg, db)
+	jobs.Start()
+	defer jobs.Stop()
+
+	r := setupRouter(&cfg, db)
 
 	log.Printf("Server starting on port %s...", cfg.ServerPort)
 	if err := r.Run(":" + cfg.ServerPort); err != nil {
@@ -44,13 +63,132 @@ func main() {
 	}
 }
 
-func setupRouter(db *database.Database) *gin.Engine {
+func setupRouter(cfg *config.Config, db *database.Database) *gin.Engine {
 	r := gin.Default()
 
 	// Swagger documentation
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	route.SetupRoutes(r, db)
+	route.SetupRoutes(r, db, cfg)
 
 	return r
 }
+
+func setupScheduler(cfg *config.Config, db *database.Database) *scheduler.Scheduler {
+	s := scheduler.New()
+
+	checkpointService := service.NewCheckpointService(
+		repository.NewAccountRepository(db),
+		repository.NewLedgerRepository(db),
+		repository.NewTransactionRepository(db),
+		repository.NewCheckpointRepository(db),
+		cfg.CheckpointSettleDelay,
+		cfg.DuplicateTransferWindow,
+	)
+	s.Every("balance-checkpoints", cfg.CheckpointInterval, checkpointService.CreateCheckpoints)
+	s.Every("balance-checkpoint-verification", cfg.CheckpointVerifyInterval, checkpointService.VerifyCheckpoints)
+
+	// Both jobs are idempotent per accrual date, so running them more often
+	// than daily only shortens the delay after midnight.
+	interestService := service.NewInterestService(
+		db,
+		repository.NewAccountRepository(db),
+		repository.NewLedgerRepository(db),
+		repository.NewTransactionRepository(db),
+		repository.NewInterestRepository(db),
+		repository.NewPeriodRepository(db),
+	)
+	s.Every("interest-accrual", cfg.InterestAccrualInterval, interestService.AccrueInterest)
+	s.Every("interest-posting", cfg.InterestPostingInterval, interestService.PostInterest)
+
+	auditRunService := service.NewAuditRunService(
+		db,
+		repository.NewAccountRepository(db),
+		repository.NewAuditRunRepository(db),
+		newAuditService(cfg, db),
+	)
+	if err := s.Cron("incremental-audit", cfg.AuditSchedule, auditRunService.RunIncremental); err != nil {
+		log.Printf("Warning: Scheduled audits disabled: %v", err)
+	}
+
+	amlRepo := repository.NewAMLRepository(db)
+	amlSettings := service.DefaultAMLSettings()
+	amlSettings.ReportingThreshold = cfg.AMLReportingThreshold
+	amlSettings.RoundAmountUnit = cfg.AMLRoundAmountUnit
+	amlSettings.LargeCashAmount = cfg.AMLLargeCashAmount
+	if len(cfg.AMLCashEquivalentTypes) > 0 {
+		amlSettings.CashEquivalentTypes = cfg.AMLCashEquivalentTypes
+	}
+	amlService := service.NewAMLService(
+		db,
+		repository.NewAccountRepository(db),
+		repository.NewTransactionRepository(db),
+		repository.NewUserRepository(db),
+		amlRepo,
+		service.NewDefaultAMLRules(amlRepo, amlSettings),
+	)
+	s.Every("aml-monitoring", cfg.AMLMonitorInterval, amlService.Monitor)
+
+	dormancyService := service.NewDormancyService(
+		db,
+		repository.NewAccountRepository(db),
+		cfg.DormancyPeriod,
+		cfg.EscheatmentPeriod,
+	)
+	s.Every("account-dormancy", cfg.DormancyCheckInterval, dormancyService.MarkDormant)
+
+	return s
+}
+
+// newAuditService builds the audit service for scheduled runs. Its fraud case
+// and alert observers are the same as for audits started through the API.
+func newAuditService(cfg *config.Config, db *database.Database) *service.AuditService {
+	accountRepo := repository.NewAccountRepository(db)
+	ledgerRepo := repository.NewLedgerRepository(db)
+
+	detectors := service.NewDefaultDetectorRegistry(
+		ledgerRepo,
+		repository.NewPeriodRepository(db),
+		repository.NewTransactionRepository(db),
+		service.DetectorSettings{
+			DuplicateWindow:   cfg.DuplicateTransferWindow,
+			VelocityMaxDebits: cfg.AuditVelocityMaxDebits,
+			VelocityWindow:    cfg.AuditVelocityWindow,
+		},
+	)
+	if err := detectors.ApplyConfig(cfg.AuditDisabledDetectors, cfg.AuditDetectorSeverities); err != nil {
+		log.Printf("Warning: Invalid audit detector configuration: %v", err)
+	}
+
+	auditService := service.NewAuditService(
+		accountRepo,
+		repository.NewCheckpointRepository(db),
+		ledgerRepo,
+		repository.NewWalletRepository(db),
+		detectors,
+		service.AuditLimits{
+			Concurrency:    cfg.AuditConcurrency,
+			AccountTimeout: cfg.AuditAccountTimeout,
+		},
+	)
+	if severity, err := service.ParseSeverity(cfg.AuditFraudSeverity); err != nil {
+		log.Printf("Warning: Invalid audit fraud severity, using medium: %v", err)
+	} else {
+		auditService.SetFraudSeverity(severity)
+	}
+	auditService.AddObserver(service.NewFraudCaseService(db, accountRepo, repository.NewFraudCaseRepository(db), cfg.FraudAutoFreeze))
+
+	sinks, err := alert.NewSinks(cfg.AlertSinks, alert.Options{
+		FilePath:       cfg.AlertFilePath,
+		WebhookURL:     cfg.AlertWebhookURL,
+		WebhookSecret:  cfg.AlertWebhookSecret,
+		WebhookTimeout: cfg.AlertWebhookTimeout,
+	})
+	if err != nil {
+		log.Printf("Warning: Invalid alert sink configuration, alerts are only logged: %v", err)
+		sinks = []alert.Sink{alert.NewLogSink()}
+	}
+	auditService.AddObserver(service.NewAlertService(repository.NewAuditAlertRepository(db), sinks))
+
+	return auditService
+}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"net/http"
+	"paygo/internal/api/middleware"
+	"paygo/internal/domain/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// authorizeUser lets staff act for any user and everyone else only for
+// themselves. It writes a 403 response when the principal may not.
+func authorizeUser(ctx *gin.Context, userID uuid.UUID) bool {
+	if principal, ok := middleware.Principal(ctx); ok && (principal.IsStaff() || principal.UserID == userID) {
+		return true
+	}
+	ctx.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to act for this user"})
+	return false
+}
+
+// authorizeAccount checks that the principal may move money out of the
+// account. An unknown account is left to the service to report.
+func authorizeAccount(ctx *gin.Context, accountRepo *repository.AccountRepository, accountID uuid.UUID) bool {
+	account, err := accountRepo.FindByIDWithoutEntries(accountID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return true
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	return authorizeUser(ctx, account.UserID)
+}
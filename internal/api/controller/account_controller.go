@@ -0,0 +1,383 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"paygo/internal/api/dto"
+	"paygo/internal/api/middleware"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/database"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AccountController struct {
+	AccountService *service.AccountService
+	BalanceService *service.BalanceService
+}
+
+func NewAccountController(db database.DBManager) *AccountController {
+	accountRepo := repository.NewAccountRepository(db)
+	ledgerRepo := repository.NewLedgerRepository(db)
+	balanceService := service.NewBalanceService(accountRepo, ledgerRepo)
+
+	return &AccountController{
+		AccountService: service.NewAccountService(db, accountRepo, repository.NewUserRepository(db)),
+		BalanceService: balanceService,
+	}
+}
+
+// OpenAccount godoc
+// @Summary Open an account
+// @Description Opens an empty active account for the user under a generated account number. Customers can only open accounts for themselves. The actor is the authenticated user.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param request body dto.OpenAccountRequest true "Owner, type and currency"
+// @Success 201 {object} model.Account "Opened account"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Not allowed to act for this user"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Security BearerAuth
+// @Router /accounts [post]
+func (c *AccountController) OpenAccount(ctx *gin.Context) {
+	var request dto.OpenAccountRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	if !authorizeUser(ctx, request.UserID) {
+		return
+	}
+
+	account, err := c.AccountService.OpenAccount(request.UserID, request.AccountType, request.Currency, actorFromContext(ctx))
+	if err != nil {
+		writeAccountError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, account)
+}
+
+// ListAccounts godoc
+// @Summary List accounts
+// @Description Customers only see their own accounts.
+// @Tags accounts
+// @Produce json
+// @Param user_id query string false "Only accounts of this user"
+// @Param status query string false "active, frozen, blocked, dormant or closed"
+// @Param account_type query string false "checking, savings, wallet or system"
+// @Param limit query int false "Page size (default 50, max 500)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} model.Account "Accounts, oldest first"
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Failure 403 {object} map[string]interface{} "Not allowed to act for this user"
+// @Security BearerAuth
+// @Router /accounts [get]
+func (c *AccountController) ListAccounts(ctx *gin.Context) {
+	filter := repository.AccountFilter{
+		Status:      ctx.Query("status"),
+		AccountType: ctx.Query("account_type"),
+	}
+
+	if value := ctx.Query("user_id"); value != "" {
+		userID, err := uuid.Parse(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+			return
+		}
+		filter.UserID = &userID
+	}
+	if principal, ok := middleware.Principal(ctx); ok && !principal.IsStaff() && filter.UserID == nil {
+		filter.UserID = &principal.UserID
+	}
+	if filter.UserID != nil && !authorizeUser(ctx, *filter.UserID) {
+		return
+	}
+
+	var err error
+	if filter.Limit, err = parseIntQuery(ctx, "limit", 50, 1, 500); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.Offset, err = parseIntQuery(ctx, "offset", 0, 0, -1); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var accounts []model.Account
+	accounts, err = c.AccountService.ListAccounts(filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, accounts)
+}
+
+// GetAccount godoc
+// @Summary Get an account
+// @Tags accounts
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Success 200 {object} model.Account "Account without its ledger entries"
+// @Failure 400 {object} map[string]interface{} "Invalid account ID"
+// @Failure 404 {object} map[string]interface{} "Account not found"
+// @Security BearerAuth
+// @Router /accounts/{accountId} [get]
+func (c *AccountController) GetAccount(ctx *gin.Context) {
+	accountID, ok := parseAccountID(ctx)
+	if !ok {
+		return
+	}
+
+	account, err := c.AccountService.GetAccount(accountID)
+	if err != nil {
+		writeAccountError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, account)
+}
+
+// ChangeStatus godoc
+// @Summary Change an account's status
+// @Description Moves the account between active, frozen, blocked and closed. Closed is final and requires a zero balance. Dormant accounts are returned to active through reactivation. Wallet and system accounts cannot be changed here. The actor is the authenticated user.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param request body dto.ChangeAccountStatusRequest true "New status and reason"
+// @Success 200 {object} model.Account "Updated account"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Account not found"
+// @Failure 409 {object} map[string]interface{} "Transition not allowed or balance not zero"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/status [post]
+func (c *AccountController) ChangeStatus(ctx *gin.Context) {
+	accountID, ok := parseAccountID(ctx)
+	if !ok {
+		return
+	}
+
+	var request dto.ChangeAccountStatusRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	account, err := c.AccountService.ChangeStatus(accountID, request.Status, request.Reason, actorFromContext(ctx))
+	if err != nil {
+		writeAccountError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, account)
+}
+
+// GetBalance godoc
+// @Summary Get an account balance at a point in time
+// @Description Returns the balance of an account as of the given time, either by booking time or by value date. A date means the end of that day in UTC, so entries booked or valued on it are included on either basis.
+// @Tags accounts
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param as_of query string false "Point in time (RFC 3339 or YYYY-MM-DD for the end of that day), defaults to now"
+// @Param basis query string false "booking (default) or value"
+// @Success 200 {object} service.PointInTimeBalance "Balance"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Account not found"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/balance [get]
+func (c *AccountController) GetBalance(ctx *gin.Context) {
+	accountID, err := uuid.Parse(ctx.Param("accountId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	asOf, err := parseAsOfQuery(ctx, "as_of", time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid as_of, expected RFC 3339 timestamp or date"})
+		return
+	}
+
+	basis, err := service.ParseBalanceBasis(ctx.Query("basis"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	balance, err := c.BalanceService.BalanceAsOf(accountID, asOf, basis)
+	if err != nil {
+		if errors.Is(err, service.ErrAccountNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, balance)
+}
+
+// ListLedgerEntries godoc
+// @Summary Page through an account's ledger
+// @Description Returns the account's ledger entries in booking order. Pass next_cursor from the response as cursor to fetch the following page.
+// @Tags accounts
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param cursor query string false "Cursor returned with the previous page"
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Success 200 {object} service.LedgerPage "Ledger page"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Account not found"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/ledger-entries [get]
+func (c *AccountController) ListLedgerEntries(ctx *gin.Context) {
+	accountID, err := uuid.Parse(ctx.Param("accountId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	limit, err := parseIntQuery(ctx, "limit", 100, 1, 1000)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := c.BalanceService.LedgerEntries(accountID, ctx.Query("cursor"), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrAccountNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrInvalidCursor):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// GetBalances godoc
+// @Summary Get balances of many accounts at a point in time
+// @Description Returns the balances of the given accounts as of the same point in time, for month-end reporting. A date-only as_of means the end of that day in UTC, so entries booked or valued on it are included on either basis.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param request body dto.BulkBalanceRequest true "Account IDs, point in time and basis"
+// @Success 200 {object} map[string]interface{} "Balances and unknown account IDs"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Security BearerAuth
+// @Router /accounts/balances [post]
+func (c *AccountController) GetBalances(ctx *gin.Context) {
+	var request dto.BulkBalanceRequest
+
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	asOf := time.Now()
+	if request.AsOf != "" {
+		var err error
+		if asOf, err = parseAsOf(request.AsOf); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid as_of, expected RFC 3339 timestamp or date"})
+			return
+		}
+	}
+
+	basis, err := service.ParseBalanceBasis(request.Basis)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	balances, missing, err := c.BalanceService.BalancesAsOf(request.AccountIDs, asOf, basis)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"as_of":     asOf,
+		"basis":     basis,
+		"total":     len(balances),
+		"balances":  balances,
+		"not_found": missing,
+	})
+}
+
+func writeAccountError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrAccountNotFound), errors.Is(err, service.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidStatusTransition),
+		errors.Is(err, service.ErrAccountBalanceNotZero),
+		errors.Is(err, service.ErrAccountStatusNotManaged):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseTimeQuery parses an RFC 3339 timestamp or a YYYY-MM-DD date (midnight
+// UTC) query parameter, returning fallback when it is absent.
+func parseTimeQuery(ctx *gin.Context, key string, fallback time.Time) (time.Time, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return fallback, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseAsOfQuery parses a point-in-time query parameter with parseAsOf,
+// returning fallback when it is absent.
+func parseAsOfQuery(ctx *gin.Context, key string, fallback time.Time) (time.Time, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return fallback, nil
+	}
+	return parseAsOf(value)
+}
+
+// parseAsOf parses an RFC 3339 timestamp or a YYYY-MM-DD date. A date means
+// the end of that day in UTC: the last microsecond, the precision the ledger
+// stores, before the next midnight. Entries booked or valued on that day are
+// then included on both balance bases.
+func parseAsOf(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Microsecond), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseIntQuery reads an integer query parameter within [minValue, maxValue];
+// a negative maxValue means unbounded.
+func parseIntQuery(ctx *gin.Context, key string, fallback, minValue, maxValue int) (int, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < minValue || (maxValue >= 0 && n > maxValue) {
+		if maxValue >= 0 {
+			return 0, fmt.Errorf("invalid %s, expected an integer between %d and %d", key, minValue, maxValue)
+		}
+		return 0, fmt.Errorf("invalid %s, expected an integer of at least %d", key, minValue)
+	}
+	return n, nil
+}
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"paygo/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// actorFromContext identifies who performed an action, for audit trails.
+func actorFromContext(ctx *gin.Context) string {
+	if principal, ok := middleware.Principal(ctx); ok {
+		return principal.Email
+	}
+	return "unknown"
+}
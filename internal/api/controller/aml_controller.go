@@ -0,0 +1,358 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"paygo/internal/api/dto"
+	"paygo/internal/config"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AMLController struct {
+	AMLService *service.AMLService
+}
+
+func NewAMLController(db database.DBManager, cfg *config.Config) *AMLController {
+	amlRepo := repository.NewAMLRepository(db)
+
+	settings := service.DefaultAMLSettings()
+	settings.ReportingThreshold = cfg.AMLReportingThreshold
+	settings.RoundAmountUnit = cfg.AMLRoundAmountUnit
+	settings.LargeCashAmount = cfg.AMLLargeCashAmount
+	if len(cfg.AMLCashEquivalentTypes) > 0 {
+		settings.CashEquivalentTypes = cfg.AMLCashEquivalentTypes
+	}
+
+	return &AMLController{
+		AMLService: service.NewAMLService(
+			db,
+			repository.NewAccountRepository(db),
+			repository.NewTransactionRepository(db),
+			repository.NewUserRepository(db),
+			amlRepo,
+			service.NewDefaultAMLRules(amlRepo, settings),
+		),
+	}
+}
+
+// Scan godoc
+// @Summary Run the AML monitoring rules now
+// @Description Evaluates the structuring, pass-through, round-amount and large cash-equivalent rules over their windows ending now. Hits open alerts, or add new transactions to the open alert of the same rule and account.
+// @Tags aml
+// @Produce json
+// @Success 200 {object} service.AMLScanResult "Alerts created or updated"
+// @Security BearerAuth
+// @Router /aml/scan [post]
+func (c *AMLController) Scan(ctx *gin.Context) {
+	result, err := c.AMLService.Scan(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// ListAlerts godoc
+// @Summary List AML alerts
+// @Tags aml
+// @Produce json
+// @Param status query string false "open, dismissed or escalated"
+// @Param rule query string false "structuring, pass_through, round_amounts or large_cash_equivalent"
+// @Param account_id query string false "Only alerts on this account"
+// @Param limit query int false "Page size (default 50, max 500)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} model.AMLAlert "Alerts, newest first"
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Security BearerAuth
+// @Router /aml/alerts [get]
+func (c *AMLController) ListAlerts(ctx *gin.Context) {
+	filter := repository.AMLAlertFilter{
+		Status: ctx.Query("status"),
+		Rule:   ctx.Query("rule"),
+	}
+
+	if value := ctx.Query("account_id"); value != "" {
+		accountID, err := uuid.Parse(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+			return
+		}
+		filter.AccountID = &accountID
+	}
+
+	var err error
+	if filter.Limit, err = parseIntQuery(ctx, "limit", 50, 1, 500); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.Offset, err = parseIntQuery(ctx, "offset", 0, 0, -1); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var alerts []model.AMLAlert
+	alerts, err = c.AMLService.ListAlerts(filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alerts)
+}
+
+// GetAlert godoc
+// @Summary Get an AML alert
+// @Tags aml
+// @Produce json
+// @Param alertId path string true "Alert ID"
+// @Success 200 {object} model.AMLAlert "Alert"
+// @Failure 400 {object} map[string]interface{} "Invalid alert ID"
+// @Failure 404 {object} map[string]interface{} "Alert not found"
+// @Security BearerAuth
+// @Router /aml/alerts/{alertId} [get]
+func (c *AMLController) GetAlert(ctx *gin.Context) {
+	alertID, ok := parseAlertID(ctx)
+	if !ok {
+		return
+	}
+
+	alert, err := c.AMLService.GetAlert(alertID)
+	if err != nil {
+		writeAMLError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alert)
+}
+
+// DismissAlert godoc
+// @Summary Dismiss an AML alert
+// @Description Closes an open alert as not suspicious. Its transactions do not raise the rule again. The actor is the authenticated user.
+// @Tags aml
+// @Accept json
+// @Produce json
+// @Param alertId path string true "Alert ID"
+// @Param request body dto.DismissAMLAlertRequest true "Reason"
+// @Success 200 {object} model.AMLAlert "Dismissed alert"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Alert not found"
+// @Failure 409 {object} map[string]interface{} "Alert is not open"
+// @Security BearerAuth
+// @Router /aml/alerts/{alertId}/dismiss [post]
+func (c *AMLController) DismissAlert(ctx *gin.Context) {
+	alertID, ok := parseAlertID(ctx)
+	if !ok {
+		return
+	}
+
+	var request dto.DismissAMLAlertRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	alert, err := c.AMLService.DismissAlert(alertID, request.Note, actorFromContext(ctx))
+	if err != nil {
+		writeAMLError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alert)
+}
+
+// CreateReport godoc
+// @Summary Escalate AML alerts into a suspicious activity report
+// @Description Opens a draft report for the given open alerts and marks them escalated. The actor is the authenticated user.
+// @Tags aml
+// @Accept json
+// @Produce json
+// @Param request body dto.EscalateAMLAlertsRequest true "Alerts and narrative"
+// @Success 201 {object} model.SuspiciousActivityReport "Draft report"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Alert not found"
+// @Failure 409 {object} map[string]interface{} "Alert is not open"
+// @Security BearerAuth
+// @Router /aml/reports [post]
+func (c *AMLController) CreateReport(ctx *gin.Context) {
+	var request dto.EscalateAMLAlertsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	report, err := c.AMLService.Escalate(request.AlertIDs, request.Narrative, actorFromContext(ctx))
+	if err != nil {
+		writeAMLError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, report)
+}
+
+// ListReports godoc
+// @Summary List suspicious activity reports
+// @Tags aml
+// @Produce json
+// @Param status query string false "draft or filed"
+// @Param limit query int false "Page size (default 50, max 500)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} model.SuspiciousActivityReport "Reports, newest first, without their alerts"
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Security BearerAuth
+// @Router /aml/reports [get]
+func (c *AMLController) ListReports(ctx *gin.Context) {
+	limit, err := parseIntQuery(ctx, "limit", 50, 1, 500)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	offset, err := parseIntQuery(ctx, "offset", 0, 0, -1)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var reports []model.SuspiciousActivityReport
+	reports, err = c.AMLService.ListReports(ctx.Query("status"), limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
+
+// GetReport godoc
+// @Summary Get a suspicious activity report
+// @Tags aml
+// @Produce json
+// @Param reportId path string true "Report ID"
+// @Success 200 {object} model.SuspiciousActivityReport "Report with its alerts"
+// @Failure 400 {object} map[string]interface{} "Invalid report ID"
+// @Failure 404 {object} map[string]interface{} "Report not found"
+// @Security BearerAuth
+// @Router /aml/reports/{reportId} [get]
+func (c *AMLController) GetReport(ctx *gin.Context) {
+	reportID, ok := parseReportID(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := c.AMLService.GetReport(reportID)
+	if err != nil {
+		writeAMLError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// FileReport godoc
+// @Summary File a suspicious activity report
+// @Description Marks a draft report as filed with the regulator. Filed reports cannot be changed. The actor is the authenticated user.
+// @Tags aml
+// @Produce json
+// @Param reportId path string true "Report ID"
+// @Success 200 {object} model.SuspiciousActivityReport "Filed report"
+// @Failure 400 {object} map[string]interface{} "Invalid report ID"
+// @Failure 404 {object} map[string]interface{} "Report not found"
+// @Failure 409 {object} map[string]interface{} "Report already filed"
+// @Security BearerAuth
+// @Router /aml/reports/{reportId}/file [post]
+func (c *AMLController) FileReport(ctx *gin.Context) {
+	reportID, ok := parseReportID(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := c.AMLService.FileReport(reportID, actorFromContext(ctx))
+	if err != nil {
+		writeAMLError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// ExportReport godoc
+// @Summary Export a suspicious activity report
+// @Description Exports the report with the involved users, accounts and transactions. CSV has one row per transaction with both parties.
+// @Tags aml
+// @Produce json
+// @Produce text/csv
+// @Param reportId path string true "Report ID"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} service.SARExport "Report export"
+// @Failure 400 {object} map[string]interface{} "Invalid report ID or format"
+// @Failure 404 {object} map[string]interface{} "Report not found"
+// @Security BearerAuth
+// @Router /aml/reports/{reportId}/export [get]
+func (c *AMLController) ExportReport(ctx *gin.Context) {
+	reportID, ok := parseReportID(ctx)
+	if !ok {
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, expected json or csv"})
+		return
+	}
+
+	export, err := c.AMLService.ExportReport(reportID)
+	if err != nil {
+		writeAMLError(ctx, err)
+		return
+	}
+
+	filename := fmt.Sprintf("%s.%s", export.Report.Reference, format)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	if format == "csv" {
+		ctx.Header("Content-Type", "text/csv; charset=utf-8")
+		ctx.Status(http.StatusOK)
+		if err := service.WriteSARCSV(ctx.Writer, export); err != nil {
+			_ = ctx.Error(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, export)
+}
+
+func parseAlertID(ctx *gin.Context) (uuid.UUID, bool) {
+	alertID, err := uuid.Parse(ctx.Param("alertId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid alert ID"})
+		return uuid.Nil, false
+	}
+	return alertID, true
+}
+
+func parseReportID(ctx *gin.Context) (uuid.UUID, bool) {
+	reportID, err := uuid.Parse(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return uuid.Nil, false
+	}
+	return reportID, true
+}
+
+func writeAMLError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrAMLAlertNotFound), errors.Is(err, service.ErrSARNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrAMLAlertNotOpen), errors.Is(err, service.ErrSARFiled):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
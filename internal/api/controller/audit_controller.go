@@ -1,25 +1,81 @@
 package controller
 
 import (
+	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"paygo/internal/api/dto"
+	"paygo/internal/config"
+	"paygo/internal/domain/model"
 	"paygo/internal/domain/repository"
 	"paygo/internal/domain/service"
+	"paygo/internal/infra/alert"
 	"paygo/internal/infra/database"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type AuditController struct {
-	AuditService *service.AuditService
+	AuditService            *service.AuditService
+	AuditRunService         *service.AuditRunService
+	TransactionAuditService *service.TransactionAuditService
+	AlertService            *service.AlertService
+	TimelineService         *service.TimelineService
 }
 
-func NewAuditController(db database.DBManager) *AuditController {
+func NewAuditController(db database.DBManager, cfg *config.Config) *AuditController {
 	accountRepo := repository.NewAccountRepository(db)
-	auditService := service.NewAuditService(accountRepo)
+	checkpointRepo := repository.NewCheckpointRepository(db)
+	ledgerRepo := repository.NewLedgerRepository(db)
+	periodRepo := repository.NewPeriodRepository(db)
+	walletRepo := repository.NewWalletRepository(db)
+	auditRunRepo := repository.NewAuditRunRepository(db)
+	transactionRepo := repository.NewTransactionRepository(db)
+
+	detectors := service.NewDefaultDetectorRegistry(ledgerRepo, periodRepo, transactionRepo, service.DetectorSettings{
+		DuplicateWindow:   cfg.DuplicateTransferWindow,
+		VelocityMaxDebits: cfg.AuditVelocityMaxDebits,
+		VelocityWindow:    cfg.AuditVelocityWindow,
+	})
+	if err := detectors.ApplyConfig(cfg.AuditDisabledDetectors, cfg.AuditDetectorSeverities); err != nil {
+		log.Printf("Warning: Invalid audit detector configuration: %v", err)
+	}
+
+	auditService := service.NewAuditService(accountRepo, checkpointRepo, ledgerRepo, walletRepo, detectors, service.AuditLimits{
+		Concurrency:    cfg.AuditConcurrency,
+		AccountTimeout: cfg.AuditAccountTimeout,
+	})
+	if severity, err := service.ParseSeverity(cfg.AuditFraudSeverity); err != nil {
+		log.Printf("Warning: Invalid audit fraud severity, using medium: %v", err)
+	} else {
+		auditService.SetFraudSeverity(severity)
+	}
+
+	fraudCaseService := service.NewFraudCaseService(db, accountRepo, repository.NewFraudCaseRepository(db), cfg.FraudAutoFreeze)
+	auditService.AddObserver(fraudCaseService)
+
+	sinks, err := alert.NewSinks(cfg.AlertSinks, alert.Options{
+		FilePath:       cfg.AlertFilePath,
+		WebhookURL:     cfg.AlertWebhookURL,
+		WebhookSecret:  cfg.AlertWebhookSecret,
+		WebhookTimeout: cfg.AlertWebhookTimeout,
+	})
+	if err != nil {
+		log.Printf("Warning: Invalid alert sink configuration, alerts are only logged: %v", err)
+		sinks = []alert.Sink{alert.NewLogSink()}
+	}
+	alertService := service.NewAlertService(repository.NewAuditAlertRepository(db), sinks)
+	auditService.AddObserver(alertService)
 
 	return &AuditController{
-		AuditService: auditService,
+		AuditService:            auditService,
+		AuditRunService:         service.NewAuditRunService(db, accountRepo, auditRunRepo, auditService),
+		TransactionAuditService: service.NewTransactionAuditService(transactionRepo, ledgerRepo),
+		AlertService:            alertService,
+		TimelineService:         service.NewTimelineService(accountRepo, ledgerRepo),
 	}
 }
 
@@ -32,6 +88,7 @@ func NewAuditController(db database.DBManager) *AuditController {
 // @Param accountId path string true "Account ID"
 // @Success 200 {object} service.AuditResult "Audit result"
 // @Failure 400 {object} map[string]interface{} "Invalid account ID"
+// @Security BearerAuth
 // @Router /audit/accounts/{accountId} [get]
 func (c *AuditController) AuditAccount(ctx *gin.Context) {
 	accountIDParam := ctx.Param("accountId")
@@ -51,15 +108,103 @@ func (c *AuditController) AuditAccount(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, result)
 }
 
+// AccountTimeline godoc
+// @Summary Replay an account's ledger to find where a discrepancy started
+// @Description Replays the account's ledger entries in booking order from its first entry and compares each replayed balance with the stored running balance. Returns the first entry where they diverge and the entries around it with their transactions and counterparties. Pass from to page through the replay instead.
+// @Tags audit
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param context query int false "Entries on either side of the first divergence (default 5, max 50)"
+// @Param from query int false "Return entries starting at this position (1 is the first entry) instead"
+// @Param limit query int false "Entries to return with from (default 50, max 500)"
+// @Success 200 {object} service.AccountTimeline "Timeline"
+// @Failure 400 {object} map[string]interface{} "Invalid account ID or query"
+// @Failure 404 {object} map[string]interface{} "Account not found"
+// @Security BearerAuth
+// @Router /audit/accounts/{accountId}/timeline [get]
+func (c *AuditController) AccountTimeline(ctx *gin.Context) {
+	accountID, err := uuid.Parse(ctx.Param("accountId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	var window service.TimelineWindow
+	var from int
+	if window.Context, err = parseIntQuery(ctx, "context", 5, 0, 50); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if from, err = parseIntQuery(ctx, "from", 0, 1, -1); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if window.Limit, err = parseIntQuery(ctx, "limit", 50, 1, 500); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	window.From = int64(from)
+
+	timeline, err := c.TimelineService.Timeline(ctx.Request.Context(), accountID, window)
+	if err != nil {
+		if errors.Is(err, service.ErrAccountNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timeline)
+}
+
+// AuditWallet godoc
+// @Summary Audit a wallet
+// @Description Audit the ledger account backing a wallet, exactly as accounts are audited
+// @Tags audit
+// @Produce json
+// @Param walletId path string true "Wallet ID"
+// @Success 200 {object} service.AuditResult "Audit result"
+// @Failure 400 {object} map[string]interface{} "Invalid wallet ID"
+// @Failure 404 {object} map[string]interface{} "Wallet not found"
+// @Security BearerAuth
+// @Router /audit/wallets/{walletId} [get]
+func (c *AuditController) AuditWallet(ctx *gin.Context) {
+	walletID, err := uuid.Parse(ctx.Param("walletId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wallet ID"})
+		return
+	}
+
+	result, err := c.AuditService.AuditWallet(walletID)
+	if err != nil {
+		if errors.Is(err, service.ErrWalletNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if result.Status == service.AuditStatusIncomplete {
+		ctx.JSON(http.StatusNotFound, result)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
 // AuditAccounts godoc
 // @Summary Audit multiple accounts
-// @Description Audit multiple accounts concurrently to detect fraud
+// @Description Audit multiple accounts concurrently to detect fraud. Results are returned in request order, or with stream=true written as NDJSON lines in completion order as each audit finishes, carrying the index of the account in the request.
 // @Tags audit
 // @Accept json
-// @Produce json
+// @Produce json,application/x-ndjson
 // @Param accountIds body []string true "Array of Account IDs"
+// @Param stream query bool false "Stream results as NDJSON"
 // @Success 200 {array} service.AuditResult "Array of audit results"
 // @Failure 400 {object} map[string]interface{} "Invalid request body or account IDs"
+// @Security BearerAuth
 // @Router /audit/accounts [post]
 func (c *AuditController) AuditAccounts(ctx *gin.Context) {
 	var accountIDStrings []string
@@ -84,10 +229,340 @@ func (c *AuditController) AuditAccounts(ctx *gin.Context) {
 		accountIDs = append(accountIDs, accountID)
 	}
 
-	results := c.AuditService.AuditAccounts(accountIDs)
+	if ctx.Query("stream") == "true" {
+		c.streamAudits(ctx, accountIDs)
+		return
+	}
+
+	results := c.AuditService.AuditAccounts(ctx.Request.Context(), accountIDs)
 
 	ctx.JSON(http.StatusOK, gin.H{
 		"total":   len(results),
 		"results": results,
 	})
 }
+
+// streamAudits writes one JSON line per audit as it finishes. The status is
+// already sent by then, so failures can only end the stream early.
+func (c *AuditController) streamAudits(ctx *gin.Context, accountIDs []uuid.UUID) {
+	ctx.Header("Content-Type", "application/x-ndjson")
+	ctx.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(ctx.Writer)
+	_ = c.AuditService.StreamAudits(ctx.Request.Context(), accountIDs, func(result service.IndexedAuditResult) error {
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+		ctx.Writer.Flush()
+		return nil
+	})
+}
+
+// ListDetectors godoc
+// @Summary List audit detectors
+// @Description Lists the registered fraud detectors with their fraud type, whether they are enabled and the severity of their findings
+// @Tags audit
+// @Produce json
+// @Success 200 {array} service.DetectorInfo "Detectors in execution order"
+// @Security BearerAuth
+// @Router /audit/detectors [get]
+func (c *AuditController) ListDetectors(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.AuditService.Detectors())
+}
+
+// AuditBalances godoc
+// @Summary Check every account balance against the ledger
+// @Description Compares each account's stored balance with its latest verified checkpoint plus the entries booked after it, for all accounts in one grouped query. Detectors are not run; start an audit run for the full checks.
+// @Tags audit
+// @Produce json
+// @Param mismatched_only query bool false "Only list accounts whose balance or last running balance differs from the ledger (default true)"
+// @Success 200 {object} map[string]interface{} "Count and balance audits"
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Security BearerAuth
+// @Router /audit/balances [get]
+func (c *AuditController) AuditBalances(ctx *gin.Context) {
+	mismatchedOnly, err := strconv.ParseBool(ctx.DefaultQuery("mismatched_only", "true"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "mismatched_only must be a boolean"})
+		return
+	}
+
+	audits, err := c.AuditService.AuditBalances(ctx.Request.Context(), mismatchedOnly)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"count":    len(audits),
+		"accounts": audits,
+	})
+}
+
+// AuditTransactions godoc
+// @Summary Audit transactions
+// @Description Verifies that each transaction's legs net to zero in a single currency and match its amount and currency, and looks for ledger entries without a transaction. Select transactions either by ID or by creation time range [from, to). Only failing transactions are listed.
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param request body dto.AuditTransactionsRequest true "Transaction IDs or time range"
+// @Success 200 {object} service.TransactionAuditReport "Audit report"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Security BearerAuth
+// @Router /audit/transactions [post]
+func (c *AuditController) AuditTransactions(ctx *gin.Context) {
+	var request dto.AuditTransactionsRequest
+
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	byID := len(request.TransactionIDs) > 0
+	byRange := request.From != nil || request.To != nil
+
+	var report *service.TransactionAuditReport
+	var err error
+
+	switch {
+	case byID && byRange:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Provide either transaction_ids or from/to, not both"})
+		return
+	case byID:
+		report, err = c.TransactionAuditService.AuditTransactions(ctx.Request.Context(), request.TransactionIDs)
+	case request.From != nil && request.To != nil:
+		report, err = c.TransactionAuditService.AuditTransactionsBetween(ctx.Request.Context(), *request.From, *request.To)
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Provide transaction_ids or both from and to"})
+		return
+	}
+
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// StartRun godoc
+// @Summary Start a full-ledger audit run
+// @Description Audits every account in the background and persists a finding per account. Poll the run for progress. An incremental run only audits the accounts changed since the last completed run.
+// @Tags audit
+// @Produce json
+// @Param mode query string false "full (default) or incremental"
+// @Success 202 {object} model.AuditRun "Started run"
+// @Failure 400 {object} map[string]interface{} "Invalid mode"
+// @Failure 409 {object} map[string]interface{} "A run is already in progress"
+// @Security BearerAuth
+// @Router /audit/runs [post]
+func (c *AuditController) StartRun(ctx *gin.Context) {
+	mode := ctx.DefaultQuery("mode", service.AuditRunFull)
+	if mode != service.AuditRunFull && mode != service.AuditRunIncremental {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "mode must be full or incremental"})
+		return
+	}
+
+	run, err := c.AuditRunService.StartRun(actorFromContext(ctx), mode == service.AuditRunIncremental)
+	if err != nil {
+		if errors.Is(err, service.ErrAuditRunInProgress) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, run)
+}
+
+// ListRuns godoc
+// @Summary List audit runs
+// @Tags audit
+// @Produce json
+// @Param limit query int false "Maximum number of runs (default 20, max 100)"
+// @Success 200 {array} model.AuditRun "Runs, latest first"
+// @Security BearerAuth
+// @Router /audit/runs [get]
+func (c *AuditController) ListRuns(ctx *gin.Context) {
+	limit, err := parseIntQuery(ctx, "limit", 20, 1, 100)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var runs []model.AuditRun
+	runs, err = c.AuditRunService.ListRuns(limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, runs)
+}
+
+// GetRun godoc
+// @Summary Get the progress of an audit run
+// @Tags audit
+// @Produce json
+// @Param runId path string true "Audit run ID"
+// @Success 200 {object} model.AuditRun "Run with progress counters"
+// @Failure 404 {object} map[string]interface{} "Run not found"
+// @Security BearerAuth
+// @Router /audit/runs/{runId} [get]
+func (c *AuditController) GetRun(ctx *gin.Context) {
+	runID, err := uuid.Parse(ctx.Param("runId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid run ID"})
+		return
+	}
+
+	run, err := c.AuditRunService.GetRun(runID)
+	if err != nil {
+		writeAuditRunError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, run)
+}
+
+// ListFindings godoc
+// @Summary List the findings of an audit run
+// @Tags audit
+// @Produce json
+// @Param runId path string true "Audit run ID"
+// @Param status query string false "VALID, SUSPICIOUS, FRAUDULENT or INCOMPLETE"
+// @Param fraud_type query string false "Only findings with this fraud type"
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} map[string]interface{} "Total count and page of findings"
+// @Failure 404 {object} map[string]interface{} "Run not found"
+// @Security BearerAuth
+// @Router /audit/runs/{runId}/findings [get]
+func (c *AuditController) ListFindings(ctx *gin.Context) {
+	runID, err := uuid.Parse(ctx.Param("runId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid run ID"})
+		return
+	}
+
+	filter := repository.FindingFilter{
+		Status:    ctx.Query("status"),
+		FraudType: ctx.Query("fraud_type"),
+	}
+	if filter.Limit, err = parseIntQuery(ctx, "limit", 100, 1, 1000); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.Offset, err = parseIntQuery(ctx, "offset", 0, 0, -1); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	findings, total, err := c.AuditRunService.Findings(runID, filter)
+	if err != nil {
+		writeAuditRunError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"total":    total,
+		"limit":    filter.Limit,
+		"offset":   filter.Offset,
+		"findings": findings,
+	})
+}
+
+// CompareRuns godoc
+// @Summary Compare two audit runs
+// @Description Lists the accounts whose findings differ between the base run and the target run: added, removed, regressed, resolved or changed
+// @Tags audit
+// @Produce json
+// @Param runId path string true "Base audit run ID"
+// @Param targetRunId path string true "Target audit run ID"
+// @Success 200 {object} service.AuditRunComparison "Differences between the runs"
+// @Failure 404 {object} map[string]interface{} "Run not found"
+// @Security BearerAuth
+// @Router /audit/runs/{runId}/compare/{targetRunId} [get]
+func (c *AuditController) CompareRuns(ctx *gin.Context) {
+	baseRunID, err := uuid.Parse(ctx.Param("runId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid run ID"})
+		return
+	}
+
+	targetRunID, err := uuid.Parse(ctx.Param("targetRunId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target run ID"})
+		return
+	}
+
+	comparison, err := c.AuditRunService.CompareRuns(baseRunID, targetRunID)
+	if err != nil {
+		writeAuditRunError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comparison)
+}
+
+func writeAuditRunError(ctx *gin.Context, err error) {
+	if errors.Is(err, service.ErrAuditRunNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// ListAlerts godoc
+// @Summary List audit alerts
+// @Description Lists the fraudulent findings sent to the alert sinks, latest sighting first. A finding alerts once until an audit no longer reports it.
+// @Tags audit
+// @Produce json
+// @Param account_id query string false "Account ID"
+// @Param resolved query bool false "Only resolved (true) or unresolved (false) alerts"
+// @Param limit query int false "Maximum number of alerts (default 50, max 500)"
+// @Param offset query int false "Number of alerts to skip"
+// @Success 200 {array} model.AuditAlert "Alerts"
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Security BearerAuth
+// @Router /audit/alerts [get]
+func (c *AuditController) ListAlerts(ctx *gin.Context) {
+	var filter repository.AuditAlertFilter
+
+	if value := ctx.Query("account_id"); value != "" {
+		accountID, err := uuid.Parse(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+			return
+		}
+		filter.AccountID = &accountID
+	}
+	if value := ctx.Query("resolved"); value != "" {
+		resolved, err := strconv.ParseBool(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "resolved must be a boolean"})
+			return
+		}
+		filter.Resolved = &resolved
+	}
+
+	var err error
+	if filter.Limit, err = parseIntQuery(ctx, "limit", 50, 1, 500); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.Offset, err = parseIntQuery(ctx, "offset", 0, 0, -1); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var alerts []model.AuditAlert
+	alerts, err = c.AlertService.ListAlerts(filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alerts)
+}
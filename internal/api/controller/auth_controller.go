@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"paygo/internal/api/dto"
+	"paygo/internal/api/middleware"
+	"paygo/internal/config"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthController struct {
+	AuthService *service.AuthService
+}
+
+func NewAuthController(db database.DBManager, cfg *config.Config) *AuthController {
+	return &AuthController{
+		AuthService: service.NewAuthService(
+			db,
+			repository.NewAuthRepository(db),
+			repository.NewUserRepository(db),
+			cfg.JWTSecret,
+			cfg.AccessTokenTTL,
+			cfg.RefreshTokenTTL,
+		),
+	}
+}
+
+// Register godoc
+// @Summary Register a user
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RegisterRequest true "Email, password and name"
+// @Success 201 {object} model.User "Registered user"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Email already registered"
+// @Router /auth/register [post]
+func (c *AuthController) Register(ctx *gin.Context) {
+	var request dto.RegisterRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	var user *model.User
+	user, err := c.AuthService.Register(request.Email, request.Password, request.FirstName, request.LastName)
+	if err != nil {
+		writeAuthError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, user)
+}
+
+// Login godoc
+// @Summary Log in with email and password
+// @Description Starts a session and returns a short-lived access token with a refresh token.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LoginRequest true "Credentials"
+// @Success 200 {object} service.TokenPair "Tokens"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Invalid email or password"
+// @Failure 403 {object} map[string]interface{} "User is not active"
+// @Router /auth/login [post]
+func (c *AuthController) Login(ctx *gin.Context) {
+	var request dto.LoginRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	pair, err := c.AuthService.Login(request.Email, request.Password)
+	if err != nil {
+		writeAuthError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pair)
+}
+
+// Refresh godoc
+// @Summary Exchange a refresh token for new tokens
+// @Description The refresh token is single use. Presenting one that was already exchanged ends the session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} service.TokenPair "New tokens"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Invalid or expired refresh token"
+// @Failure 403 {object} map[string]interface{} "User is not active"
+// @Router /auth/refresh [post]
+func (c *AuthController) Refresh(ctx *gin.Context) {
+	var request dto.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	pair, err := c.AuthService.Refresh(request.RefreshToken)
+	if err != nil {
+		writeAuthError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pair)
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Revokes the session of the refresh token. Its access tokens stop working immediately.
+// @Tags auth
+// @Accept json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 204 "Logged out"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Router /auth/logout [post]
+func (c *AuthController) Logout(ctx *gin.Context) {
+	var request dto.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if err := c.AuthService.Logout(request.RefreshToken); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// LogoutAll godoc
+// @Summary Log out everywhere
+// @Description Revokes every session of the authenticated user.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Number of revoked tokens"
+// @Failure 401 {object} map[string]interface{} "Not authenticated"
+// @Router /auth/logout-all [post]
+func (c *AuthController) LogoutAll(ctx *gin.Context) {
+	principal, _ := middleware.Principal(ctx)
+
+	revoked, err := c.AuthService.LogoutAll(principal.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
+// Me godoc
+// @Summary Get the authenticated user
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "User ID, email, role and session"
+// @Failure 401 {object} map[string]interface{} "Not authenticated"
+// @Router /auth/me [get]
+func (c *AuthController) Me(ctx *gin.Context) {
+	principal, _ := middleware.Principal(ctx)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"user_id":    principal.UserID,
+		"email":      principal.Email,
+		"role":       principal.Role,
+		"session_id": principal.SessionID,
+	})
+}
+
+func writeAuthError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEmailTaken):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidCredentials), errors.Is(err, service.ErrInvalidRefreshToken):
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUserInactive):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"paygo/internal/api/dto"
+	"paygo/internal/config"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type DormancyController struct {
+	DormancyService *service.DormancyService
+}
+
+func NewDormancyController(db database.DBManager, cfg *config.Config) *DormancyController {
+	return &DormancyController{
+		DormancyService: service.NewDormancyService(
+			db,
+			repository.NewAccountRepository(db),
+			cfg.DormancyPeriod,
+			cfg.EscheatmentPeriod,
+		),
+	}
+}
+
+// ListDormant godoc
+// @Summary List dormant accounts
+// @Description Dormant accounts accept incoming transfers but cannot send until they are reactivated.
+// @Tags accounts
+// @Produce json
+// @Param limit query int false "Page size (default 50, max 500)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} repository.AccountActivity "Dormant accounts with their last activity"
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Security BearerAuth
+// @Router /accounts/dormant [get]
+func (c *DormancyController) ListDormant(ctx *gin.Context) {
+	limit, err := parseIntQuery(ctx, "limit", 50, 1, 500)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	offset, err := parseIntQuery(ctx, "offset", 0, 0, -1)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var accounts []repository.AccountActivity
+	accounts, err = c.DormancyService.ListDormant(limit, offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, accounts)
+}
+
+// EscheatmentReport godoc
+// @Summary Report dormant accounts due for escheatment
+// @Description Lists dormant accounts with a non-zero balance and no activity for the escheatment period, with totals per currency.
+// @Tags accounts
+// @Produce json
+// @Success 200 {object} service.EscheatmentReport "Escheatment report"
+// @Security BearerAuth
+// @Router /accounts/escheatment [get]
+func (c *DormancyController) EscheatmentReport(ctx *gin.Context) {
+	report, err := c.DormancyService.EscheatmentReport()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// Reactivate godoc
+// @Summary Reactivate a dormant account
+// @Description Returns a dormant account to active after the owner's identity was verified. The actor is the authenticated user.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param request body dto.ReactivateAccountRequest true "Verification"
+// @Success 200 {object} model.Account "Reactivated account"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Account not found"
+// @Failure 409 {object} map[string]interface{} "Account is not dormant"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/reactivate [post]
+func (c *DormancyController) Reactivate(ctx *gin.Context) {
+	accountID, ok := parseAccountID(ctx)
+	if !ok {
+		return
+	}
+
+	var request dto.ReactivateAccountRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	account, err := c.DormancyService.Reactivate(accountID, request.VerificationMethod, request.VerificationReference, request.Note, actorFromContext(ctx))
+	if err != nil {
+		writeDormancyError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, account)
+}
+
+// StatusHistory godoc
+// @Summary Get an account's status history
+// @Tags accounts
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Success 200 {array} model.AccountStatusChange "Status changes, oldest first"
+// @Failure 400 {object} map[string]interface{} "Invalid account ID"
+// @Failure 404 {object} map[string]interface{} "Account not found"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/status-history [get]
+func (c *DormancyController) StatusHistory(ctx *gin.Context) {
+	accountID, ok := parseAccountID(ctx)
+	if !ok {
+		return
+	}
+
+	var changes []model.AccountStatusChange
+	changes, err := c.DormancyService.StatusHistory(accountID)
+	if err != nil {
+		writeDormancyError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, changes)
+}
+
+func parseAccountID(ctx *gin.Context) (uuid.UUID, bool) {
+	accountID, err := uuid.Parse(ctx.Param("accountId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return uuid.Nil, false
+	}
+	return accountID, true
+}
+
+func writeDormancyError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrAccountNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrAccountNotDormant):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
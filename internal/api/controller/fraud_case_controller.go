@@ -0,0 +1,263 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"paygo/internal/api/dto"
+	"paygo/internal/config"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type FraudCaseController struct {
+	FraudCaseService *service.FraudCaseService
+}
+
+func NewFraudCaseController(db database.DBManager, cfg *config.Config) *FraudCaseController {
+	accountRepo := repository.NewAccountRepository(db)
+	caseRepo := repository.NewFraudCaseRepository(db)
+
+	return &FraudCaseController{
+		FraudCaseService: service.NewFraudCaseService(db, accountRepo, caseRepo, cfg.FraudAutoFreeze),
+	}
+}
+
+// ListCases godoc
+// @Summary List fraud cases
+// @Description Lists fraud cases opened by audits, newest first
+// @Tags fraud-cases
+// @Produce json
+// @Param status query string false "open, investigating or resolved"
+// @Param account_id query string false "Only cases of this account"
+// @Param assigned_to query string false "Only cases assigned to this investigator"
+// @Param limit query int false "Page size (default 50, max 500)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} model.FraudCase "Fraud cases"
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Security BearerAuth
+// @Router /fraud-cases [get]
+func (c *FraudCaseController) ListCases(ctx *gin.Context) {
+	filter := repository.FraudCaseFilter{
+		Status:     ctx.Query("status"),
+		AssignedTo: ctx.Query("assigned_to"),
+	}
+
+	if value := ctx.Query("account_id"); value != "" {
+		accountID, err := uuid.Parse(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+			return
+		}
+		filter.AccountID = &accountID
+	}
+
+	var err error
+	if filter.Limit, err = parseIntQuery(ctx, "limit", 50, 1, 500); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if filter.Offset, err = parseIntQuery(ctx, "offset", 0, 0, -1); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var cases []model.FraudCase
+	cases, err = c.FraudCaseService.ListCases(filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cases)
+}
+
+// GetCase godoc
+// @Summary Get a fraud case
+// @Description Returns the case with its findings and full history
+// @Tags fraud-cases
+// @Produce json
+// @Param caseId path string true "Fraud case ID"
+// @Success 200 {object} model.FraudCase "Fraud case"
+// @Failure 400 {object} map[string]interface{} "Invalid case ID"
+// @Failure 404 {object} map[string]interface{} "Case not found"
+// @Security BearerAuth
+// @Router /fraud-cases/{caseId} [get]
+func (c *FraudCaseController) GetCase(ctx *gin.Context) {
+	caseID, ok := parseCaseID(ctx)
+	if !ok {
+		return
+	}
+
+	fraudCase, err := c.FraudCaseService.GetCase(caseID)
+	if err != nil {
+		writeFraudCaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fraudCase)
+}
+
+// AssignCase godoc
+// @Summary Assign a fraud case
+// @Description Assigns the case to an investigator. The actor is the authenticated user.
+// @Tags fraud-cases
+// @Accept json
+// @Produce json
+// @Param caseId path string true "Fraud case ID"
+// @Param request body dto.AssignFraudCaseRequest true "Investigator"
+// @Success 200 {object} model.FraudCase "Updated case"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Case not found"
+// @Failure 409 {object} map[string]interface{} "Case already resolved"
+// @Security BearerAuth
+// @Router /fraud-cases/{caseId}/assign [post]
+func (c *FraudCaseController) AssignCase(ctx *gin.Context) {
+	caseID, ok := parseCaseID(ctx)
+	if !ok {
+		return
+	}
+
+	var request dto.AssignFraudCaseRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	fraudCase, err := c.FraudCaseService.Assign(caseID, request.Assignee, actorFromContext(ctx))
+	if err != nil {
+		writeFraudCaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fraudCase)
+}
+
+// CommentOnCase godoc
+// @Summary Comment on a fraud case
+// @Description Adds a comment to the case history. The actor is the authenticated user.
+// @Tags fraud-cases
+// @Accept json
+// @Produce json
+// @Param caseId path string true "Fraud case ID"
+// @Param request body dto.FraudCaseCommentRequest true "Comment"
+// @Success 200 {object} model.FraudCase "Updated case"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Case not found"
+// @Failure 409 {object} map[string]interface{} "Case already resolved"
+// @Security BearerAuth
+// @Router /fraud-cases/{caseId}/comments [post]
+func (c *FraudCaseController) CommentOnCase(ctx *gin.Context) {
+	caseID, ok := parseCaseID(ctx)
+	if !ok {
+		return
+	}
+
+	var request dto.FraudCaseCommentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	fraudCase, err := c.FraudCaseService.Comment(caseID, request.Comment, actorFromContext(ctx))
+	if err != nil {
+		writeFraudCaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fraudCase)
+}
+
+// ResolveCase godoc
+// @Summary Resolve a fraud case
+// @Description Closes the case as confirmed fraud or a false positive. A frozen account stays frozen until it is unfrozen. The actor is the authenticated user.
+// @Tags fraud-cases
+// @Accept json
+// @Produce json
+// @Param caseId path string true "Fraud case ID"
+// @Param request body dto.ResolveFraudCaseRequest true "Resolution"
+// @Success 200 {object} model.FraudCase "Resolved case"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Case not found"
+// @Failure 409 {object} map[string]interface{} "Case already resolved"
+// @Security BearerAuth
+// @Router /fraud-cases/{caseId}/resolve [post]
+func (c *FraudCaseController) ResolveCase(ctx *gin.Context) {
+	caseID, ok := parseCaseID(ctx)
+	if !ok {
+		return
+	}
+
+	var request dto.ResolveFraudCaseRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	fraudCase, err := c.FraudCaseService.Resolve(caseID, request.Resolution, request.Note, actorFromContext(ctx))
+	if err != nil {
+		writeFraudCaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fraudCase)
+}
+
+// UnfreezeAccount godoc
+// @Summary Unfreeze the account of a fraud case
+// @Description Reactivates the frozen account of the case. The actor is the authenticated user.
+// @Tags fraud-cases
+// @Accept json
+// @Produce json
+// @Param caseId path string true "Fraud case ID"
+// @Param request body dto.UnfreezeAccountRequest true "Reason"
+// @Success 200 {object} model.FraudCase "Updated case"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Case not found"
+// @Failure 409 {object} map[string]interface{} "Account is not frozen"
+// @Security BearerAuth
+// @Router /fraud-cases/{caseId}/unfreeze [post]
+func (c *FraudCaseController) UnfreezeAccount(ctx *gin.Context) {
+	caseID, ok := parseCaseID(ctx)
+	if !ok {
+		return
+	}
+
+	var request dto.UnfreezeAccountRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	fraudCase, err := c.FraudCaseService.Unfreeze(caseID, request.Note, actorFromContext(ctx))
+	if err != nil {
+		writeFraudCaseError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fraudCase)
+}
+
+func parseCaseID(ctx *gin.Context) (uuid.UUID, bool) {
+	caseID, err := uuid.Parse(ctx.Param("caseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid case ID"})
+		return uuid.Nil, false
+	}
+	return caseID, true
+}
+
+func writeFraudCaseError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrFraudCaseNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrFraudCaseResolved), errors.Is(err, service.ErrAccountNotFrozen):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
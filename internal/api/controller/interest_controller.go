@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"paygo/internal/api/dto"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type InterestController struct {
+	InterestService *service.InterestService
+}
+
+func NewInterestController(db database.DBManager) *InterestController {
+	interestService := service.NewInterestService(
+		db,
+		repository.NewAccountRepository(db),
+		repository.NewLedgerRepository(db),
+		repository.NewTransactionRepository(db),
+		repository.NewInterestRepository(db),
+		repository.NewPeriodRepository(db),
+	)
+
+	return &InterestController{
+		InterestService: interestService,
+	}
+}
+
+// CreateProduct godoc
+// @Summary Create a savings product
+// @Description Creates a savings product with an annual rate, a day-count convention and the system account interest is paid from
+// @Tags interest
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateSavingsProductRequest true "Savings product"
+// @Success 201 {object} model.SavingsProduct "Created product"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Security BearerAuth
+// @Router /savings-products [post]
+func (c *InterestController) CreateProduct(ctx *gin.Context) {
+	var request dto.CreateSavingsProductRequest
+
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	product, err := c.InterestService.CreateProduct(&model.SavingsProduct{
+		Code:             request.Code,
+		Name:             request.Name,
+		CurrencyCode:     request.CurrencyCode,
+		AnnualRate:       request.AnnualRate,
+		DayCount:         request.DayCount,
+		PostingFrequency: request.PostingFrequency,
+		ExpenseAccountID: request.ExpenseAccountID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, product)
+}
+
+// ListProducts godoc
+// @Summary List savings products
+// @Tags interest
+// @Produce json
+// @Success 200 {array} model.SavingsProduct "Savings products"
+// @Security BearerAuth
+// @Router /savings-products [get]
+func (c *InterestController) ListProducts(ctx *gin.Context) {
+	products, err := c.InterestService.ListProducts()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, products)
+}
+
+// AssignProduct godoc
+// @Summary Attach a savings product to an account
+// @Description Makes the account a savings account earning the product's interest from the next day
+// @Tags interest
+// @Accept json
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param request body dto.AssignSavingsProductRequest true "Savings product"
+// @Success 200 {object} model.Account "Updated account"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Account or product not found"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/savings-product [put]
+func (c *InterestController) AssignProduct(ctx *gin.Context) {
+	accountID, err := uuid.Parse(ctx.Param("accountId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	var request dto.AssignSavingsProductRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	account, err := c.InterestService.AssignProduct(accountID, request.ProductID)
+	if err != nil {
+		if errors.Is(err, service.ErrAccountNotFound) || errors.Is(err, service.ErrProductNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, account)
+}
+
+// ListAccruals godoc
+// @Summary List interest accruals of an account
+// @Tags interest
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param from query string false "First accrual date (YYYY-MM-DD), defaults to 30 days ago"
+// @Param to query string false "Last accrual date (YYYY-MM-DD), defaults to today"
+// @Success 200 {array} model.InterestAccrual "Daily accruals"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Account not found"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/interest-accruals [get]
+func (c *InterestController) ListAccruals(ctx *gin.Context) {
+	accountID, err := uuid.Parse(ctx.Param("accountId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	to, err := parseTimeQuery(ctx, "to", time.Now().UTC())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to, expected YYYY-MM-DD"})
+		return
+	}
+
+	from, err := parseTimeQuery(ctx, "from", to.AddDate(0, 0, -30))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from, expected YYYY-MM-DD"})
+		return
+	}
+
+	accruals, err := c.InterestService.Accruals(accountID, from, to)
+	if err != nil {
+		if errors.Is(err, service.ErrAccountNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, accruals)
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"paygo/internal/api/dto"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PeriodController struct {
+	PeriodService     *service.PeriodService
+	CorrectionService *service.CorrectionService
+}
+
+func NewPeriodController(db database.DBManager) *PeriodController {
+	accountRepo := repository.NewAccountRepository(db)
+	transactionRepo := repository.NewTransactionRepository(db)
+	periodRepo := repository.NewPeriodRepository(db)
+
+	return &PeriodController{
+		PeriodService:     service.NewPeriodService(db, periodRepo),
+		CorrectionService: service.NewCorrectionService(db, accountRepo, transactionRepo, periodRepo),
+	}
+}
+
+// ClosePeriod godoc
+// @Summary Close an accounting period
+// @Description Closes the day or month containing the given date and snapshots every account balance at its end. Nothing can be booked inside a closed period afterwards.
+// @Tags periods
+// @Accept json
+// @Produce json
+// @Param request body dto.ClosePeriodRequest true "Period to close"
+// @Success 201 {object} model.AccountingPeriod "Closed period"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Period already closed or not ended"
+// @Security BearerAuth
+// @Router /periods/close [post]
+func (c *PeriodController) ClosePeriod(ctx *gin.Context) {
+	var request dto.ClosePeriodRequest
+
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	date, err := time.Parse(time.DateOnly, request.Date)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+		return
+	}
+
+	var period *model.AccountingPeriod
+	period, err = c.PeriodService.ClosePeriod(request.PeriodType, date, actorFromContext(ctx))
+	if err != nil {
+		if errors.Is(err, service.ErrPeriodAlreadyClosed) || errors.Is(err, service.ErrPeriodNotEnded) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, period)
+}
+
+// ListPeriods godoc
+// @Summary List closed accounting periods
+// @Tags periods
+// @Produce json
+// @Success 200 {array} model.AccountingPeriod "Closed periods, latest first"
+// @Security BearerAuth
+// @Router /periods [get]
+func (c *PeriodController) ListPeriods(ctx *gin.Context) {
+	periods, err := c.PeriodService.ListPeriods()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, periods)
+}
+
+// GetSnapshots godoc
+// @Summary Get the balance snapshot of a closed period
+// @Tags periods
+// @Produce json
+// @Param periodId path string true "Period ID"
+// @Success 200 {array} model.PeriodBalanceSnapshot "Account balances at the end of the period"
+// @Failure 404 {object} map[string]interface{} "Period not found"
+// @Security BearerAuth
+// @Router /periods/{periodId}/snapshots [get]
+func (c *PeriodController) GetSnapshots(ctx *gin.Context) {
+	periodID, err := uuid.Parse(ctx.Param("periodId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid period ID"})
+		return
+	}
+
+	snapshots, err := c.PeriodService.Snapshots(periodID)
+	if err != nil {
+		if errors.Is(err, service.ErrPeriodNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, snapshots)
+}
+
+// ReverseTransaction godoc
+// @Summary Reverse a transaction
+// @Description Posts a correction in the open period that mirrors every leg of the original transaction and references it
+// @Tags periods
+// @Accept json
+// @Produce json
+// @Param transactionId path string true "Original transaction ID"
+// @Param request body dto.CorrectionRequest true "Reason for the correction"
+// @Success 201 {object} model.Transaction "Reversal transaction"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Transaction not found"
+// @Failure 409 {object} map[string]interface{} "Already reversed or period closed"
+// @Security BearerAuth
+// @Router /transactions/{transactionId}/reversal [post]
+func (c *PeriodController) ReverseTransaction(ctx *gin.Context) {
+	transactionID, err := uuid.Parse(ctx.Param("transactionId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	var request dto.CorrectionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	reversal, err := c.CorrectionService.ReverseTransaction(transactionID, request.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTransactionNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrAlreadyReversed), errors.Is(err, service.ErrPeriodClosed):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, reversal)
+}
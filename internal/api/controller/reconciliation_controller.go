@@ -0,0 +1,303 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"paygo/internal/api/dto"
+	"paygo/internal/config"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/bankstatement"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxStatementFileSize bounds uploaded bank statement files.
+const maxStatementFileSize = 10 << 20
+
+type ReconciliationController struct {
+	ReconciliationService *service.ReconciliationService
+	csvMapping            map[string]string
+}
+
+func NewReconciliationController(db database.DBManager, cfg *config.Config) *ReconciliationController {
+	accountRepo := repository.NewAccountRepository(db)
+	statementRepo := repository.NewBankStatementRepository(db)
+
+	rules := service.DefaultReconciliationRules()
+	rules.DateWindowDays = cfg.ReconciliationDateWindow
+
+	return &ReconciliationController{
+		ReconciliationService: service.NewReconciliationService(db, accountRepo, statementRepo, rules),
+		csvMapping:            cfg.ReconciliationCSVMapping,
+	}
+}
+
+// ImportStatement godoc
+// @Summary Import a bank statement
+// @Description Imports a partner bank statement for a settlement account and matches its lines with ledger entries by reference, then by amount and value date. CSV files use the configured column mapping, overridden by the optional mapping field, e.g. {"date":"Booking Date","delimiter":";"}. The importer is the authenticated user.
+// @Tags reconciliation
+// @Accept multipart/form-data
+// @Produce json
+// @Param account_id formData string true "Settlement account ID"
+// @Param format formData string true "csv or mt940"
+// @Param file formData file true "Statement file"
+// @Param mapping formData string false "CSV column mapping as a JSON object"
+// @Success 201 {array} model.BankStatement "Imported statements with their lines"
+// @Failure 400 {object} map[string]interface{} "Invalid file or mapping"
+// @Failure 404 {object} map[string]interface{} "Account not found"
+// @Failure 409 {object} map[string]interface{} "Statement already imported"
+// @Security BearerAuth
+// @Router /reconciliation/statements [post]
+func (c *ReconciliationController) ImportStatement(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxStatementFileSize)
+
+	accountID, err := uuid.Parse(ctx.PostForm("account_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	request := service.StatementImport{
+		AccountID:  accountID,
+		Format:     ctx.PostForm("format"),
+		ImportedBy: actorFromContext(ctx),
+	}
+
+	if request.Format == bankstatement.FormatCSV {
+		settings := maps.Clone(c.csvMapping)
+		if settings == nil {
+			settings = make(map[string]string)
+		}
+		if value := ctx.PostForm("mapping"); value != "" {
+			var overrides map[string]string
+			if err := json.Unmarshal([]byte(value), &overrides); err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mapping", "details": err.Error()})
+				return
+			}
+			maps.Copy(settings, overrides)
+		}
+		if request.CSVMapping, err = bankstatement.CSVMappingFromMap(settings); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mapping", "details": err.Error()})
+			return
+		}
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Statement file is required", "details": err.Error()})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	if request.File, err = io.ReadAll(file); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var statements []model.BankStatement
+	statements, err = c.ReconciliationService.Import(request)
+	if err != nil {
+		writeReconciliationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, statements)
+}
+
+// ListStatements godoc
+// @Summary List imported bank statements
+// @Tags reconciliation
+// @Produce json
+// @Param account_id query string false "Only statements of this account"
+// @Param limit query int false "Maximum number of statements (default 50, max 500)"
+// @Success 200 {array} model.BankStatement "Statements, newest first"
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Security BearerAuth
+// @Router /reconciliation/statements [get]
+func (c *ReconciliationController) ListStatements(ctx *gin.Context) {
+	var accountID *uuid.UUID
+	if value := ctx.Query("account_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+			return
+		}
+		accountID = &id
+	}
+
+	limit, err := parseIntQuery(ctx, "limit", 50, 1, 500)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	statements, err := c.ReconciliationService.ListStatements(accountID, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, statements)
+}
+
+// GetReport godoc
+// @Summary Get the reconciliation report of a statement
+// @Description Returns matched, unmatched and suspicious line counts, the lines, and the ledger entries of the statement period that no line accounts for
+// @Tags reconciliation
+// @Produce json
+// @Param statementId path string true "Statement ID"
+// @Param status query string false "Only lines in this status: matched, unmatched or suspicious"
+// @Success 200 {object} service.ReconciliationReport "Reconciliation report"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Statement not found"
+// @Security BearerAuth
+// @Router /reconciliation/statements/{statementId}/report [get]
+func (c *ReconciliationController) GetReport(ctx *gin.Context) {
+	statementID, err := uuid.Parse(ctx.Param("statementId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid statement ID"})
+		return
+	}
+
+	status := ctx.Query("status")
+	if status != "" && status != "matched" && status != "unmatched" && status != "suspicious" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "status must be matched, unmatched or suspicious"})
+		return
+	}
+
+	report, err := c.ReconciliationService.Report(statementID, status)
+	if err != nil {
+		writeReconciliationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// RematchStatement godoc
+// @Summary Match a statement again
+// @Description Runs automatic matching again over the unmatched and suspicious lines, e.g. after late ledger postings. Manual matches are kept.
+// @Tags reconciliation
+// @Produce json
+// @Param statementId path string true "Statement ID"
+// @Success 200 {object} service.ReconciliationReport "Reconciliation report"
+// @Failure 400 {object} map[string]interface{} "Invalid statement ID"
+// @Failure 404 {object} map[string]interface{} "Statement not found"
+// @Security BearerAuth
+// @Router /reconciliation/statements/{statementId}/rematch [post]
+func (c *ReconciliationController) RematchStatement(ctx *gin.Context) {
+	statementID, err := uuid.Parse(ctx.Param("statementId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid statement ID"})
+		return
+	}
+
+	report, err := c.ReconciliationService.Rematch(statementID)
+	if err != nil {
+		writeReconciliationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// MatchLine godoc
+// @Summary Match a statement line by hand
+// @Description Matches a statement line with a ledger entry of the statement's account. The actor is the authenticated user.
+// @Tags reconciliation
+// @Accept json
+// @Produce json
+// @Param lineId path string true "Statement line ID"
+// @Param request body dto.MatchStatementLineRequest true "Ledger entry and reason"
+// @Success 200 {object} model.BankStatementLine "Matched line"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Line or ledger entry not found"
+// @Failure 409 {object} map[string]interface{} "Line or ledger entry already matched"
+// @Security BearerAuth
+// @Router /reconciliation/lines/{lineId}/match [post]
+func (c *ReconciliationController) MatchLine(ctx *gin.Context) {
+	lineID, err := uuid.Parse(ctx.Param("lineId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid line ID"})
+		return
+	}
+
+	var request dto.MatchStatementLineRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	line, err := c.ReconciliationService.MatchLine(lineID, request.LedgerEntryID, request.Note, actorFromContext(ctx))
+	if err != nil {
+		writeReconciliationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, line)
+}
+
+// UnmatchLine godoc
+// @Summary Unmatch a statement line
+// @Description Releases the ledger entry of a matched statement line. The actor is the authenticated user.
+// @Tags reconciliation
+// @Accept json
+// @Produce json
+// @Param lineId path string true "Statement line ID"
+// @Param request body dto.UnmatchStatementLineRequest true "Reason"
+// @Success 200 {object} model.BankStatementLine "Unmatched line"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Line not found"
+// @Failure 409 {object} map[string]interface{} "Line is not matched"
+// @Security BearerAuth
+// @Router /reconciliation/lines/{lineId}/unmatch [post]
+func (c *ReconciliationController) UnmatchLine(ctx *gin.Context) {
+	lineID, err := uuid.Parse(ctx.Param("lineId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid line ID"})
+		return
+	}
+
+	var request dto.UnmatchStatementLineRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	line, err := c.ReconciliationService.UnmatchLine(lineID, request.Note, actorFromContext(ctx))
+	if err != nil {
+		writeReconciliationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, line)
+}
+
+func writeReconciliationError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrAccountNotFound),
+		errors.Is(err, service.ErrStatementNotFound),
+		errors.Is(err, service.ErrStatementLineNotFound),
+		errors.Is(err, service.ErrLedgerEntryNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrStatementAlreadyImported),
+		errors.Is(err, service.ErrStatementLineMatched),
+		errors.Is(err, service.ErrStatementLineNotMatched),
+		errors.Is(err, service.ErrLedgerEntryAlreadyMatched):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"log"
+	"net/http"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReportController struct {
+	ReportService *service.ReportService
+}
+
+func NewReportController(db database.DBManager) *ReportController {
+	reportRepo := repository.NewReportRepository(db)
+	reportService := service.NewReportService(reportRepo)
+
+	return &ReportController{
+		ReportService: reportService,
+	}
+}
+
+// TrialBalance godoc
+// @Summary Trial balance
+// @Description Debit and credit totals and net per account and per currency as of a point in time. Flags every currency whose debits and credits do not net to zero.
+// @Tags reports
+// @Produce json
+// @Param as_of query string false "Point in time (RFC 3339 or YYYY-MM-DD for the end of that day), defaults to now"
+// @Success 200 {object} service.TrialBalance "Trial balance"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Security BearerAuth
+// @Router /reports/trial-balance [get]
+func (c *ReportController) TrialBalance(ctx *gin.Context) {
+	asOf, err := parseAsOfQuery(ctx, "as_of", time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid as_of, expected RFC 3339 timestamp or date"})
+		return
+	}
+
+	report, err := c.ReportService.TrialBalance(asOf)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// GeneralLedger godoc
+// @Summary General ledger detail
+// @Description Opening balance, entries, totals and closing balance per account for the period [from, to). Entries are streamed, account by account, as {"from", "to", "accounts": [{"account", "entries"}]}.
+// @Tags reports
+// @Produce json
+// @Param from query string true "Period start (RFC 3339 or YYYY-MM-DD), inclusive"
+// @Param to query string true "Period end (RFC 3339 or YYYY-MM-DD), exclusive"
+// @Param account_id query string false "Restrict to one account"
+// @Param currency query string false "Restrict to one currency"
+// @Success 200 {object} map[string]interface{} "General ledger"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Security BearerAuth
+// @Router /reports/general-ledger [get]
+func (c *ReportController) GeneralLedger(ctx *gin.Context) {
+	from, err := parseTimeQuery(ctx, "from", time.Time{})
+	if err != nil || from.IsZero() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or missing from, expected RFC 3339 timestamp or date"})
+		return
+	}
+
+	to, err := parseTimeQuery(ctx, "to", time.Time{})
+	if err != nil || to.IsZero() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or missing to, expected RFC 3339 timestamp or date"})
+		return
+	}
+
+	filter := repository.GeneralLedgerFilter{
+		From:         from,
+		To:           to,
+		CurrencyCode: ctx.Query("currency"),
+	}
+
+	if accountIDParam := ctx.Query("account_id"); accountIDParam != "" {
+		accountID, err := uuid.Parse(accountIDParam)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+			return
+		}
+		filter.AccountID = &accountID
+	}
+
+	accounts, err := c.ReportService.PrepareGeneralLedger(filter)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Type", "application/json; charset=utf-8")
+	ctx.Status(http.StatusOK)
+	if err := c.ReportService.WriteGeneralLedger(filter, accounts, service.NewJSONGeneralLedgerWriter(ctx.Writer)); err != nil {
+		// The status line has already been sent, so the response is cut short
+		// and the client sees a truncated document.
+		log.Printf("Failed to write general ledger for %s to %s: %v", from.Format(time.RFC3339), to.Format(time.RFC3339), err)
+		_ = ctx.Error(err)
+		ctx.Abort()
+	}
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StatementController struct {
+	StatementService *service.StatementService
+}
+
+func NewStatementController(db database.DBManager) *StatementController {
+	accountRepo := repository.NewAccountRepository(db)
+	ledgerRepo := repository.NewLedgerRepository(db)
+	checkpointRepo := repository.NewCheckpointRepository(db)
+	statementService := service.NewStatementService(accountRepo, ledgerRepo, checkpointRepo)
+
+	return &StatementController{
+		StatementService: statementService,
+	}
+}
+
+// GetStatement godoc
+// @Summary Get an account statement
+// @Description Returns the opening balance, every ledger entry and the closing balance of an account for the period [from, to). Entries are streamed.
+// @Tags accounts
+// @Produce json
+// @Produce text/csv
+// @Produce application/xml
+// @Param accountId path string true "Account ID"
+// @Param from query string true "Period start (RFC 3339 or YYYY-MM-DD), inclusive"
+// @Param to query string true "Period end (RFC 3339 or YYYY-MM-DD), exclusive"
+// @Param format query string false "json (default), csv or camt053 (ISO 20022 camt.053.001.02)"
+// @Success 200 {object} map[string]interface{} "Statement"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Account not found"
+// @Security BearerAuth
+// @Router /accounts/{accountId}/statements [get]
+func (c *StatementController) GetStatement(ctx *gin.Context) {
+	accountID, err := uuid.Parse(ctx.Param("accountId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+		return
+	}
+
+	from, err := parseTimeQuery(ctx, "from", time.Time{})
+	if err != nil || from.IsZero() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or missing from, expected RFC 3339 timestamp or date"})
+		return
+	}
+
+	to, err := parseTimeQuery(ctx, "to", time.Time{})
+	if err != nil || to.IsZero() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or missing to, expected RFC 3339 timestamp or date"})
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" && format != "camt053" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, expected json, csv or camt053"})
+		return
+	}
+
+	header, err := c.StatementService.PrepareStatement(accountID, from, to)
+	if err != nil {
+		if errors.Is(err, service.ErrAccountNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var writer service.StatementWriter
+	switch format {
+	case "camt053":
+		summary, err := c.StatementService.SummarizeStatement(header)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Header("Content-Type", "application/xml; charset=utf-8")
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="camt053-%s-%s-%s.xml"`,
+			header.AccountNumber, from.Format(time.DateOnly), to.Format(time.DateOnly)))
+		writer = service.NewCamt053StatementWriter(ctx.Writer, *summary)
+	case "csv":
+		ctx.Header("Content-Type", "text/csv; charset=utf-8")
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="statement-%s-%s-%s.csv"`,
+			header.AccountNumber, from.Format(time.DateOnly), to.Format(time.DateOnly)))
+		writer = service.NewCSVStatementWriter(ctx.Writer)
+	default:
+		ctx.Header("Content-Type", "application/json; charset=utf-8")
+		writer = service.NewJSONStatementWriter(ctx.Writer)
+	}
+
+	ctx.Status(http.StatusOK)
+	if err := c.StatementService.WriteStatement(header, writer); err != nil {
+		// The status line has already been sent, so the response is cut short
+		// and the client sees a truncated document.
+		log.Printf("Failed to write statement for account %s: %v", accountID, err)
+		_ = ctx.Error(err)
+		ctx.Abort()
+	}
+}
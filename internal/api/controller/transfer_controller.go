@@ -1,38 +1,57 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"paygo/internal/api/dto"
+	"paygo/internal/config"
+	"paygo/internal/domain/model"
 	"paygo/internal/domain/repository"
 	"paygo/internal/domain/service"
 	"paygo/internal/infra/database"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type TransferController struct {
 	TransferService *service.TransferService
 }
 
-func NewTransferController(db database.DBManager) *TransferController {
+func NewTransferController(db database.DBManager, cfg *config.Config) *TransferController {
 	accountRepo := repository.NewAccountRepository(db)
 	transactionRepo := repository.NewTransactionRepository(db)
-	transferService := service.NewTransferService(db, accountRepo, transactionRepo)
+	periodRepo := repository.NewPeriodRepository(db)
+	transferService := service.NewTransferService(db, accountRepo, transactionRepo, periodRepo)
+	transferService.RiskScorer = newRiskScorer(db, cfg)
+	transferService.DuplicateWindow = cfg.DuplicateTransferWindow
 
 	return &TransferController{
 		TransferService: transferService,
 	}
 }
 
+func newRiskScorer(db database.DBManager, cfg *config.Config) service.RiskScorer {
+	rules := service.DefaultRiskRules()
+	rules.ReviewThreshold = cfg.RiskReviewThreshold
+	rules.DenyThreshold = cfg.RiskDenyThreshold
+	rules.LargeAmount = cfg.RiskLargeAmount
+	return service.NewRuleRiskScorer(repository.NewLedgerRepository(db), rules)
+}
+
 // TransferMoney godoc
 // @Summary Transfer money between accounts
-// @Description Transfer money from one account to another
+// @Description Transfer money from one account to another. Customers can only transfer from their own accounts. Every transfer is risk scored first: risky transfers are held for manual review and the riskiest are denied. A transfer with the same accounts, amount and description as a recent one is refused with 409 unless confirm_duplicate is set.
 // @Tags transfers
 // @Accept json
 // @Produce json
 // @Param transfer body dto.TransferRequest true "Transfer details"
 // @Success 200 {object} map[string]interface{} "Transfer successful"
+// @Success 202 {object} map[string]interface{} "Transfer held for review"
 // @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 403 {object} map[string]interface{} "Transfer denied by risk checks or source account not owned"
+// @Failure 409 {object} map[string]interface{} "Possible duplicate of a recent transfer"
+// @Security BearerAuth
 // @Router /transfers [post]
 func (c *TransferController) TransferMoney(ctx *gin.Context) {
 	var request dto.TransferRequest
@@ -41,20 +60,136 @@ func (c *TransferController) TransferMoney(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if !authorizeAccount(ctx, c.TransferService.AccountRepo, request.FromAccountID) {
+		return
+	}
 
 	transaction, fromAccount, toAccount, err := c.TransferService.TransferMoney(
 		request.FromAccountID,
 		request.ToAccountID,
 		request.Amount,
 		request.Description,
+		request.ConfirmDuplicate,
 	)
 
+	if err != nil {
+		if errors.Is(err, service.ErrDuplicateTransfer) {
+			ctx.JSON(http.StatusConflict, gin.H{
+				"error":        err.Error(),
+				"hint":         "Resend with confirm_duplicate set to book it anyway",
+				"duplicate_of": transaction,
+			})
+			return
+		}
+		if errors.Is(err, service.ErrTransferDenied) {
+			ctx.JSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+				"data":  transferResponse(transaction, fromAccount, toAccount),
+			})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if transaction.Status == "pending_review" {
+		ctx.JSON(http.StatusAccepted, gin.H{
+			"message": "Transfer held for review",
+			"data":    transferResponse(transaction, fromAccount, toAccount),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Transfer successful",
+		"data":    transferResponse(transaction, fromAccount, toAccount),
+	})
+}
+
+// ListPendingReviews godoc
+// @Summary List transfers awaiting review
+// @Description Lists transfers held by risk scoring, oldest first
+// @Tags transfers
+// @Produce json
+// @Param limit query int false "Maximum number of transfers (default 100, max 1000)"
+// @Success 200 {array} model.Transaction "Transfers pending review"
+// @Failure 400 {object} map[string]interface{} "Invalid limit"
+// @Security BearerAuth
+// @Router /transfers/reviews [get]
+func (c *TransferController) ListPendingReviews(ctx *gin.Context) {
+	limit, err := parseIntQuery(ctx, "limit", 100, 1, 1000)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	response := dto.TransferResponse{
+	var transactions []model.Transaction
+	transactions, err = c.TransferService.PendingReviews(limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transactions)
+}
+
+// ApproveTransfer godoc
+// @Summary Approve a held transfer
+// @Description Books a transfer held for review. The reviewer is the authenticated user.
+// @Tags transfers
+// @Produce json
+// @Param transactionId path string true "Transaction ID"
+// @Success 200 {object} model.Transaction "Approved transfer"
+// @Failure 400 {object} map[string]interface{} "Invalid request or transfer can no longer be booked"
+// @Failure 404 {object} map[string]interface{} "Transaction not found"
+// @Failure 409 {object} map[string]interface{} "Transfer is not pending review"
+// @Security BearerAuth
+// @Router /transfers/{transactionId}/approve [post]
+func (c *TransferController) ApproveTransfer(ctx *gin.Context) {
+	c.review(ctx, c.TransferService.ApproveTransfer)
+}
+
+// RejectTransfer godoc
+// @Summary Reject a held transfer
+// @Description Cancels a transfer held for review and releases the held amount. The reviewer is the authenticated user.
+// @Tags transfers
+// @Produce json
+// @Param transactionId path string true "Transaction ID"
+// @Success 200 {object} model.Transaction "Rejected transfer"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Transaction not found"
+// @Failure 409 {object} map[string]interface{} "Transfer is not pending review"
+// @Security BearerAuth
+// @Router /transfers/{transactionId}/reject [post]
+func (c *TransferController) RejectTransfer(ctx *gin.Context) {
+	c.review(ctx, c.TransferService.RejectTransfer)
+}
+
+func (c *TransferController) review(ctx *gin.Context, decide func(uuid.UUID, string) (*model.Transaction, error)) {
+	transactionID, err := uuid.Parse(ctx.Param("transactionId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	transaction, err := decide(transactionID, actorFromContext(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTransactionNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrTransferNotPending):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transaction)
+}
+
+func transferResponse(transaction *model.Transaction, fromAccount, toAccount *model.Account) dto.TransferResponse {
+	return dto.TransferResponse{
 		TransactionID:         transaction.ID,
 		TransactionReference:  transaction.TransactionReference,
 		Status:                transaction.Status,
@@ -64,10 +199,8 @@ func (c *TransferController) TransferMoney(ctx *gin.Context) {
 		ToAccountID:           toAccount.ID,
 		FromAccountNewBalance: fromAccount.Balance,
 		ToAccountNewBalance:   toAccount.Balance,
+		RiskScore:             transaction.RiskScore,
+		RiskDecision:          transaction.RiskDecision,
+		RiskReasons:           transaction.RiskReasons,
 	}
-
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Transfer successful",
-		"data":    response,
-	})
 }
@@ -0,0 +1,261 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"paygo/internal/api/dto"
+	"paygo/internal/config"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WalletController struct {
+	WalletService *service.WalletService
+}
+
+func NewWalletController(db database.DBManager, cfg *config.Config) *WalletController {
+	accountRepo := repository.NewAccountRepository(db)
+	transactionRepo := repository.NewTransactionRepository(db)
+	periodRepo := repository.NewPeriodRepository(db)
+	walletRepo := repository.NewWalletRepository(db)
+	transferService := service.NewTransferService(db, accountRepo, transactionRepo, periodRepo)
+	transferService.RiskScorer = newRiskScorer(db, cfg)
+
+	return &WalletController{
+		WalletService: service.NewWalletService(db, transferService, walletRepo, accountRepo),
+	}
+}
+
+// CreateWallet godoc
+// @Summary Create a wallet
+// @Description Opens a wallet for a user in a currency, backed by its own ledger account. Customers can only open wallets for themselves.
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateWalletRequest true "Wallet owner and currency"
+// @Success 201 {object} model.Wallet "Created wallet"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Not allowed to act for this user"
+// @Failure 409 {object} map[string]interface{} "Wallet already exists"
+// @Security BearerAuth
+// @Router /wallets [post]
+func (c *WalletController) CreateWallet(ctx *gin.Context) {
+	var request dto.CreateWalletRequest
+
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	if !authorizeUser(ctx, request.UserID) {
+		return
+	}
+
+	wallet, err := c.WalletService.CreateWallet(request.UserID, request.Currency)
+	if err != nil {
+		if errors.Is(err, service.ErrWalletExists) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, wallet)
+}
+
+// ListWallets godoc
+// @Summary List a user's wallets
+// @Tags wallets
+// @Produce json
+// @Param user_id query string true "User ID"
+// @Success 200 {array} model.Wallet "Wallets with their balances"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 403 {object} map[string]interface{} "Not allowed to act for this user"
+// @Security BearerAuth
+// @Router /wallets [get]
+func (c *WalletController) ListWallets(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Query("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	var wallets []model.Wallet
+	wallets, err = c.WalletService.ListWallets(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, wallets)
+}
+
+// GetWallet godoc
+// @Summary Get a wallet
+// @Tags wallets
+// @Produce json
+// @Param walletId path string true "Wallet ID"
+// @Success 200 {object} model.Wallet "Wallet with its balance"
+// @Failure 403 {object} map[string]interface{} "Not the owner of this wallet"
+// @Failure 404 {object} map[string]interface{} "Wallet not found"
+// @Security BearerAuth
+// @Router /wallets/{walletId} [get]
+func (c *WalletController) GetWallet(ctx *gin.Context) {
+	walletID, err := uuid.Parse(ctx.Param("walletId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wallet ID"})
+		return
+	}
+
+	wallet, err := c.WalletService.GetWallet(walletID)
+	if err != nil {
+		writeWalletError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, wallet)
+}
+
+// TopUp godoc
+// @Summary Top up a wallet
+// @Description Moves money from one of the wallet owner's accounts into the wallet. Top-ups are risk scored like transfers: risky ones are held for review and the riskiest are denied.
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param walletId path string true "Wallet ID"
+// @Param request body dto.WalletFundsRequest true "Source account and amount"
+// @Success 200 {object} service.WalletMovement "Top-up transaction and new balances"
+// @Success 202 {object} service.WalletMovement "Top-up held for review"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Top-up denied by risk checks or wallet not owned"
+// @Failure 404 {object} map[string]interface{} "Wallet or account not found"
+// @Security BearerAuth
+// @Router /wallets/{walletId}/top-up [post]
+func (c *WalletController) TopUp(ctx *gin.Context) {
+	walletID, request, ok := bindWalletFunds(ctx)
+	if !ok {
+		return
+	}
+
+	movement, err := c.WalletService.TopUp(walletID, request.AccountID, request.Amount, request.Description)
+	if err != nil {
+		writeWalletError(ctx, err)
+		return
+	}
+
+	writeWalletMovement(ctx, movement)
+}
+
+// Withdraw godoc
+// @Summary Withdraw from a wallet
+// @Description Moves money from the wallet to one of its owner's accounts. Withdrawals are risk scored like transfers: risky ones are held for review and the riskiest are denied.
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param walletId path string true "Wallet ID"
+// @Param request body dto.WalletFundsRequest true "Destination account and amount"
+// @Success 200 {object} service.WalletMovement "Withdrawal transaction and new balances"
+// @Success 202 {object} service.WalletMovement "Withdrawal held for review"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Withdrawal denied by risk checks or wallet not owned"
+// @Failure 404 {object} map[string]interface{} "Wallet or account not found"
+// @Security BearerAuth
+// @Router /wallets/{walletId}/withdraw [post]
+func (c *WalletController) Withdraw(ctx *gin.Context) {
+	walletID, request, ok := bindWalletFunds(ctx)
+	if !ok {
+		return
+	}
+
+	movement, err := c.WalletService.Withdraw(walletID, request.AccountID, request.Amount, request.Description)
+	if err != nil {
+		writeWalletError(ctx, err)
+		return
+	}
+
+	writeWalletMovement(ctx, movement)
+}
+
+// TransferBetweenWallets godoc
+// @Summary Transfer between wallets
+// @Description Customers can only transfer from their own wallets. Wallet transfers are risk scored like account transfers: risky ones are held for review and the riskiest are denied.
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param request body dto.WalletTransferRequest true "Wallets and amount"
+// @Success 200 {object} service.WalletMovement "Transfer transaction and new balances"
+// @Success 202 {object} service.WalletMovement "Transfer held for review"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Transfer denied by risk checks or wallet not owned"
+// @Failure 404 {object} map[string]interface{} "Wallet not found"
+// @Security BearerAuth
+// @Router /wallets/transfers [post]
+func (c *WalletController) TransferBetweenWallets(ctx *gin.Context) {
+	var request dto.WalletTransferRequest
+
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+	fromWallet, err := c.WalletService.GetWallet(request.FromWalletID)
+	if err != nil {
+		writeWalletError(ctx, err)
+		return
+	}
+	if !authorizeUser(ctx, fromWallet.UserID) {
+		return
+	}
+
+	movement, err := c.WalletService.TransferBetweenWallets(request.FromWalletID, request.ToWalletID, request.Amount, request.Description)
+	if err != nil {
+		writeWalletError(ctx, err)
+		return
+	}
+
+	writeWalletMovement(ctx, movement)
+}
+
+func bindWalletFunds(ctx *gin.Context) (uuid.UUID, dto.WalletFundsRequest, bool) {
+	var request dto.WalletFundsRequest
+
+	walletID, err := uuid.Parse(ctx.Param("walletId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wallet ID"})
+		return uuid.Nil, request, false
+	}
+
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return uuid.Nil, request, false
+	}
+
+	return walletID, request, true
+}
+
+func writeWalletMovement(ctx *gin.Context, movement *service.WalletMovement) {
+	if movement.Transaction.Status == "pending_review" {
+		ctx.JSON(http.StatusAccepted, movement)
+		return
+	}
+	ctx.JSON(http.StatusOK, movement)
+}
+
+func writeWalletError(ctx *gin.Context, err error) {
+	if errors.Is(err, service.ErrWalletNotFound) || errors.Is(err, service.ErrAccountNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrTransferDenied) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+type BulkBalanceRequest struct {
+	AccountIDs []uuid.UUID `json:"account_ids" binding:"required,min=1,max=1000"`
+	// AsOf is an RFC 3339 timestamp or a YYYY-MM-DD date, meaning the end
+	// of that day in UTC. It defaults to now.
+	AsOf  string `json:"as_of"`
+	Basis string `json:"basis" binding:"omitempty,oneof=booking value"`
+}
+
+type ReactivateAccountRequest struct {
+	VerificationMethod    string `json:"verification_method" binding:"required,oneof=id_document in_person video_call"`
+	VerificationReference string `json:"verification_reference" binding:"required,max=200"`
+	Note                  string `json:"note" binding:"max=2000"`
+}
+
+type OpenAccountRequest struct {
+	UserID      uuid.UUID `json:"user_id" binding:"required"`
+	AccountType string    `json:"account_type" binding:"required,oneof=checking savings"`
+	Currency    string    `json:"currency" binding:"required,len=3"`
+}
+
+type ChangeAccountStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active frozen blocked closed"`
+	Reason string `json:"reason" binding:"required,max=2000"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "github.com/google/uuid"
+
+type DismissAMLAlertRequest struct {
+	Note string `json:"note" binding:"required,max=2000"`
+}
+
+type EscalateAMLAlertsRequest struct {
+	AlertIDs  []uuid.UUID `json:"alert_ids" binding:"required,min=1,max=100"`
+	Narrative string      `json:"narrative" binding:"required,max=10000"`
+}
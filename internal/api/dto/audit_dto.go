@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditTransactionsRequest selects transactions either by ID or by the
+// [from, to) range of their creation time.
+type AuditTransactionsRequest struct {
+	TransactionIDs []uuid.UUID `json:"transaction_ids" binding:"max=10000"`
+	From           *time.Time  `json:"from"`
+	To             *time.Time  `json:"to"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+// Passwords are limited to 72 bytes, the most bcrypt hashes.
+type RegisterRequest struct {
+	Email     string `json:"email" binding:"required,email,max=254"`
+	Password  string `json:"password" binding:"required,min=8,max=72"`
+	FirstName string `json:"first_name" binding:"required,max=100"`
+	LastName  string `json:"last_name" binding:"required,max=100"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
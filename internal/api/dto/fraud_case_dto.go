@@ -0,0 +1,18 @@
+package dto
+
+type AssignFraudCaseRequest struct {
+	Assignee string `json:"assignee" binding:"required,max=100"`
+}
+
+type FraudCaseCommentRequest struct {
+	Comment string `json:"comment" binding:"required,max=2000"`
+}
+
+type ResolveFraudCaseRequest struct {
+	Resolution string `json:"resolution" binding:"required,oneof=confirmed_fraud false_positive"`
+	Note       string `json:"note" binding:"max=2000"`
+}
+
+type UnfreezeAccountRequest struct {
+	Note string `json:"note" binding:"required,max=2000"`
+}
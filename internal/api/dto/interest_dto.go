@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/google/uuid"
+
+type CreateSavingsProductRequest struct {
+	Code             string    `json:"code" binding:"required,max=50"`
+	Name             string    `json:"name" binding:"required"`
+	CurrencyCode     string    `json:"currency_code" binding:"required,len=3"`
+	AnnualRate       float64   `json:"annual_rate" binding:"gte=0,lte=1"`
+	DayCount         string    `json:"day_count" binding:"required,oneof=ACT/365 ACT/360 30/360"`
+	PostingFrequency string    `json:"posting_frequency" binding:"required,oneof=daily monthly"`
+	ExpenseAccountID uuid.UUID `json:"expense_account_id" binding:"required"`
+}
+
+type AssignSavingsProductRequest struct {
+	ProductID uuid.UUID `json:"product_id" binding:"required"`
+}
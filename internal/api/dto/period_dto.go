@@ -0,0 +1,11 @@
+package dto
+
+type ClosePeriodRequest struct {
+	PeriodType string `json:"period_type" binding:"required,oneof=day month"`
+	// Date is any day inside the period to close, as YYYY-MM-DD.
+	Date string `json:"date" binding:"required"`
+}
+
+type CorrectionRequest struct {
+	Reason string `json:"reason" binding:"required,max=200"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "github.com/google/uuid"
+
+type MatchStatementLineRequest struct {
+	LedgerEntryID uuid.UUID `json:"ledger_entry_id" binding:"required"`
+	Note          string    `json:"note" binding:"required,max=500"`
+}
+
+type UnmatchStatementLineRequest struct {
+	Note string `json:"note" binding:"required,max=500"`
+}
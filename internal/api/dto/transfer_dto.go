@@ -7,6 +7,9 @@ type TransferRequest struct {
 	ToAccountID   uuid.UUID `json:"to_account_id" binding:"required"`
 	Amount        float64   `json:"amount" binding:"required,gt=0"`
 	Description   string    `json:"description"`
+	// ConfirmDuplicate books the transfer even if it looks like a repeat of
+	// a recent one.
+	ConfirmDuplicate bool `json:"confirm_duplicate"`
 }
 
 type TransferResponse struct {
@@ -19,4 +22,7 @@ type TransferResponse struct {
 	ToAccountID           uuid.UUID `json:"to_account_id"`
 	FromAccountNewBalance float64   `json:"from_account_new_balance"`
 	ToAccountNewBalance   float64   `json:"to_account_new_balance"`
+	RiskScore             *int      `json:"risk_score,omitempty"`
+	RiskDecision          string    `json:"risk_decision,omitempty"`
+	RiskReasons           []string  `json:"risk_reasons,omitempty"`
 }
@@ -0,0 +1,22 @@
+package dto
+
+import "github.com/google/uuid"
+
+type CreateWalletRequest struct {
+	UserID   uuid.UUID `json:"user_id" binding:"required"`
+	Currency string    `json:"currency" binding:"required,len=3"`
+}
+
+// WalletFundsRequest moves money between a wallet and one of its owner's accounts.
+type WalletFundsRequest struct {
+	AccountID   uuid.UUID `json:"account_id" binding:"required"`
+	Amount      float64   `json:"amount" binding:"required,gt=0"`
+	Description string    `json:"description"`
+}
+
+type WalletTransferRequest struct {
+	FromWalletID uuid.UUID `json:"from_wallet_id" binding:"required"`
+	ToWalletID   uuid.UUID `json:"to_wallet_id" binding:"required"`
+	Amount       float64   `json:"amount" binding:"required,gt=0"`
+	Description  string    `json:"description"`
+}
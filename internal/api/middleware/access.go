@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"paygo/internal/domain/repository"
+	"paygo/internal/domain/service"
+	"paygo/internal/infra/database"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequireRole only lets users with one of the roles through. It must run
+// after Authenticate.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal, ok := Principal(ctx)
+		if !ok || !slices.Contains(roles, principal.Role) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Not allowed for your role"})
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// RequireStaff guards back-office routes.
+func RequireStaff() gin.HandlerFunc {
+	return RequireRole(service.RoleStaff)
+}
+
+// AccountOwner only lets staff and the owner of the :accountId account
+// through. Malformed or unknown IDs are left to the handler to report.
+func AccountOwner(db database.DBManager) gin.HandlerFunc {
+	accountRepo := repository.NewAccountRepository(db)
+
+	return requireOwner("accountId", func(id uuid.UUID) (uuid.UUID, error) {
+		account, err := accountRepo.FindByIDWithoutEntries(id)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return account.UserID, nil
+	})
+}
+
+// WalletOwner only lets staff and the owner of the :walletId wallet through.
+func WalletOwner(db database.DBManager) gin.HandlerFunc {
+	walletRepo := repository.NewWalletRepository(db)
+
+	return requireOwner("walletId", func(id uuid.UUID) (uuid.UUID, error) {
+		wallet, err := walletRepo.FindByID(id)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return wallet.UserID, nil
+	})
+}
+
+func requireOwner(param string, ownerOf func(uuid.UUID) (uuid.UUID, error)) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal, ok := Principal(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Not authenticated"})
+			return
+		}
+		if principal.IsStaff() {
+			ctx.Next()
+			return
+		}
+
+		id, err := uuid.Parse(ctx.Param(param))
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		ownerID, err := ownerOf(id)
+		if err != nil {
+			if repository.IsNotFound(err) {
+				ctx.Next()
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if ownerID != principal.UserID {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Not the owner of this resource"})
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"paygo/internal/domain/service"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const principalKey = "principal"
+
+// Authenticate rejects requests without a valid bearer access token and
+// stores the authenticated user in the context.
+func Authenticate(authService *service.AuthService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		scheme, token, found := strings.Cut(ctx.GetHeader("Authorization"), " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			ctx.Header("WWW-Authenticate", `Bearer realm="paygo"`)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing bearer token"})
+			return
+		}
+
+		principal, err := authService.Authenticate(strings.TrimSpace(token))
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidAccessToken) {
+				ctx.Header("WWW-Authenticate", `Bearer realm="paygo", error="invalid_token"`)
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.Set(principalKey, principal)
+		ctx.Next()
+	}
+}
+
+// Principal returns the user authenticated by Authenticate, if any.
+func Principal(ctx *gin.Context) (*service.Principal, bool) {
+	value, ok := ctx.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+	principal, ok := value.(*service.Principal)
+	return principal, ok
+}
@@ -0,0 +1,34 @@
+package route
+
+import (
+	"paygo/internal/api/controller"
+	"paygo/internal/api/middleware"
+	"paygo/internal/config"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAccountRoutes(router *gin.RouterGroup, db database.DBManager, cfg *config.Config) {
+	accountController := controller.NewAccountController(db)
+	statementController := controller.NewStatementController(db)
+	dormancyController := controller.NewDormancyController(db, cfg)
+	owner := middleware.AccountOwner(db)
+	staff := middleware.RequireStaff()
+
+	accountRoutes := router.Group("/accounts")
+	{
+		accountRoutes.POST("", accountController.OpenAccount)
+		accountRoutes.GET("", accountController.ListAccounts)
+		accountRoutes.GET("/:accountId", owner, accountController.GetAccount)
+		accountRoutes.POST("/:accountId/status", staff, accountController.ChangeStatus)
+		accountRoutes.GET("/:accountId/balance", owner, accountController.GetBalance)
+		accountRoutes.GET("/:accountId/ledger-entries", owner, accountController.ListLedgerEntries)
+		accountRoutes.POST("/balances", staff, accountController.GetBalances)
+		accountRoutes.GET("/:accountId/statements", owner, statementController.GetStatement)
+		accountRoutes.GET("/dormant", staff, dormancyController.ListDormant)
+		accountRoutes.GET("/escheatment", staff, dormancyController.EscheatmentReport)
+		accountRoutes.POST("/:accountId/reactivate", staff, dormancyController.Reactivate)
+		accountRoutes.GET("/:accountId/status-history", owner, dormancyController.StatusHistory)
+	}
+}
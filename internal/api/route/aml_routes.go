@@ -0,0 +1,28 @@
+package route
+
+import (
+	"paygo/internal/api/controller"
+	"paygo/internal/config"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAMLRoutes(router *gin.RouterGroup, db database.DBManager, cfg *config.Config) {
+	amlController := controller.NewAMLController(db, cfg)
+
+	amlRoutes := router.Group("/aml")
+	{
+		amlRoutes.POST("/scan", amlController.Scan)
+
+		amlRoutes.GET("/alerts", amlController.ListAlerts)
+		amlRoutes.GET("/alerts/:alertId", amlController.GetAlert)
+		amlRoutes.POST("/alerts/:alertId/dismiss", amlController.DismissAlert)
+
+		amlRoutes.POST("/reports", amlController.CreateReport)
+		amlRoutes.GET("/reports", amlController.ListReports)
+		amlRoutes.GET("/reports/:reportId", amlController.GetReport)
+		amlRoutes.POST("/reports/:reportId/file", amlController.FileReport)
+		amlRoutes.GET("/reports/:reportId/export", amlController.ExportReport)
+	}
+}
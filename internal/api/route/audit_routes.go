@@ -2,17 +2,30 @@ package route
 
 import (
 	"paygo/internal/api/controller"
+	"paygo/internal/config"
 	"paygo/internal/infra/database"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupAuditRoutes(rg *gin.RouterGroup, db database.DBManager) {
-	auditController := controller.NewAuditController(db)
+func SetupAuditRoutes(rg *gin.RouterGroup, db database.DBManager, cfg *config.Config) {
+	auditController := controller.NewAuditController(db, cfg)
 
 	auditGroup := rg.Group("/audit")
 	{
 		auditGroup.GET("/accounts/:accountId", auditController.AuditAccount)
+		auditGroup.GET("/accounts/:accountId/timeline", auditController.AccountTimeline)
 		auditGroup.POST("/accounts", auditController.AuditAccounts)
+		auditGroup.GET("/wallets/:walletId", auditController.AuditWallet)
+		auditGroup.POST("/transactions", auditController.AuditTransactions)
+		auditGroup.GET("/balances", auditController.AuditBalances)
+		auditGroup.GET("/detectors", auditController.ListDetectors)
+		auditGroup.GET("/alerts", auditController.ListAlerts)
+
+		auditGroup.POST("/runs", auditController.StartRun)
+		auditGroup.GET("/runs", auditController.ListRuns)
+		auditGroup.GET("/runs/:runId", auditController.GetRun)
+		auditGroup.GET("/runs/:runId/findings", auditController.ListFindings)
+		auditGroup.GET("/runs/:runId/compare/:targetRunId", auditController.CompareRuns)
 	}
 }
@@ -0,0 +1,33 @@
+package route
+
+import (
+	"paygo/internal/api/controller"
+	"paygo/internal/api/middleware"
+	"paygo/internal/config"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAuthRoutes registers the login routes and returns a group of router
+// whose routes require an access token.
+func SetupAuthRoutes(router *gin.RouterGroup, db database.DBManager, cfg *config.Config) *gin.RouterGroup {
+	authController := controller.NewAuthController(db, cfg)
+	authenticated := router.Group("", middleware.Authenticate(authController.AuthService))
+
+	authRoutes := router.Group("/auth")
+	{
+		authRoutes.POST("/register", authController.Register)
+		authRoutes.POST("/login", authController.Login)
+		authRoutes.POST("/refresh", authController.Refresh)
+		authRoutes.POST("/logout", authController.Logout)
+	}
+
+	sessionRoutes := authenticated.Group("/auth")
+	{
+		sessionRoutes.POST("/logout-all", authController.LogoutAll)
+		sessionRoutes.GET("/me", authController.Me)
+	}
+
+	return authenticated
+}
@@ -0,0 +1,23 @@
+package route
+
+import (
+	"paygo/internal/api/controller"
+	"paygo/internal/config"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupFraudCaseRoutes(router *gin.RouterGroup, db database.DBManager, cfg *config.Config) {
+	fraudCaseController := controller.NewFraudCaseController(db, cfg)
+
+	caseRoutes := router.Group("/fraud-cases")
+	{
+		caseRoutes.GET("", fraudCaseController.ListCases)
+		caseRoutes.GET("/:caseId", fraudCaseController.GetCase)
+		caseRoutes.POST("/:caseId/assign", fraudCaseController.AssignCase)
+		caseRoutes.POST("/:caseId/comments", fraudCaseController.CommentOnCase)
+		caseRoutes.POST("/:caseId/resolve", fraudCaseController.ResolveCase)
+		caseRoutes.POST("/:caseId/unfreeze", fraudCaseController.UnfreezeAccount)
+	}
+}
@@ -0,0 +1,25 @@
+package route
+
+import (
+	"paygo/internal/api/controller"
+	"paygo/internal/api/middleware"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupInterestRoutes(router *gin.RouterGroup, db database.DBManager) {
+	interestController := controller.NewInterestController(db)
+
+	productRoutes := router.Group("/savings-products")
+	{
+		productRoutes.GET("", interestController.ListProducts)
+		productRoutes.POST("", middleware.RequireStaff(), interestController.CreateProduct)
+	}
+
+	accountRoutes := router.Group("/accounts")
+	{
+		accountRoutes.PUT("/:accountId/savings-product", middleware.RequireStaff(), interestController.AssignProduct)
+		accountRoutes.GET("/:accountId/interest-accruals", middleware.AccountOwner(db), interestController.ListAccruals)
+	}
+}
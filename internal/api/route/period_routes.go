@@ -0,0 +1,21 @@
+package route
+
+import (
+	"paygo/internal/api/controller"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupPeriodRoutes(router *gin.RouterGroup, db database.DBManager) {
+	periodController := controller.NewPeriodController(db)
+
+	periodRoutes := router.Group("/periods")
+	{
+		periodRoutes.GET("", periodController.ListPeriods)
+		periodRoutes.POST("/close", periodController.ClosePeriod)
+		periodRoutes.GET("/:periodId/snapshots", periodController.GetSnapshots)
+	}
+
+	router.POST("/transactions/:transactionId/reversal", periodController.ReverseTransaction)
+}
@@ -0,0 +1,23 @@
+package route
+
+import (
+	"paygo/internal/api/controller"
+	"paygo/internal/config"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReconciliationRoutes(router *gin.RouterGroup, db database.DBManager, cfg *config.Config) {
+	reconciliationController := controller.NewReconciliationController(db, cfg)
+
+	reconciliationRoutes := router.Group("/reconciliation")
+	{
+		reconciliationRoutes.POST("/statements", reconciliationController.ImportStatement)
+		reconciliationRoutes.GET("/statements", reconciliationController.ListStatements)
+		reconciliationRoutes.GET("/statements/:statementId/report", reconciliationController.GetReport)
+		reconciliationRoutes.POST("/statements/:statementId/rematch", reconciliationController.RematchStatement)
+		reconciliationRoutes.POST("/lines/:lineId/match", reconciliationController.MatchLine)
+		reconciliationRoutes.POST("/lines/:lineId/unmatch", reconciliationController.UnmatchLine)
+	}
+}
@@ -0,0 +1,18 @@
+package route
+
+import (
+	"paygo/internal/api/controller"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReportRoutes(router *gin.RouterGroup, db database.DBManager) {
+	reportController := controller.NewReportController(db)
+
+	reportRoutes := router.Group("/reports")
+	{
+		reportRoutes.GET("/trial-balance", reportController.TrialBalance)
+		reportRoutes.GET("/general-ledger", reportController.GeneralLedger)
+	}
+}
@@ -1,15 +1,29 @@
 package route
 
 import (
+	"paygo/internal/api/middleware"
+	"paygo/internal/config"
 	"paygo/internal/infra/database"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(r *gin.Engine, db *database.Database) {
+func SetupRoutes(r *gin.Engine, db *database.Database, cfg *config.Config) {
 	v1 := r.Group("/api/v1")
 
-	SetupTransferRoutes(v1, db)
 	SetupHealthRoutes(v1)
-	SetupAuditRoutes(v1, db)
+	authenticated := SetupAuthRoutes(v1, db, cfg)
+	backOffice := authenticated.Group("", middleware.RequireStaff())
+
+	SetupTransferRoutes(authenticated, db, cfg)
+	SetupAccountRoutes(authenticated, db, cfg)
+	SetupInterestRoutes(authenticated, db)
+	SetupWalletRoutes(authenticated, db, cfg)
+
+	SetupAuditRoutes(backOffice, db, cfg)
+	SetupReportRoutes(backOffice, db)
+	SetupPeriodRoutes(backOffice, db)
+	SetupFraudCaseRoutes(backOffice, db, cfg)
+	SetupReconciliationRoutes(backOffice, db, cfg)
+	SetupAMLRoutes(backOffice, db, cfg)
 }
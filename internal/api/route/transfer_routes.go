@@ -2,16 +2,25 @@ package route
 
 import (
 	"paygo/internal/api/controller"
+	"paygo/internal/api/middleware"
+	"paygo/internal/config"
 	"paygo/internal/infra/database"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupTransferRoutes(router *gin.RouterGroup, db *database.Database) {
-	transferController := controller.NewTransferController(db)
+func SetupTransferRoutes(router *gin.RouterGroup, db *database.Database, cfg *config.Config) {
+	transferController := controller.NewTransferController(db, cfg)
 
 	transferRoutes := router.Group("/transfers")
 	{
 		transferRoutes.POST("", transferController.TransferMoney)
 	}
+
+	reviewRoutes := router.Group("/transfers", middleware.RequireStaff())
+	{
+		reviewRoutes.GET("/reviews", transferController.ListPendingReviews)
+		reviewRoutes.POST("/:transactionId/approve", transferController.ApproveTransfer)
+		reviewRoutes.POST("/:transactionId/reject", transferController.RejectTransfer)
+	}
 }
@@ -0,0 +1,26 @@
+package route
+
+import (
+	"paygo/internal/api/controller"
+	"paygo/internal/api/middleware"
+	"paygo/internal/config"
+	"paygo/internal/infra/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupWalletRoutes(router *gin.RouterGroup, db database.DBManager, cfg *config.Config) {
+	walletController := controller.NewWalletController(db, cfg)
+
+	owner := middleware.WalletOwner(db)
+
+	walletRoutes := router.Group("/wallets")
+	{
+		walletRoutes.GET("", walletController.ListWallets)
+		walletRoutes.POST("", walletController.CreateWallet)
+		walletRoutes.POST("/transfers", walletController.TransferBetweenWallets)
+		walletRoutes.GET("/:walletId", owner, walletController.GetWallet)
+		walletRoutes.POST("/:walletId/top-up", owner, walletController.TopUp)
+		walletRoutes.POST("/:walletId/withdraw", owner, walletController.Withdraw)
+	}
+}
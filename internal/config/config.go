@@ -4,10 +4,16 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// DefaultJWTSecret is the published placeholder secret. The server only
+// accepts it in dev mode.
+const DefaultJWTSecret = "your-secret-key"
+
 type Config struct {
 	DBHost     string
 	DBPort     int
@@ -16,6 +22,78 @@ type Config struct {
 	DBName     string
 	ServerPort string
 	JWTSecret  string
+	DevMode    bool
+
+	// Access tokens are short-lived JWTs; refresh tokens rotate on every use
+	// and bound how long a session lasts without logging in again.
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	CheckpointInterval    time.Duration
+	CheckpointSettleDelay time.Duration
+	// CheckpointVerifyInterval is how often every checkpoint chain is
+	// re-verified against the ledger.
+	CheckpointVerifyInterval time.Duration
+
+	InterestAccrualInterval time.Duration
+	InterestPostingInterval time.Duration
+
+	AuditConcurrency    int
+	AuditAccountTimeout time.Duration
+	// AuditDisabledDetectors and AuditDetectorSeverities tune the audit
+	// detectors by name, e.g. "velocity" and "velocity=medium".
+	AuditDisabledDetectors  []string
+	AuditDetectorSeverities map[string]string
+	// AuditFraudSeverity is the lowest finding severity that marks an
+	// account FRAUDULENT; lesser findings mark it SUSPICIOUS. The velocity
+	// detector flags more than AuditVelocityMaxDebits debits within
+	// AuditVelocityWindow.
+	AuditFraudSeverity     string
+	AuditVelocityMaxDebits int
+	AuditVelocityWindow    time.Duration
+	// AuditSchedule is a five-field cron expression for incremental audit
+	// runs, e.g. "*/15 * * * *". Empty disables scheduled audits.
+	AuditSchedule string
+
+	// AlertSinks lists where fraudulent findings are sent: "log" (the
+	// default), "file" and/or "webhook".
+	AlertSinks          []string
+	AlertFilePath       string
+	AlertWebhookURL     string
+	AlertWebhookSecret  string
+	AlertWebhookTimeout time.Duration
+
+	RiskReviewThreshold int
+	RiskDenyThreshold   int
+	RiskLargeAmount     float64
+
+	// DuplicateTransferWindow is how far back a transfer is compared with
+	// earlier ones to catch accidental resubmissions. Zero disables it.
+	DuplicateTransferWindow time.Duration
+
+	// FraudAutoFreeze freezes accounts that fail an audit run, scheduled or
+	// started through the API, until a fraud case investigator unfreezes them.
+	FraudAutoFreeze bool
+
+	// AML monitoring runs every AMLMonitorInterval. Cash-equivalent types
+	// are the transaction types the large cash rule watches.
+	AMLMonitorInterval     time.Duration
+	AMLReportingThreshold  float64
+	AMLRoundAmountUnit     float64
+	AMLLargeCashAmount     float64
+	AMLCashEquivalentTypes []string
+
+	// Accounts without activity for DormancyPeriod are marked dormant by a
+	// job running every DormancyCheckInterval. Dormant accounts inactive for
+	// EscheatmentPeriod are due to be reported as unclaimed property.
+	DormancyPeriod        time.Duration
+	DormancyCheckInterval time.Duration
+	EscheatmentPeriod     time.Duration
+
+	// ReconciliationCSVMapping is the default column mapping for CSV bank
+	// statements, e.g. "date=Booking Date,amount=Amount,delimiter=semicolon".
+	ReconciliationCSVMapping map[string]string
+	ReconciliationDateWindow int
 }
 
 func LoadConfig() (config Config) {
@@ -30,7 +108,56 @@ func LoadConfig() (config Config) {
 	config.DBPassword = getEnv("DB_PASSWORD", "postgres")
 	config.DBName = getEnv("DB_NAME", "paygo")
 	config.ServerPort = getEnv("SERVER_PORT", "8080")
-	config.JWTSecret = getEnv("JWT_SECRET", "your-secret-key")
+	config.JWTSecret = getEnv("JWT_SECRET", DefaultJWTSecret)
+	config.DevMode = getEnvAsBool("DEV_MODE", false)
+	config.AccessTokenTTL = getEnvAsDuration("ACCESS_TOKEN_TTL", 15*time.Minute)
+	config.RefreshTokenTTL = getEnvAsDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+
+	config.CheckpointInterval = getEnvAsDuration("CHECKPOINT_INTERVAL", time.Hour)
+	config.CheckpointSettleDelay = getEnvAsDuration("CHECKPOINT_SETTLE_DELAY", time.Minute)
+	config.CheckpointVerifyInterval = getEnvAsDuration("CHECKPOINT_VERIFY_INTERVAL", 24*time.Hour)
+
+	config.InterestAccrualInterval = getEnvAsDuration("INTEREST_ACCRUAL_INTERVAL", time.Hour)
+	config.InterestPostingInterval = getEnvAsDuration("INTEREST_POSTING_INTERVAL", time.Hour)
+
+	config.AuditConcurrency = getEnvAsInt("AUDIT_CONCURRENCY", 8)
+	config.AuditAccountTimeout = getEnvAsDuration("AUDIT_ACCOUNT_TIMEOUT", 30*time.Second)
+	config.AuditDisabledDetectors = getEnvAsList("AUDIT_DISABLED_DETECTORS")
+	config.AuditDetectorSeverities = getEnvAsMap("AUDIT_DETECTOR_SEVERITIES")
+	config.AuditFraudSeverity = getEnv("AUDIT_FRAUD_SEVERITY", "medium")
+	config.AuditVelocityMaxDebits = getEnvAsInt("AUDIT_VELOCITY_MAX_DEBITS", 20)
+	config.AuditVelocityWindow = getEnvAsDuration("AUDIT_VELOCITY_WINDOW", time.Hour)
+	config.AuditSchedule = getEnv("AUDIT_SCHEDULE", "")
+
+	config.AlertSinks = getEnvAsList("ALERT_SINKS")
+	if len(config.AlertSinks) == 0 {
+		config.AlertSinks = []string{"log"}
+	}
+	config.AlertFilePath = getEnv("ALERT_FILE_PATH", "")
+	config.AlertWebhookURL = getEnv("ALERT_WEBHOOK_URL", "")
+	config.AlertWebhookSecret = getEnv("ALERT_WEBHOOK_SECRET", "")
+	config.AlertWebhookTimeout = getEnvAsDuration("ALERT_WEBHOOK_TIMEOUT", 10*time.Second)
+
+	config.RiskReviewThreshold = getEnvAsInt("RISK_REVIEW_THRESHOLD", 50)
+	config.RiskDenyThreshold = getEnvAsInt("RISK_DENY_THRESHOLD", 80)
+	config.RiskLargeAmount = getEnvAsFloat("RISK_LARGE_AMOUNT", 1000)
+
+	config.DuplicateTransferWindow = getEnvAsDuration("DUPLICATE_TRANSFER_WINDOW", 5*time.Minute)
+
+	config.FraudAutoFreeze = getEnvAsBool("FRAUD_AUTO_FREEZE", false)
+
+	config.AMLMonitorInterval = getEnvAsDuration("AML_MONITOR_INTERVAL", 15*time.Minute)
+	config.AMLReportingThreshold = getEnvAsFloat("AML_REPORTING_THRESHOLD", 10000)
+	config.AMLRoundAmountUnit = getEnvAsFloat("AML_ROUND_AMOUNT_UNIT", 1000)
+	config.AMLLargeCashAmount = getEnvAsFloat("AML_LARGE_CASH_AMOUNT", 10000)
+	config.AMLCashEquivalentTypes = getEnvAsList("AML_CASH_EQUIVALENT_TYPES")
+
+	config.DormancyPeriod = getEnvAsDuration("DORMANCY_PERIOD", 365*24*time.Hour)
+	config.DormancyCheckInterval = getEnvAsDuration("DORMANCY_CHECK_INTERVAL", 24*time.Hour)
+	config.EscheatmentPeriod = getEnvAsDuration("ESCHEATMENT_PERIOD", 3*365*24*time.Hour)
+
+	config.ReconciliationCSVMapping = getEnvAsMap("RECONCILIATION_CSV_MAPPING")
+	config.ReconciliationDateWindow = getEnvAsInt("RECONCILIATION_DATE_WINDOW_DAYS", 2)
 
 	return
 }
@@ -56,3 +183,70 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return value
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		log.Printf("Warning: Failed to convert %s to bool, using default %v: %v", key, defaultValue, err)
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		log.Printf("Warning: Failed to convert %s to float, using default %v: %v", key, defaultValue, err)
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := time.ParseDuration(valueStr)
+	if err != nil {
+		log.Printf("Warning: Failed to convert %s to duration, using default %v: %v", key, defaultValue, err)
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvAsList reads a comma-separated list.
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvAsMap reads a comma-separated list of key=value pairs.
+func getEnvAsMap(key string) map[string]string {
+	values := make(map[string]string)
+	for _, pair := range getEnvAsList(key) {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			log.Printf("Warning: Ignoring malformed entry %q in %s, expected key=value", pair, key)
+			continue
+		}
+		values[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return values
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountStatusChange records one change of an account's status. Changes
+// are only ever appended.
+type AccountStatusChange struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AccountID  uuid.UUID `gorm:"type:uuid;not null;index:idx_status_change_account,priority:1" json:"account_id"`
+	FromStatus string    `gorm:"not null" json:"from_status"`
+	ToStatus   string    `gorm:"not null" json:"to_status"`
+	Reason     string    `json:"reason,omitempty"`
+	Actor      string    `gorm:"not null" json:"actor"`
+	CreatedAt  time.Time `gorm:"not null;index:idx_status_change_account,priority:2" json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccountingPeriod struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PeriodType    string    `gorm:"not null;uniqueIndex:idx_period_type_start,priority:1" json:"period_type"` // "day" or "month"
+	StartsAt      time.Time `gorm:"not null;uniqueIndex:idx_period_type_start,priority:2" json:"starts_at"`
+	EndsAt        time.Time `gorm:"not null;index" json:"ends_at"` // exclusive
+	Status        string    `gorm:"not null;default:closed" json:"status"`
+	ClosedBy      string    `gorm:"not null" json:"closed_by"`
+	ClosedAt      time.Time `gorm:"not null" json:"closed_at"`
+	SnapshotCount int64     `gorm:"not null;default:0" json:"snapshot_count"`
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AMLAlert is a hit of an AML monitoring rule on an account. An account has
+// at most one open alert per rule; later hits add their transactions to it,
+// and Amount and Summary describe the latest hit. Transactions already
+// covered by a dismissed or escalated alert do not raise the rule again.
+type AMLAlert struct {
+	ID             uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Rule           string      `gorm:"not null;index" json:"rule"`
+	AccountID      uuid.UUID   `gorm:"type:uuid;not null;index" json:"account_id"`
+	UserID         uuid.UUID   `gorm:"type:uuid;not null;index" json:"user_id"`
+	Status         string      `gorm:"not null;default:open;index" json:"status"` // "open", "dismissed" or "escalated"
+	Amount         float64     `gorm:"type:numeric(19,4);not null" json:"amount"`
+	Summary        string      `gorm:"not null" json:"summary"`
+	TransactionIDs StringArray `gorm:"type:jsonb;not null;default:'[]'" json:"transaction_ids"`
+	ReportID       *uuid.UUID  `gorm:"type:uuid;index" json:"report_id,omitempty"`
+	ReviewedBy     string      `json:"reviewed_by,omitempty"`
+	ReviewNote     string      `json:"review_note,omitempty"`
+	ReviewedAt     *time.Time  `json:"reviewed_at,omitempty"`
+	CreatedAt      time.Time   `gorm:"not null" json:"created_at"`
+	UpdatedAt      time.Time   `gorm:"not null" json:"updated_at"`
+	Account        Account     `gorm:"foreignKey:AccountID" json:"-"`
+}
+
+// SuspiciousActivityReport bundles escalated AML alerts for filing with the
+// regulator. A filed report can no longer be changed.
+type SuspiciousActivityReport struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Reference string     `gorm:"uniqueIndex;not null" json:"reference"`
+	Status    string     `gorm:"not null;default:draft;index" json:"status"` // "draft" or "filed"
+	Narrative string     `json:"narrative"`
+	CreatedBy string     `gorm:"not null" json:"created_by"`
+	FiledBy   string     `json:"filed_by,omitempty"`
+	CreatedAt time.Time  `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"not null" json:"updated_at"`
+	FiledAt   *time.Time `json:"filed_at,omitempty"`
+	Alerts    []AMLAlert `gorm:"foreignKey:ReportID" json:"alerts,omitempty"`
+}
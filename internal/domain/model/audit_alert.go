@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAlert is a fraudulent audit finding that was sent to the alert sinks.
+// A finding alerts once while it is unresolved; later audits that report it
+// again only bump LastSeenAt and Occurrences. It is resolved when an audit of
+// the account no longer reports it, and alerts again if it comes back.
+type AuditAlert struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Fingerprint   string     `gorm:"not null;uniqueIndex:idx_audit_alert_unresolved,where:resolved_at IS NULL" json:"fingerprint"`
+	AccountID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"account_id"`
+	AccountNumber string     `json:"account_number"`
+	Detector      string     `gorm:"not null" json:"detector"`
+	FraudType     string     `gorm:"not null" json:"fraud_type"`
+	Severity      string     `gorm:"not null" json:"severity"`
+	Message       string     `gorm:"not null" json:"message"`
+	Occurrences   int64      `gorm:"not null;default:1" json:"occurrences"`
+	FirstSeenAt   time.Time  `gorm:"not null" json:"first_seen_at"`
+	LastSeenAt    time.Time  `gorm:"not null" json:"last_seen_at"`
+	NotifiedAt    *time.Time `json:"notified_at,omitempty"` // nil until every sink accepted the alert
+	LastError     string     `json:"last_error,omitempty"`
+	ResolvedAt    *time.Time `gorm:"index" json:"resolved_at,omitempty"`
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditFinding is the persisted result of auditing one account in an audit run.
+type AuditFinding struct {
+	ID                 uuid.UUID        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RunID              uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_finding_run_account,priority:1;index:idx_finding_run_status,priority:1" json:"run_id"`
+	AccountID          uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_finding_run_account,priority:2" json:"account_id"`
+	AccountNumber      string           `json:"account_number"`
+	Status             string           `gorm:"not null;index:idx_finding_run_status,priority:2" json:"status"`
+	FraudTypes         StringArray      `gorm:"type:jsonb;not null;default:'[]'" json:"fraud_types"`
+	Findings           DetectorFindings `gorm:"type:jsonb;not null;default:'[]'" json:"findings"`
+	Errors             StringArray      `gorm:"type:jsonb;not null;default:'[]'" json:"errors"`
+	ExpectedBalance    float64          `gorm:"type:numeric(19,4)" json:"expected_balance"`
+	ActualBalance      float64          `gorm:"type:numeric(19,4)" json:"actual_balance"`
+	BalanceDiscrepancy float64          `gorm:"type:numeric(19,4)" json:"balance_discrepancy"`
+	LedgerEntriesCount int64            `json:"ledger_entries_count"`
+	CheckpointID       *uuid.UUID       `gorm:"type:uuid" json:"checkpoint_id,omitempty"`
+	AuditedAt          time.Time        `gorm:"not null" json:"audited_at"`
+	Run                AuditRun         `gorm:"foreignKey:RunID" json:"-"`
+}
+
+// DetectorFinding is one finding of an audit detector.
+type DetectorFinding struct {
+	Detector  string `json:"detector"`
+	FraudType string `json:"fraud_type"`
+	Severity  string `json:"severity"`
+	Message   string `json:"message"`
+}
+
+// DetectorFindings is a list of findings stored as a jsonb array.
+type DetectorFindings []DetectorFinding
+
+func (f DetectorFindings) Value() (driver.Value, error) {
+	if f == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]DetectorFinding(f))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (f *DetectorFindings) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*f = DetectorFindings{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into DetectorFindings", value)
+	}
+	return json.Unmarshal(data, (*[]DetectorFinding)(f))
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditRun is an audit of every account in the ledger, executed in the
+// background. An incremental run only audits the accounts changed since
+// ChangedSince. The counters are updated as pages of accounts are audited.
+type AuditRun struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Status          string     `gorm:"not null;default:running;index" json:"status"` // "running", "completed" or "failed"
+	Mode            string     `gorm:"not null;default:full" json:"mode"`            // "full" or "incremental"
+	ChangedSince    *time.Time `json:"changed_since,omitempty"`
+	StartedBy       string     `json:"started_by"`
+	TotalAccounts   int64      `gorm:"not null;default:0" json:"total_accounts"`
+	AuditedAccounts int64      `gorm:"not null;default:0" json:"audited_accounts"`
+	ValidCount      int64      `gorm:"not null;default:0" json:"valid_count"`
+	SuspiciousCount int64      `gorm:"not null;default:0" json:"suspicious_count"`
+	FraudulentCount int64      `gorm:"not null;default:0" json:"fraudulent_count"`
+	IncompleteCount int64      `gorm:"not null;default:0" json:"incomplete_count"`
+	Error           string     `json:"error,omitempty"`
+	StartedAt       time.Time  `gorm:"not null;index" json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	UpdatedAt       time.Time  `gorm:"not null" json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BalanceCheckpoint struct {
+	ID                   uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AccountID            uuid.UUID  `gorm:"type:uuid;not null;index:idx_checkpoint_account_covered,priority:1" json:"account_id"`
+	PreviousCheckpointID *uuid.UUID `gorm:"type:uuid" json:"previous_checkpoint_id,omitempty"`
+	CoveredUntil         time.Time  `gorm:"not null;index:idx_checkpoint_account_covered,priority:2" json:"covered_until"`
+	LastEntryID          *uuid.UUID `gorm:"type:uuid" json:"last_entry_id,omitempty"`
+	EntryCount           int64      `gorm:"not null" json:"entry_count"`
+	Balance              float64    `gorm:"type:numeric(19,4);not null" json:"balance"`
+	EntriesHash          string     `gorm:"type:char(64);not null" json:"entries_hash"`
+	Verified             bool       `gorm:"not null;default:true" json:"verified"`
+	VerifiedAt           *time.Time `json:"verified_at,omitempty"`
+	CreatedAt            time.Time  `gorm:"not null" json:"created_at"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BankStatement is a statement from a partner bank imported to reconcile
+// one of our settlement accounts.
+type BankStatement struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AccountID      uuid.UUID           `gorm:"type:uuid;not null;index" json:"account_id"`
+	Format         string              `gorm:"not null" json:"format"` // "csv" or "mt940"
+	Reference      string              `json:"reference,omitempty"`
+	BankAccount    string              `json:"bank_account,omitempty"`
+	CurrencyCode   string              `gorm:"type:char(3);not null" json:"currency_code"`
+	OpeningBalance *float64            `gorm:"type:numeric(19,4)" json:"opening_balance,omitempty"`
+	ClosingBalance *float64            `gorm:"type:numeric(19,4)" json:"closing_balance,omitempty"`
+	PeriodStart    time.Time           `gorm:"type:date;not null" json:"period_start"`
+	PeriodEnd      time.Time           `gorm:"type:date;not null" json:"period_end"`
+	LineCount      int                 `gorm:"not null" json:"line_count"`
+	FileHash       string              `gorm:"uniqueIndex:idx_bank_statement_file;not null" json:"file_hash"` // hex SHA-256 of the file, ":" and the statement's index in it
+	ImportedBy     string              `json:"imported_by"`
+	ImportedAt     time.Time           `gorm:"not null" json:"imported_at"`
+	Lines          []BankStatementLine `gorm:"foreignKey:StatementID" json:"lines,omitempty"`
+	Account        Account             `gorm:"foreignKey:AccountID" json:"-"`
+}
+
+// BankStatementLine is one booking on a bank statement and the ledger entry
+// it was reconciled with.
+type BankStatementLine struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	StatementID      uuid.UUID  `gorm:"type:uuid;not null;index:idx_statement_line_status,priority:1" json:"statement_id"`
+	LineNumber       int        `gorm:"not null" json:"line_number"`
+	BookingDate      time.Time  `gorm:"type:date;not null" json:"booking_date"`
+	ValueDate        time.Time  `gorm:"type:date;not null" json:"value_date"`
+	Direction        string     `gorm:"not null" json:"direction"` // "credit" or "debit", as seen by the bank account
+	Amount           float64    `gorm:"type:numeric(19,4);not null" json:"amount"`
+	Reference        string     `gorm:"index" json:"reference,omitempty"`
+	BankReference    string     `json:"bank_reference,omitempty"`
+	Description      string     `json:"description,omitempty"`
+	Status           string     `gorm:"not null;default:unmatched;index:idx_statement_line_status,priority:2" json:"status"` // "unmatched", "matched" or "suspicious"
+	MatchedEntryID   *uuid.UUID `gorm:"type:uuid;uniqueIndex" json:"matched_entry_id,omitempty"`
+	CandidateEntryID *uuid.UUID `gorm:"type:uuid" json:"candidate_entry_id,omitempty"` // closest ledger entry of a suspicious line
+	MatchMethod      string     `json:"match_method,omitempty"`                        // "reference", "amount_date" or "manual"
+	MatchNote        string     `json:"match_note,omitempty"`
+	MatchedBy        string     `json:"matched_by,omitempty"`
+	MatchedAt        *time.Time `json:"matched_at,omitempty"`
+	UpdatedAt        time.Time  `gorm:"not null" json:"updated_at"`
+}
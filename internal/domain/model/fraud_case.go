@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FraudCase tracks the investigation of an account that failed an audit.
+// An account has at most one unresolved case; later fraudulent audits add
+// their findings to it.
+type FraudCase struct {
+	ID         uuid.UUID        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AccountID  uuid.UUID        `gorm:"type:uuid;not null;index" json:"account_id"`
+	Status     string           `gorm:"not null;default:open;index" json:"status"` // "open", "investigating" or "resolved"
+	AssignedTo string           `gorm:"index" json:"assigned_to,omitempty"`
+	Resolution string           `json:"resolution,omitempty"` // "confirmed_fraud" or "false_positive"
+	FraudTypes StringArray      `gorm:"type:jsonb;not null;default:'[]'" json:"fraud_types"`
+	Findings   DetectorFindings `gorm:"type:jsonb;not null;default:'[]'" json:"findings"`
+	CreatedAt  time.Time        `gorm:"not null" json:"created_at"`
+	UpdatedAt  time.Time        `gorm:"not null" json:"updated_at"`
+	ResolvedAt *time.Time       `json:"resolved_at,omitempty"`
+	Account    Account          `gorm:"foreignKey:AccountID" json:"-"`
+	Events     []FraudCaseEvent `gorm:"foreignKey:CaseID" json:"events,omitempty"`
+}
+
+// FraudCaseEvent is one entry in the history of a fraud case. Events are
+// only ever appended.
+type FraudCaseEvent struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CaseID    uuid.UUID `gorm:"type:uuid;not null;index" json:"case_id"`
+	Type      string    `gorm:"not null" json:"type"` // "opened", "findings_added", "frozen", "assigned", "comment", "resolved" or "unfrozen"
+	Actor     string    `gorm:"not null" json:"actor"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+}
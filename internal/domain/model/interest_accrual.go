@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InterestAccrual is the interest earned by an account over one day. It is
+// accrued but unpaid until a posting transaction pays it out.
+type InterestAccrual struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AccountID           uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_accrual_account_date,priority:1" json:"account_id"`
+	ProductID           uuid.UUID  `gorm:"type:uuid;not null" json:"product_id"`
+	AccrualDate         time.Time  `gorm:"type:date;not null;uniqueIndex:idx_accrual_account_date,priority:2" json:"accrual_date"`
+	Balance             float64    `gorm:"type:numeric(19,4);not null" json:"balance"` // end-of-day value-dated balance
+	AnnualRate          float64    `gorm:"type:numeric(9,6);not null" json:"annual_rate"`
+	DayCount            string     `gorm:"not null" json:"day_count"`
+	Amount              float64    `gorm:"type:numeric(19,8);not null" json:"amount"`
+	Status              string     `gorm:"not null;default:accrued;index" json:"status"` // "accrued" or "posted"
+	PostedTransactionID *uuid.UUID `gorm:"type:uuid;index" json:"posted_transaction_id,omitempty"`
+	PostedAt            *time.Time `json:"posted_at,omitempty"`
+	CreatedAt           time.Time  `gorm:"not null" json:"created_at"`
+}
@@ -8,12 +8,13 @@ import (
 
 type LedgerEntry struct {
 	ID             uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	TransactionID  uuid.UUID   `gorm:"type:uuid;not null" json:"transaction_id"`
-	AccountID      uuid.UUID   `gorm:"type:uuid;not null" json:"account_id"`
+	TransactionID  uuid.UUID   `gorm:"type:uuid;not null;index" json:"transaction_id"`
+	AccountID      uuid.UUID   `gorm:"type:uuid;not null;index:idx_ledger_account_created,priority:1" json:"account_id"`
 	EntryType      string      `gorm:"not null" json:"entry_type"` // "debit" or "credit"
 	Amount         float64     `gorm:"type:numeric(19,4);not null" json:"amount"`
 	RunningBalance float64     `gorm:"type:numeric(19,4);not null" json:"running_balance"`
-	CreatedAt      time.Time   `gorm:"not null" json:"created_at"`
+	ValueDate      *time.Time  `gorm:"type:date" json:"value_date,omitempty"` // nil means the booking date
+	CreatedAt      time.Time   `gorm:"not null;index:idx_ledger_account_created,priority:2" json:"created_at"`
 	Transaction    Transaction `gorm:"foreignKey:TransactionID" json:"-"`
 	Account        Account     `gorm:"foreignKey:AccountID" json:"-"`
 }
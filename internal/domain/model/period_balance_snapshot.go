@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PeriodBalanceSnapshot is the ledger balance of an account at the end of a
+// closed accounting period.
+type PeriodBalanceSnapshot struct {
+	ID           uuid.UUID        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PeriodID     uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_snapshot_period_account,priority:1" json:"period_id"`
+	AccountID    uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_snapshot_period_account,priority:2;index" json:"account_id"`
+	CurrencyCode string           `gorm:"type:char(3);not null" json:"currency_code"`
+	Balance      float64          `gorm:"type:numeric(19,4);not null" json:"balance"`
+	EntryCount   int64            `gorm:"not null" json:"entry_count"`
+	CreatedAt    time.Time        `gorm:"not null" json:"created_at"`
+	Period       AccountingPeriod `gorm:"foreignKey:PeriodID" json:"-"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is one token of a login session. Only the SHA-256 hash of the
+// token is stored. Every refresh revokes the presented token and issues its
+// replacement in the same family; a revoked token presented again revokes the
+// whole family.
+type RefreshToken struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	FamilyID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"family_id"`
+	TokenHash    string     `gorm:"type:char(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt    time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	ReplacedByID *uuid.UUID `gorm:"type:uuid" json:"replaced_by_id,omitempty"`
+	CreatedAt    time.Time  `gorm:"not null" json:"created_at"`
+}
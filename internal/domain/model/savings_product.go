@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SavingsProduct struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Code             string    `gorm:"uniqueIndex;not null" json:"code"`
+	Name             string    `gorm:"not null" json:"name"`
+	CurrencyCode     string    `gorm:"type:char(3);not null" json:"currency_code"`
+	AnnualRate       float64   `gorm:"type:numeric(9,6);not null" json:"annual_rate"`     // 0.025 is 2.5%
+	DayCount         string    `gorm:"not null;default:ACT/365" json:"day_count"`         // "ACT/365", "ACT/360" or "30/360"
+	PostingFrequency string    `gorm:"not null;default:monthly" json:"posting_frequency"` // "daily" or "monthly"
+	ExpenseAccountID uuid.UUID `gorm:"type:uuid;not null" json:"expense_account_id"`      // system account interest is paid from
+	Status           string    `gorm:"default:active" json:"status"`
+	CreatedAt        time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt        time.Time `gorm:"not null" json:"updated_at"`
+	ExpenseAccount   Account   `gorm:"foreignKey:ExpenseAccountID" json:"-"`
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// StringArray is a list of strings stored as a jsonb array.
+type StringArray []string
+
+func (a StringArray) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(a))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (a *StringArray) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*a = StringArray{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into StringArray", value)
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
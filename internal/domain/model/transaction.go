@@ -7,14 +7,23 @@ import (
 )
 
 type Transaction struct {
-	ID                   uuid.UUID     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	TransactionReference string        `gorm:"uniqueIndex;not null" json:"transaction_reference"`
-	TransactionType      string        `gorm:"not null" json:"transaction_type"`
-	Amount               float64       `gorm:"type:numeric(19,4);not null" json:"amount"`
-	CurrencyCode         string        `gorm:"type:char(3);not null" json:"currency_code"`
-	Status               string        `gorm:"default:pending" json:"status"`
-	Description          string        `json:"description"`
-	CreatedAt            time.Time     `gorm:"not null" json:"created_at"`
-	UpdatedAt            time.Time     `gorm:"not null" json:"updated_at"`
-	LedgerEntries        []LedgerEntry `gorm:"foreignKey:TransactionID" json:"ledger_entries,omitempty"`
+	ID                    uuid.UUID     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TransactionReference  string        `gorm:"uniqueIndex;not null" json:"transaction_reference"`
+	TransactionType       string        `gorm:"not null" json:"transaction_type"`
+	Amount                float64       `gorm:"type:numeric(19,4);not null" json:"amount"`
+	CurrencyCode          string        `gorm:"type:char(3);not null" json:"currency_code"`
+	Status                string        `gorm:"default:pending" json:"status"`
+	Description           string        `json:"description"`
+	OriginalTransactionID *uuid.UUID    `gorm:"type:uuid;index" json:"original_transaction_id,omitempty"` // set on corrections
+	SourceAccountID       *uuid.UUID    `gorm:"type:uuid;index" json:"source_account_id,omitempty"`
+	DestinationAccountID  *uuid.UUID    `gorm:"type:uuid;index" json:"destination_account_id,omitempty"`
+	DuplicateOfID         *uuid.UUID    `gorm:"type:uuid;index" json:"duplicate_of_id,omitempty"` // set when a suspected duplicate was confirmed
+	RiskScore             *int          `json:"risk_score,omitempty"`
+	RiskDecision          string        `json:"risk_decision,omitempty"` // "allow", "review" or "deny"
+	RiskReasons           StringArray   `gorm:"type:jsonb;not null;default:'[]'" json:"risk_reasons,omitempty"`
+	ReviewedBy            string        `json:"reviewed_by,omitempty"`
+	ReviewedAt            *time.Time    `json:"reviewed_at,omitempty"`
+	CreatedAt             time.Time     `gorm:"not null" json:"created_at"`
+	UpdatedAt             time.Time     `gorm:"not null" json:"updated_at"`
+	LedgerEntries         []LedgerEntry `gorm:"foreignKey:TransactionID" json:"ledger_entries,omitempty"`
 }
@@ -14,6 +14,7 @@ type User struct {
 	LastName     string    `json:"last_name"`
 	Verified     bool      `gorm:"default:false" json:"verified"`
 	Status       string    `gorm:"default:active" json:"status"`
+	Role         string    `gorm:"not null;default:customer" json:"role"`
 	CreatedAt    time.Time `gorm:"not null" json:"created_at"`
 	UpdatedAt    time.Time `gorm:"not null" json:"updated_at"`
 	Accounts     []Account `gorm:"foreignKey:UserID" json:"accounts,omitempty"`
@@ -6,12 +6,18 @@ import (
 	"github.com/google/uuid"
 )
 
+// Wallet is a user's stored-value balance in one currency. Its money lives on
+// a dedicated ledger account of type "wallet", so every movement is recorded
+// as ledger entries and Balance is read from that account.
 type Wallet struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_wallet_user_currency,priority:1" json:"user_id"`
 	User      User      `gorm:"foreignKey:UserID" json:"-"`
-	Balance   float64   `gorm:"type:numeric(19,4);not null;default:0" json:"balance"`
-	Currency  string    `gorm:"type:char(3);not null;default:USD" json:"currency"`
+	AccountID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"account_id"`
+	Account   Account   `gorm:"foreignKey:AccountID" json:"-"`
+	Balance   float64   `gorm:"-" json:"balance"`
+	Currency  string    `gorm:"type:char(3);not null;default:USD;uniqueIndex:idx_wallet_user_currency,priority:2" json:"currency"`
+	Status    string    `gorm:"default:active" json:"status"`
 	CreatedAt time.Time `gorm:"not null" json:"created_at"`
 	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
 }
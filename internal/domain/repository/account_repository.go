@@ -1,13 +1,23 @@
 package repository
 
 import (
+	"context"
 	"paygo/internal/domain/model"
 	"paygo/internal/infra/database"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm/clause"
 )
 
+type AccountFilter struct {
+	UserID      *uuid.UUID
+	Status      string
+	AccountType string
+	Limit       int
+	Offset      int
+}
+
 type AccountRepository struct {
 	db database.DB
 }
@@ -20,19 +30,67 @@ func (r *AccountRepository) WithTx(tx database.DB) *AccountRepository {
 	return &AccountRepository{db: tx}
 }
 
-func (r *AccountRepository) FindByID(id uuid.UUID, forUpdate bool) (*model.Account, error) {
+func (r *AccountRepository) WithContext(ctx context.Context) *AccountRepository {
+	return &AccountRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *AccountRepository) Create(account *model.Account) error {
+	return r.db.Create(account)
+}
+
+// FindByIDForUpdate locks the account row without loading any associations,
+// so locking costs the same however long the account's history is.
+func (r *AccountRepository) FindByIDForUpdate(id uuid.UUID) (*model.Account, error) {
 	var account model.Account
-	query := r.db.Where("id = ?", id).Preload("LedgerEntries")
+	if err := r.db.Where("id = ?", id).Clauses(clause.Locking{Strength: "UPDATE"}).First(&account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
 
-	if forUpdate {
-		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+// FindByIDWithoutEntries loads the account without its ledger history.
+func (r *AccountRepository) FindByIDWithoutEntries(id uuid.UUID) (*model.Account, error) {
+	var account model.Account
+	if err := r.db.Where("id = ?", id).First(&account); err != nil {
+		return nil, err
 	}
+	return &account, nil
+}
 
-	if err := query.First(&account); err != nil {
+func (r *AccountRepository) FindByIDs(ids []uuid.UUID) ([]model.Account, error) {
+	var accounts []model.Account
+	if err := r.db.Where("id IN ?", ids).Find(&accounts); err != nil {
 		return nil, err
 	}
+	return accounts, nil
+}
+
+func (r *AccountRepository) AccountNumberExists(accountNumber string) (bool, error) {
+	var count int64
+	if err := r.db.Raw("SELECT COUNT(*) FROM accounts WHERE account_number = ?", accountNumber).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
 
-	return &account, nil
+// Find lists accounts matching the filter, oldest first.
+func (r *AccountRepository) Find(filter AccountFilter) ([]model.Account, error) {
+	query := r.db
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.AccountType != "" {
+		query = query.Where("account_type = ?", filter.AccountType)
+	}
+
+	var accounts []model.Account
+	if err := query.Order("created_at, id").Limit(filter.Limit).Offset(filter.Offset).Find(&accounts); err != nil {
+		return nil, err
+	}
+	return accounts, nil
 }
 
 func (r *AccountRepository) Update(account *model.Account) (*model.Account, error) {
@@ -42,6 +100,47 @@ func (r *AccountRepository) Update(account *model.Account) (*model.Account, erro
 	return account, nil
 }
 
+// FindPage returns up to limit accounts with IDs after afterID, in ID order.
+// Pass uuid.Nil for the first page.
+func (r *AccountRepository) FindPage(afterID uuid.UUID, limit int) ([]model.Account, error) {
+	var accounts []model.Account
+	if err := r.db.Where("id > ?", afterID).Order("id").Limit(limit).Find(&accounts); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// changedSinceCondition matches accounts updated or posted to at or after a
+// time. It takes the time twice.
+const changedSinceCondition = "(updated_at >= ? OR EXISTS (SELECT 1 FROM ledger_entries le WHERE le.account_id = accounts.id AND le.created_at >= ?))"
+
+// FindChangedPage is FindPage restricted to the accounts changed since the
+// given time.
+func (r *AccountRepository) FindChangedPage(since time.Time, afterID uuid.UUID, limit int) ([]model.Account, error) {
+	var accounts []model.Account
+	err := r.db.Where("id > ?", afterID).Where(changedSinceCondition, since, since).Order("id").Limit(limit).Find(&accounts)
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (r *AccountRepository) CountChanged(since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.Raw("SELECT COUNT(*) FROM accounts WHERE "+changedSinceCondition, since, since).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *AccountRepository) Count() (int64, error) {
+	var count int64
+	if err := r.db.Raw("SELECT COUNT(*) FROM accounts").Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (r *AccountRepository) FindAll() ([]model.Account, error) {
 	var accounts []model.Account
 	if err := r.db.Find(&accounts); err != nil {
@@ -49,3 +148,98 @@ func (r *AccountRepository) FindAll() ([]model.Account, error) {
 	}
 	return accounts, nil
 }
+
+func (r *AccountRepository) CreateStatusChange(change *model.AccountStatusChange) error {
+	return r.db.Create(change)
+}
+
+// FindStatusChanges returns the account's status history, oldest first.
+func (r *AccountRepository) FindStatusChanges(accountID uuid.UUID) ([]model.AccountStatusChange, error) {
+	var changes []model.AccountStatusChange
+	if err := r.db.Where("account_id = ?", accountID).Order("created_at, id").Find(&changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// AccountActivity is an account with its owner and the time of its last
+// activity: the latest ledger entry other than an interest posting, the
+// latest reactivation, or the opening of the account, whichever is latest.
+type AccountActivity struct {
+	AccountID      uuid.UUID  `json:"account_id"`
+	AccountNumber  string     `json:"account_number"`
+	AccountType    string     `json:"account_type"`
+	CurrencyCode   string     `json:"currency_code"`
+	Balance        float64    `json:"balance"`
+	Status         string     `json:"status"`
+	UserID         uuid.UUID  `json:"user_id"`
+	OwnerName      string     `json:"owner_name"`
+	OwnerEmail     string     `json:"owner_email"`
+	LastActivityAt time.Time  `json:"last_activity_at"`
+	DormantSince   *time.Time `json:"dormant_since,omitempty"`
+}
+
+// AccountActivityFilter selects customer accounts by status and inactivity.
+// System and wallet accounts are never included.
+type AccountActivityFilter struct {
+	AccountID      *uuid.UUID
+	Status         string
+	InactiveBefore *time.Time
+	NonZeroBalance bool
+	AfterID        uuid.UUID
+	Limit          int
+	Offset         int
+}
+
+// FindActivity lists accounts matching the filter with their last activity,
+// in ID order.
+func (r *AccountRepository) FindActivity(filter AccountActivityFilter) ([]AccountActivity, error) {
+	conditions := "a.account_type NOT IN ('system', 'wallet') AND a.id > ?"
+	args := []any{filter.AfterID}
+	if filter.AccountID != nil {
+		conditions += " AND a.id = ?"
+		args = append(args, *filter.AccountID)
+	}
+	if filter.Status != "" {
+		conditions += " AND a.status = ?"
+		args = append(args, filter.Status)
+	}
+	if filter.InactiveBefore != nil {
+		conditions += " AND activity.last_activity_at < ?"
+		args = append(args, *filter.InactiveBefore)
+	}
+	if filter.NonZeroBalance {
+		conditions += " AND a.balance <> 0"
+	}
+	args = append(args, filter.Limit, filter.Offset)
+
+	var activities []AccountActivity
+	err := r.db.Raw(`
+		SELECT a.id AS account_id, a.account_number, a.account_type, a.currency_code, a.balance, a.status,
+			a.user_id, TRIM(COALESCE(u.first_name, '') || ' ' || COALESCE(u.last_name, '')) AS owner_name,
+			COALESCE(u.email, '') AS owner_email, activity.last_activity_at,
+			(SELECT MAX(sc.created_at) FROM account_status_changes sc WHERE sc.account_id = a.id AND sc.to_status = 'dormant') AS dormant_since
+		FROM accounts a
+		LEFT JOIN users u ON u.id = a.user_id
+		CROSS JOIN LATERAL (
+			SELECT GREATEST(
+				a.created_at,
+				(SELECT le.created_at
+					FROM ledger_entries le
+					JOIN transactions t ON t.id = le.transaction_id
+					WHERE le.account_id = a.id AND t.transaction_type <> 'interest'
+					ORDER BY le.created_at DESC
+					LIMIT 1),
+				(SELECT MAX(sc.created_at) FROM account_status_changes sc WHERE sc.account_id = a.id AND sc.to_status = 'active')
+			) AS last_activity_at
+		) activity
+		WHERE `+conditions+`
+		ORDER BY a.id
+		LIMIT ? OFFSET ?`,
+		args...,
+	).Scan(&activities)
+	if err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// AMLRuleHit is an account whose transactions in a monitoring window match
+// an AML rule. Outgoing is only set by the pass-through rule, where Total is
+// the incoming amount.
+type AMLRuleHit struct {
+	AccountID      uuid.UUID
+	UserID         uuid.UUID
+	Count          int64
+	Total          float64
+	Outgoing       float64
+	TransactionIDs model.StringArray
+}
+
+type AMLAlertFilter struct {
+	Status    string
+	Rule      string
+	AccountID *uuid.UUID
+	Limit     int
+	Offset    int
+}
+
+type AMLRepository struct {
+	db database.DB
+}
+
+func NewAMLRepository(db database.DBManager) *AMLRepository {
+	return &AMLRepository{db: db}
+}
+
+func (r *AMLRepository) WithTx(tx database.DB) *AMLRepository {
+	return &AMLRepository{db: tx}
+}
+
+func (r *AMLRepository) WithContext(ctx context.Context) *AMLRepository {
+	return &AMLRepository{db: r.db.WithContext(ctx)}
+}
+
+// Monitored transactions are completed movements between customer accounts;
+// postings to and from system accounts, such as interest, are not monitored.
+const amlOutgoing = `
+	FROM transactions t
+	JOIN accounts a ON a.id = t.source_account_id AND a.account_type <> 'system'
+	WHERE t.status = 'completed' AND t.created_at > ? AND t.created_at <= ?`
+
+// FindStructuring groups the outgoing transactions with an amount in
+// [lower, upper) by source account and returns the accounts with at least
+// minCount of them.
+func (r *AMLRepository) FindStructuring(from, until time.Time, lower, upper float64, minCount int) ([]AMLRuleHit, error) {
+	return r.findOutgoing(`AND t.amount >= ? AND t.amount < ?`, []any{lower, upper}, from, until, minCount)
+}
+
+// FindRoundAmounts returns the accounts that sent at least minCount
+// transactions of a whole multiple of unit.
+func (r *AMLRepository) FindRoundAmounts(from, until time.Time, unit float64, minCount int) ([]AMLRuleHit, error) {
+	return r.findOutgoing(`AND t.amount >= ? AND MOD(t.amount, ?) = 0`, []any{unit, unit}, from, until, minCount)
+}
+
+// FindLargeTransactions returns the accounts that sent transactions of the
+// given types of at least minAmount.
+func (r *AMLRepository) FindLargeTransactions(from, until time.Time, transactionTypes []string, minAmount float64) ([]AMLRuleHit, error) {
+	return r.findOutgoing(`AND t.transaction_type IN ? AND t.amount >= ?`, []any{transactionTypes, minAmount}, from, until, 1)
+}
+
+func (r *AMLRepository) findOutgoing(condition string, args []any, from, until time.Time, minCount int) ([]AMLRuleHit, error) {
+	var hits []AMLRuleHit
+	err := r.db.Raw(`
+		SELECT t.source_account_id AS account_id, a.user_id, COUNT(*) AS count, SUM(t.amount) AS total,
+			jsonb_agg(t.id::text ORDER BY t.created_at, t.id) AS transaction_ids`+
+		amlOutgoing+` `+condition+`
+		GROUP BY t.source_account_id, a.user_id
+		HAVING COUNT(*) >= ?
+		ORDER BY t.source_account_id`,
+		append(append([]any{from, until}, args...), minCount)...,
+	).Scan(&hits)
+	if err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// FindPassThrough returns the accounts that received at least minIncoming
+// and sent on at least ratio of it after the first receipt, within the
+// window. Both directions are listed in TransactionIDs.
+func (r *AMLRepository) FindPassThrough(from, until time.Time, minIncoming, ratio float64) ([]AMLRuleHit, error) {
+	var hits []AMLRuleHit
+	err := r.db.Raw(`
+		WITH flows AS (
+			SELECT t.destination_account_id AS account_id, t.id, t.amount, t.created_at, 'in' AS direction
+			FROM transactions t
+			WHERE t.status = 'completed' AND t.created_at > ? AND t.created_at <= ? AND t.destination_account_id IS NOT NULL
+			UNION ALL
+			SELECT t.source_account_id, t.id, t.amount, t.created_at, 'out'
+			FROM transactions t
+			WHERE t.status = 'completed' AND t.created_at > ? AND t.created_at <= ? AND t.source_account_id IS NOT NULL
+		), first_in AS (
+			SELECT account_id, MIN(created_at) AS at FROM flows WHERE direction = 'in' GROUP BY account_id
+		)
+		SELECT f.account_id, a.user_id, COUNT(*) AS count,
+			COALESCE(SUM(f.amount) FILTER (WHERE f.direction = 'in'), 0) AS total,
+			COALESCE(SUM(f.amount) FILTER (WHERE f.direction = 'out'), 0) AS outgoing,
+			jsonb_agg(f.id::text ORDER BY f.created_at, f.id) AS transaction_ids
+		FROM flows f
+		JOIN first_in fi ON fi.account_id = f.account_id
+		JOIN accounts a ON a.id = f.account_id AND a.account_type <> 'system'
+		WHERE f.direction = 'in' OR f.created_at > fi.at
+		GROUP BY f.account_id, a.user_id
+		HAVING COALESCE(SUM(f.amount) FILTER (WHERE f.direction = 'in'), 0) >= ?
+			AND COALESCE(SUM(f.amount) FILTER (WHERE f.direction = 'out'), 0) >= ? * SUM(f.amount) FILTER (WHERE f.direction = 'in')
+		ORDER BY f.account_id`,
+		from, until, from, until, minIncoming, ratio,
+	).Scan(&hits)
+	if err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+func (r *AMLRepository) CreateAlert(alert *model.AMLAlert) error {
+	return r.db.Create(alert)
+}
+
+func (r *AMLRepository) UpdateAlert(alert *model.AMLAlert) error {
+	return r.db.Save(alert)
+}
+
+// FindAlertsByRuleAndAccount returns every alert the rule raised on the
+// account, whatever its status.
+func (r *AMLRepository) FindAlertsByRuleAndAccount(rule string, accountID uuid.UUID) ([]model.AMLAlert, error) {
+	var alerts []model.AMLAlert
+	if err := r.db.Where("rule = ? AND account_id = ?", rule, accountID).Order("created_at").Find(&alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+func (r *AMLRepository) FindAlertByID(id uuid.UUID, forUpdate bool) (*model.AMLAlert, error) {
+	var alert model.AMLAlert
+	query := r.db.Where("id = ?", id)
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	if err := query.First(&alert); err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// FindAlertsByIDsForUpdate locks the alerts in ID order.
+func (r *AMLRepository) FindAlertsByIDsForUpdate(ids []uuid.UUID) ([]model.AMLAlert, error) {
+	var alerts []model.AMLAlert
+	if err := r.db.Where("id IN ?", ids).Order("id").Clauses(clause.Locking{Strength: "UPDATE"}).Find(&alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// FindAlerts lists alerts matching the filter, newest first.
+func (r *AMLRepository) FindAlerts(filter AMLAlertFilter) ([]model.AMLAlert, error) {
+	query := r.db
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Rule != "" {
+		query = query.Where("rule = ?", filter.Rule)
+	}
+	if filter.AccountID != nil {
+		query = query.Where("account_id = ?", *filter.AccountID)
+	}
+
+	var alerts []model.AMLAlert
+	if err := query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+func (r *AMLRepository) CreateReport(report *model.SuspiciousActivityReport) error {
+	return r.db.Create(report)
+}
+
+func (r *AMLRepository) UpdateReport(report *model.SuspiciousActivityReport) error {
+	return r.db.Save(report)
+}
+
+// FindReportByID loads the report with its alerts.
+func (r *AMLRepository) FindReportByID(id uuid.UUID, forUpdate bool) (*model.SuspiciousActivityReport, error) {
+	var report model.SuspiciousActivityReport
+	query := r.db.Where("id = ?", id)
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	if err := query.First(&report); err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("report_id = ?", id).Order("created_at, id").Find(&report.Alerts); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// FindReports lists reports, newest first, without their alerts.
+func (r *AMLRepository) FindReports(status string, limit, offset int) ([]model.SuspiciousActivityReport, error) {
+	query := r.db
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var reports []model.SuspiciousActivityReport
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
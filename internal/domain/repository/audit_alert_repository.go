@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAlertFilter struct {
+	AccountID *uuid.UUID
+	Resolved  *bool
+	Limit     int
+	Offset    int
+}
+
+type AuditAlertRepository struct {
+	db database.DB
+}
+
+func NewAuditAlertRepository(db database.DBManager) *AuditAlertRepository {
+	return &AuditAlertRepository{db: db}
+}
+
+func (r *AuditAlertRepository) WithTx(tx database.DB) *AuditAlertRepository {
+	return &AuditAlertRepository{db: tx}
+}
+
+// Record stores a sighting of the alert's finding. It inserts the alert if
+// its fingerprint has no unresolved alert, and otherwise counts another
+// occurrence on the existing one. alert.ID is set to the stored alert, and
+// pending reports whether it has not been delivered yet.
+func (r *AuditAlertRepository) Record(alert *model.AuditAlert) (pending bool, err error) {
+	var row struct {
+		ID      uuid.UUID
+		Pending bool
+	}
+	err = r.db.Raw(`
+		INSERT INTO audit_alerts (fingerprint, account_id, account_number, detector, fraud_type, severity, message, occurrences, first_seen_at, last_seen_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, 1, ?, ?)
+		ON CONFLICT (fingerprint) WHERE resolved_at IS NULL
+		DO UPDATE SET occurrences = audit_alerts.occurrences + 1, last_seen_at = EXCLUDED.last_seen_at
+		RETURNING id, notified_at IS NULL AS pending`,
+		alert.Fingerprint, alert.AccountID, alert.AccountNumber, alert.Detector, alert.FraudType,
+		alert.Severity, alert.Message, alert.FirstSeenAt, alert.LastSeenAt,
+	).Scan(&row)
+	if err != nil {
+		return false, err
+	}
+	alert.ID = row.ID
+	return row.Pending, nil
+}
+
+func (r *AuditAlertRepository) MarkNotified(id uuid.UUID, at time.Time) error {
+	_, err := r.db.Exec("UPDATE audit_alerts SET notified_at = ?, last_error = '' WHERE id = ?", at, id)
+	return err
+}
+
+func (r *AuditAlertRepository) MarkFailed(id uuid.UUID, message string) error {
+	_, err := r.db.Exec("UPDATE audit_alerts SET last_error = ? WHERE id = ?", message, id)
+	return err
+}
+
+// ResolveMissing resolves the account's unresolved alerts whose fingerprint
+// is not in current, and returns how many it resolved.
+func (r *AuditAlertRepository) ResolveMissing(accountID uuid.UUID, current []string, at time.Time) (int64, error) {
+	if len(current) == 0 {
+		return r.db.Exec("UPDATE audit_alerts SET resolved_at = ? WHERE account_id = ? AND resolved_at IS NULL", at, accountID)
+	}
+	return r.db.Exec(
+		"UPDATE audit_alerts SET resolved_at = ? WHERE account_id = ? AND resolved_at IS NULL AND fingerprint NOT IN ?",
+		at, accountID, current,
+	)
+}
+
+// Find lists alerts, latest sighting first.
+func (r *AuditAlertRepository) Find(filter AuditAlertFilter) ([]model.AuditAlert, error) {
+	query := r.db
+	if filter.AccountID != nil {
+		query = query.Where("account_id = ?", *filter.AccountID)
+	}
+	if filter.Resolved != nil {
+		if *filter.Resolved {
+			query = query.Where("resolved_at IS NOT NULL")
+		} else {
+			query = query.Where("resolved_at IS NULL")
+		}
+	}
+
+	var alerts []model.AuditAlert
+	if err := query.Order("last_seen_at DESC, id").Limit(filter.Limit).Offset(filter.Offset).Find(&alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
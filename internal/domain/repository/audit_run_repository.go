@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"encoding/json"
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FindingFilter struct {
+	Status    string
+	FraudType string
+	Limit     int
+	Offset    int
+}
+
+// FindingDifference is an account whose finding differs between two runs.
+// The base or target side is empty when the account was only audited in the
+// other run.
+type FindingDifference struct {
+	AccountID         uuid.UUID         `json:"account_id"`
+	AccountNumber     string            `json:"account_number"`
+	BaseStatus        *string           `json:"base_status"`
+	TargetStatus      *string           `json:"target_status"`
+	BaseFraudTypes    model.StringArray `json:"base_fraud_types"`
+	TargetFraudTypes  model.StringArray `json:"target_fraud_types"`
+	BaseDiscrepancy   *float64          `json:"base_discrepancy"`
+	TargetDiscrepancy *float64          `json:"target_discrepancy"`
+}
+
+type AuditRunRepository struct {
+	db database.DB
+}
+
+func NewAuditRunRepository(db database.DBManager) *AuditRunRepository {
+	return &AuditRunRepository{db: db}
+}
+
+func (r *AuditRunRepository) WithTx(tx database.DB) *AuditRunRepository {
+	return &AuditRunRepository{db: tx}
+}
+
+func (r *AuditRunRepository) CreateRun(run *model.AuditRun) error {
+	return r.db.Create(run)
+}
+
+func (r *AuditRunRepository) UpdateRun(run *model.AuditRun) error {
+	return r.db.Save(run)
+}
+
+// TouchRun moves the UpdatedAt of a running run to at, marking it alive
+// without writing its progress.
+func (r *AuditRunRepository) TouchRun(id uuid.UUID, at time.Time) error {
+	_, err := r.db.Exec("UPDATE audit_runs SET updated_at = ? WHERE id = ? AND status = ?", at, id, "running")
+	return err
+}
+
+func (r *AuditRunRepository) FindRunByID(id uuid.UUID) (*model.AuditRun, error) {
+	var run model.AuditRun
+	if err := r.db.Where("id = ?", id).First(&run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *AuditRunRepository) FindRuns(limit int) ([]model.AuditRun, error) {
+	var runs []model.AuditRun
+	if err := r.db.Order("started_at DESC").Limit(limit).Find(&runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// auditRunLockKey identifies the advisory lock that serialises starting audit
+// runs. It spells "auditrun" in ASCII.
+const auditRunLockKey int64 = 0x617564697472756e
+
+// LockRunStart takes the transaction-scoped advisory lock for starting runs,
+// waiting until no other transaction holds it.
+func (r *AuditRunRepository) LockRunStart() error {
+	_, err := r.db.Exec("SELECT pg_advisory_xact_lock(?)", auditRunLockKey)
+	return err
+}
+
+func (r *AuditRunRepository) FindRunning() ([]model.AuditRun, error) {
+	var runs []model.AuditRun
+	if err := r.db.Where("status = ?", "running").Find(&runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// FindLastCompleted returns the most recently started completed run, or nil
+// if no run has completed yet.
+func (r *AuditRunRepository) FindLastCompleted() (*model.AuditRun, error) {
+	var run model.AuditRun
+	if err := r.db.Where("status = ?", "completed").Order("started_at DESC").First(&run); err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *AuditRunRepository) CreateFindings(findings []model.AuditFinding) error {
+	if len(findings) == 0 {
+		return nil
+	}
+	return r.db.Create(&findings)
+}
+
+// FindFindings returns a page of the run's findings matching the filter,
+// ordered by account number, and the number of matching findings.
+func (r *AuditRunRepository) FindFindings(runID uuid.UUID, filter FindingFilter) ([]model.AuditFinding, int64, error) {
+	query := "run_id = ?"
+	args := []any{runID}
+
+	if filter.Status != "" {
+		query += " AND status = ?"
+		args = append(args, filter.Status)
+	}
+	if filter.FraudType != "" {
+		containment, err := json.Marshal([]string{filter.FraudType})
+		if err != nil {
+			return nil, 0, err
+		}
+		query += " AND fraud_types @> ?::jsonb"
+		args = append(args, string(containment))
+	}
+
+	var total int64
+	if err := r.db.Raw("SELECT COUNT(*) FROM audit_findings WHERE "+query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	var findings []model.AuditFinding
+	err := r.db.Where(query, args...).
+		Order("account_number, account_id").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&findings)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return findings, total, nil
+}
+
+// CompareRuns returns the accounts whose status, fraud types or discrepancy
+// differ between the two runs, including accounts audited in only one of them.
+func (r *AuditRunRepository) CompareRuns(baseRunID, targetRunID uuid.UUID) ([]FindingDifference, error) {
+	var differences []FindingDifference
+	err := r.db.Raw(`
+		SELECT COALESCE(t.account_id, b.account_id) AS account_id,
+			COALESCE(t.account_number, b.account_number) AS account_number,
+			b.status AS base_status,
+			t.status AS target_status,
+			b.fraud_types AS base_fraud_types,
+			t.fraud_types AS target_fraud_types,
+			b.balance_discrepancy AS base_discrepancy,
+			t.balance_discrepancy AS target_discrepancy
+		FROM (SELECT * FROM audit_findings WHERE run_id = ?) b
+		FULL OUTER JOIN (SELECT * FROM audit_findings WHERE run_id = ?) t ON t.account_id = b.account_id
+		WHERE b.account_id IS NULL
+			OR t.account_id IS NULL
+			OR b.status <> t.status
+			OR b.fraud_types <> t.fraud_types
+			OR b.balance_discrepancy <> t.balance_discrepancy
+		ORDER BY account_number, account_id`,
+		baseRunID, targetRunID,
+	).Scan(&differences)
+	if err != nil {
+		return nil, err
+	}
+	return differences, nil
+}
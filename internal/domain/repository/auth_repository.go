@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type AuthRepository struct {
+	db database.DB
+}
+
+func NewAuthRepository(db database.DBManager) *AuthRepository {
+	return &AuthRepository{db: db}
+}
+
+func (r *AuthRepository) WithTx(tx database.DB) *AuthRepository {
+	return &AuthRepository{db: tx}
+}
+
+func (r *AuthRepository) CreateUser(user *model.User) error {
+	return r.db.Create(user)
+}
+
+// FindUserByEmail matches the email case-insensitively.
+func (r *AuthRepository) FindUserByEmail(email string) (*model.User, error) {
+	var user model.User
+	if err := r.db.Where("LOWER(email) = LOWER(?)", email).First(&user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *AuthRepository) EmailExists(email string) (bool, error) {
+	var count int64
+	if err := r.db.Raw("SELECT COUNT(*) FROM users WHERE LOWER(email) = LOWER(?)", email).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *AuthRepository) CreateRefreshToken(token *model.RefreshToken) error {
+	return r.db.Create(token)
+}
+
+func (r *AuthRepository) UpdateRefreshToken(token *model.RefreshToken) error {
+	return r.db.Save(token)
+}
+
+// FindRefreshTokenForUpdate locks the token with the given hash, so
+// concurrent refreshes with the same token cannot both rotate it.
+func (r *AuthRepository) FindRefreshTokenForUpdate(tokenHash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	if err := r.db.Where("token_hash = ?", tokenHash).Clauses(clause.Locking{Strength: "UPDATE"}).First(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *AuthRepository) RevokeFamily(familyID uuid.UUID, at time.Time) error {
+	_, err := r.db.Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL", at, familyID)
+	return err
+}
+
+// RevokeUser revokes every session of the user and returns how many tokens
+// were still live.
+func (r *AuthRepository) RevokeUser(userID uuid.UUID, at time.Time) (int64, error) {
+	return r.db.Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL", at, userID)
+}
+
+// SessionActive reports whether the session still has an unrevoked, unexpired
+// refresh token and its user is active. Access tokens of a session stop
+// working as soon as it is revoked.
+func (r *AuthRepository) SessionActive(familyID, userID uuid.UUID, now time.Time) (bool, error) {
+	var active bool
+	err := r.db.Raw(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM refresh_tokens rt
+			JOIN users u ON u.id = rt.user_id
+			WHERE rt.family_id = ? AND rt.user_id = ? AND rt.revoked_at IS NULL AND rt.expires_at > ? AND u.status = 'active'
+		)`,
+		familyID, userID, now,
+	).Scan(&active)
+	if err != nil {
+		return false, err
+	}
+	return active, nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// ReconciliationEntry is a ledger entry of a settlement account in the shape
+// the reconciliation matcher compares with bank statement lines.
+type ReconciliationEntry struct {
+	EntryID       uuid.UUID `json:"entry_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Reference     string    `json:"reference"`
+	Description   string    `json:"description"`
+	EntryType     string    `json:"entry_type"`
+	Amount        float64   `json:"amount"`
+	BookedAt      time.Time `json:"booked_at"`
+	// ValueDate is the entry's value date, or its booking date if it has none.
+	ValueDate time.Time `json:"value_date"`
+}
+
+// LineStatusCount is the number of lines of a statement in one status.
+type LineStatusCount struct {
+	Status string
+	Count  int64
+}
+
+type BankStatementRepository struct {
+	db database.DB
+}
+
+func NewBankStatementRepository(db database.DBManager) *BankStatementRepository {
+	return &BankStatementRepository{db: db}
+}
+
+func (r *BankStatementRepository) WithTx(tx database.DB) *BankStatementRepository {
+	return &BankStatementRepository{db: tx}
+}
+
+// Create stores the statement together with its lines.
+func (r *BankStatementRepository) Create(statement *model.BankStatement) error {
+	return r.db.Create(statement)
+}
+
+func (r *BankStatementRepository) ExistsByHash(fileHash string) (bool, error) {
+	var statements []model.BankStatement
+	if err := r.db.Where("file_hash = ?", fileHash).Limit(1).Find(&statements); err != nil {
+		return false, err
+	}
+	return len(statements) > 0, nil
+}
+
+// FindByID loads the statement without its lines.
+func (r *BankStatementRepository) FindByID(id uuid.UUID) (*model.BankStatement, error) {
+	var statement model.BankStatement
+	if err := r.db.Where("id = ?", id).First(&statement); err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+// Find lists statements, newest first, optionally only those of one account.
+func (r *BankStatementRepository) Find(accountID *uuid.UUID, limit int) ([]model.BankStatement, error) {
+	query := r.db
+	if accountID != nil {
+		query = query.Where("account_id = ?", *accountID)
+	}
+
+	var statements []model.BankStatement
+	if err := query.Order("imported_at DESC").Limit(limit).Find(&statements); err != nil {
+		return nil, err
+	}
+	return statements, nil
+}
+
+// FindLines returns the statement's lines in file order, optionally only
+// those in the given statuses.
+func (r *BankStatementRepository) FindLines(statementID uuid.UUID, statuses ...string) ([]model.BankStatementLine, error) {
+	query := r.db.Where("statement_id = ?", statementID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	var lines []model.BankStatementLine
+	if err := query.Order("line_number").Find(&lines); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func (r *BankStatementRepository) FindLineByID(id uuid.UUID, forUpdate bool) (*model.BankStatementLine, error) {
+	var line model.BankStatementLine
+	query := r.db.Where("id = ?", id)
+
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	if err := query.First(&line); err != nil {
+		return nil, err
+	}
+	return &line, nil
+}
+
+func (r *BankStatementRepository) UpdateLine(line *model.BankStatementLine) error {
+	return r.db.Save(line)
+}
+
+func (r *BankStatementRepository) CountLinesByStatus(statementID uuid.UUID) ([]LineStatusCount, error) {
+	var counts []LineStatusCount
+	err := r.db.Raw(`
+		SELECT status, COUNT(*) AS count
+		FROM bank_statement_lines
+		WHERE statement_id = ?
+		GROUP BY status`,
+		statementID,
+	).Scan(&counts)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// FindUnreconciledEntries returns the account's ledger entries with a value
+// date in [from, to] that no statement line is matched to, oldest first.
+func (r *BankStatementRepository) FindUnreconciledEntries(accountID uuid.UUID, from, to time.Time) ([]ReconciliationEntry, error) {
+	var entries []ReconciliationEntry
+	err := r.db.Raw(`
+		SELECT le.id AS entry_id, le.transaction_id,
+			COALESCE(t.transaction_reference, '') AS reference,
+			COALESCE(t.description, '') AS description,
+			le.entry_type, le.amount, le.created_at AS booked_at,
+			COALESCE(le.value_date, le.created_at::date) AS value_date
+		FROM ledger_entries le
+		LEFT JOIN transactions t ON t.id = le.transaction_id
+		WHERE le.account_id = ?
+			AND COALESCE(le.value_date, le.created_at::date) BETWEEN ?::date AND ?::date
+			AND NOT EXISTS (
+				SELECT 1 FROM bank_statement_lines bsl WHERE bsl.matched_entry_id = le.id
+			)
+		ORDER BY le.created_at, le.id`,
+		accountID, from, to,
+	).Scan(&entries)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindEntry loads one ledger entry of the account for a manual match.
+func (r *BankStatementRepository) FindEntry(accountID, entryID uuid.UUID) (*ReconciliationEntry, error) {
+	var entries []ReconciliationEntry
+	err := r.db.Raw(`
+		SELECT le.id AS entry_id, le.transaction_id,
+			COALESCE(t.transaction_reference, '') AS reference,
+			COALESCE(t.description, '') AS description,
+			le.entry_type, le.amount, le.created_at AS booked_at,
+			COALESCE(le.value_date, le.created_at::date) AS value_date
+		FROM ledger_entries le
+		LEFT JOIN transactions t ON t.id = le.transaction_id
+		WHERE le.account_id = ? AND le.id = ?`,
+		accountID, entryID,
+	).Scan(&entries)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+// FindLineByMatchedEntry returns the line the entry is matched to, or nil.
+func (r *BankStatementRepository) FindLineByMatchedEntry(entryID uuid.UUID) (*model.BankStatementLine, error) {
+	var lines []model.BankStatementLine
+	if err := r.db.Where("matched_entry_id = ?", entryID).Limit(1).Find(&lines); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	return &lines[0], nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CheckpointRepository struct {
+	db database.DB
+}
+
+func NewCheckpointRepository(db database.DBManager) *CheckpointRepository {
+	return &CheckpointRepository{db: db}
+}
+
+func (r *CheckpointRepository) WithTx(tx database.DB) *CheckpointRepository {
+	return &CheckpointRepository{db: tx}
+}
+
+func (r *CheckpointRepository) WithContext(ctx context.Context) *CheckpointRepository {
+	return &CheckpointRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *CheckpointRepository) Create(checkpoint *model.BalanceCheckpoint) error {
+	return r.db.Create(checkpoint)
+}
+
+func (r *CheckpointRepository) Update(checkpoint *model.BalanceCheckpoint) error {
+	return r.db.Save(checkpoint)
+}
+
+func (r *CheckpointRepository) FindByID(id uuid.UUID) (*model.BalanceCheckpoint, error) {
+	var checkpoint model.BalanceCheckpoint
+	if err := r.db.Where("id = ?", id).First(&checkpoint); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// FindLatest returns the most recent checkpoint of the account, or nil if none exists.
+func (r *CheckpointRepository) FindLatest(accountID uuid.UUID) (*model.BalanceCheckpoint, error) {
+	return r.findLatest(r.db.Where("account_id = ?", accountID))
+}
+
+// FindLatestVerified returns the most recent verified checkpoint of the account, or nil if none exists.
+func (r *CheckpointRepository) FindLatestVerified(accountID uuid.UUID) (*model.BalanceCheckpoint, error) {
+	return r.findLatest(r.db.Where("account_id = ? AND verified = ?", accountID, true))
+}
+
+// FindLatestVerifiedBefore returns the most recent verified checkpoint covering
+// only entries booked before t, or nil if none exists.
+func (r *CheckpointRepository) FindLatestVerifiedBefore(accountID uuid.UUID, t time.Time) (*model.BalanceCheckpoint, error) {
+	return r.findLatest(r.db.Where("account_id = ? AND verified = ? AND covered_until < ?", accountID, true, t))
+}
+
+// FindVerified returns the account's verified checkpoints, oldest first.
+func (r *CheckpointRepository) FindVerified(accountID uuid.UUID) ([]model.BalanceCheckpoint, error) {
+	var checkpoints []model.BalanceCheckpoint
+	if err := r.db.Where("account_id = ? AND verified = ?", accountID, true).Order("covered_until").Find(&checkpoints); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+// InvalidateFrom marks the account's checkpoints covering until the given
+// time or later as unverified, returning how many were still verified.
+func (r *CheckpointRepository) InvalidateFrom(accountID uuid.UUID, coveredUntil time.Time) (int64, error) {
+	return r.db.Exec("UPDATE balance_checkpoints SET verified = false WHERE account_id = ? AND covered_until >= ? AND verified", accountID, coveredUntil)
+}
+
+func (r *CheckpointRepository) findLatest(query database.DB) (*model.BalanceCheckpoint, error) {
+	var checkpoint model.BalanceCheckpoint
+	if err := query.Order("covered_until DESC").First(&checkpoint); err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &checkpoint, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type FraudCaseFilter struct {
+	Status     string
+	AccountID  *uuid.UUID
+	AssignedTo string
+	Limit      int
+	Offset     int
+}
+
+type FraudCaseRepository struct {
+	db database.DB
+}
+
+func NewFraudCaseRepository(db database.DBManager) *FraudCaseRepository {
+	return &FraudCaseRepository{db: db}
+}
+
+func (r *FraudCaseRepository) WithTx(tx database.DB) *FraudCaseRepository {
+	return &FraudCaseRepository{db: tx}
+}
+
+func (r *FraudCaseRepository) Create(fraudCase *model.FraudCase) error {
+	return r.db.Create(fraudCase)
+}
+
+func (r *FraudCaseRepository) Update(fraudCase *model.FraudCase) error {
+	return r.db.Save(fraudCase)
+}
+
+func (r *FraudCaseRepository) CreateEvent(event *model.FraudCaseEvent) error {
+	return r.db.Create(event)
+}
+
+// FindByID loads the case with its history, oldest event first.
+func (r *FraudCaseRepository) FindByID(id uuid.UUID, forUpdate bool) (*model.FraudCase, error) {
+	var fraudCase model.FraudCase
+	query := r.db.Where("id = ?", id)
+
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	if err := query.First(&fraudCase); err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("case_id = ?", id).Order("created_at, id").Find(&fraudCase.Events); err != nil {
+		return nil, err
+	}
+	return &fraudCase, nil
+}
+
+// FindUnresolvedByAccount returns the account's unresolved case, or nil.
+func (r *FraudCaseRepository) FindUnresolvedByAccount(accountID uuid.UUID) (*model.FraudCase, error) {
+	var cases []model.FraudCase
+	if err := r.db.Where("account_id = ? AND status <> ?", accountID, "resolved").Order("created_at DESC").Limit(1).Find(&cases); err != nil {
+		return nil, err
+	}
+	if len(cases) == 0 {
+		return nil, nil
+	}
+	return &cases[0], nil
+}
+
+// FindResolvedFindings returns the findings of the account's resolved cases.
+func (r *FraudCaseRepository) FindResolvedFindings(accountID uuid.UUID) (model.DetectorFindings, error) {
+	var cases []model.FraudCase
+	if err := r.db.Where("account_id = ? AND status = ?", accountID, "resolved").Find(&cases); err != nil {
+		return nil, err
+	}
+
+	var findings model.DetectorFindings
+	for _, fraudCase := range cases {
+		findings = append(findings, fraudCase.Findings...)
+	}
+	return findings, nil
+}
+
+// Find lists cases matching the filter, newest first, without their history.
+func (r *FraudCaseRepository) Find(filter FraudCaseFilter) ([]model.FraudCase, error) {
+	query := r.db
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.AccountID != nil {
+		query = query.Where("account_id = ?", *filter.AccountID)
+	}
+	if filter.AssignedTo != "" {
+		query = query.Where("assigned_to = ?", filter.AssignedTo)
+	}
+
+	var cases []model.FraudCase
+	if err := query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&cases); err != nil {
+		return nil, err
+	}
+	return cases, nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type InterestRepository struct {
+	db database.DB
+}
+
+func NewInterestRepository(db database.DBManager) *InterestRepository {
+	return &InterestRepository{db: db}
+}
+
+func (r *InterestRepository) WithTx(tx database.DB) *InterestRepository {
+	return &InterestRepository{db: tx}
+}
+
+func (r *InterestRepository) CreateProduct(product *model.SavingsProduct) error {
+	return r.db.Create(product)
+}
+
+func (r *InterestRepository) FindProductByID(id uuid.UUID) (*model.SavingsProduct, error) {
+	var product model.SavingsProduct
+	if err := r.db.Where("id = ?", id).First(&product); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *InterestRepository) FindProducts() ([]model.SavingsProduct, error) {
+	var products []model.SavingsProduct
+	if err := r.db.Order("code").Find(&products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// FindSavingsAccounts returns the active accounts attached to a savings product.
+func (r *InterestRepository) FindSavingsAccounts() ([]model.Account, error) {
+	var accounts []model.Account
+	if err := r.db.Where("product_id IS NOT NULL AND status = ?", "active").Find(&accounts); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// FindLatestAccrualDate returns the last day interest was accrued for the
+// account, or nil if it never was.
+func (r *InterestRepository) FindLatestAccrualDate(accountID uuid.UUID) (*time.Time, error) {
+	var accrual model.InterestAccrual
+	if err := r.db.Where("account_id = ?", accountID).Order("accrual_date DESC").First(&accrual); err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &accrual.AccrualDate, nil
+}
+
+// CreateAccrual records the accrual unless one already exists for the same
+// account and day, so re-running a day never accrues twice.
+func (r *InterestRepository) CreateAccrual(accrual *model.InterestAccrual) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "accrual_date"}},
+		DoNothing: true,
+	}).Create(accrual)
+}
+
+// FindUnpostedBefore locks and returns the account's accruals not yet paid out
+// for days before the given date.
+func (r *InterestRepository) FindUnpostedBefore(accountID uuid.UUID, before time.Time) ([]model.InterestAccrual, error) {
+	var accruals []model.InterestAccrual
+	err := r.db.Where("account_id = ? AND status = ? AND accrual_date < ?", accountID, "accrued", before.Format(time.DateOnly)).
+		Order("accrual_date").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Find(&accruals)
+	if err != nil {
+		return nil, err
+	}
+	return accruals, nil
+}
+
+func (r *InterestRepository) MarkPosted(ids []uuid.UUID, transactionID *uuid.UUID, postedAt time.Time) error {
+	_, err := r.db.Exec(
+		"UPDATE interest_accruals SET status = ?, posted_transaction_id = ?, posted_at = ? WHERE id IN ?",
+		"posted", transactionID, postedAt, ids,
+	)
+	return err
+}
+
+// FindAccruals returns the account's accruals for days in [from, to].
+func (r *InterestRepository) FindAccruals(accountID uuid.UUID, from, to time.Time) ([]model.InterestAccrual, error) {
+	var accruals []model.InterestAccrual
+	err := r.db.Where("account_id = ? AND accrual_date BETWEEN ? AND ?", accountID, from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Order("accrual_date").
+		Find(&accruals)
+	if err != nil {
+		return nil, err
+	}
+	return accruals, nil
+}
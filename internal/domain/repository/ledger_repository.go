@@ -0,0 +1,561 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountBalance is the ledger balance of a single account at a point in time.
+type AccountBalance struct {
+	AccountID uuid.UUID
+	Balance   float64
+}
+
+// StatementLine is a ledger entry joined with its transaction and the account
+// on the other side of the transaction.
+type StatementLine struct {
+	EntryID                   uuid.UUID  `json:"entry_id"`
+	TransactionID             uuid.UUID  `json:"transaction_id"`
+	Reference                 string     `json:"reference"`
+	TransactionType           string     `json:"transaction_type"`
+	Description               string     `json:"description"`
+	EntryType                 string     `json:"entry_type"`
+	Amount                    float64    `json:"amount"`
+	RunningBalance            float64    `json:"running_balance"`
+	BookedAt                  time.Time  `json:"booked_at"`
+	ValueDate                 *time.Time `json:"value_date,omitempty"`
+	CounterpartyAccountID     *uuid.UUID `json:"counterparty_account_id,omitempty"`
+	CounterpartyAccountNumber string     `json:"counterparty_account_number,omitempty"`
+}
+
+// EntryTotals holds the number and sum of an account's debit and credit entries over a period.
+type EntryTotals struct {
+	DebitCount  int
+	DebitTotal  float64
+	CreditCount int
+	CreditTotal float64
+}
+
+type LedgerRepository struct {
+	db database.DB
+}
+
+func NewLedgerRepository(db database.DBManager) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+func (r *LedgerRepository) WithTx(tx database.DB) *LedgerRepository {
+	return &LedgerRepository{db: tx}
+}
+
+func (r *LedgerRepository) WithContext(ctx context.Context) *LedgerRepository {
+	return &LedgerRepository{db: r.db.WithContext(ctx)}
+}
+
+// FindByAccountBetween returns the account's entries with after < created_at <= until,
+// in booking order. A zero after means from the beginning of the ledger.
+func (r *LedgerRepository) FindByAccountBetween(accountID uuid.UUID, after, until time.Time) ([]model.LedgerEntry, error) {
+	var entries []model.LedgerEntry
+	query := r.db.Where("account_id = ? AND created_at <= ?", accountID, until)
+
+	if !after.IsZero() {
+		query = query.Where("created_at > ?", after)
+	}
+
+	if err := query.Order("created_at, id").Find(&entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// LedgerCursor is the booking position of the last entry of a page.
+type LedgerCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// FindPageByAccount returns up to limit of the account's entries in booking
+// order, starting after the cursor, or from the first entry if it is nil.
+// The page is read with a keyset on (created_at, id), so every page costs
+// the same however deep into the history it is.
+func (r *LedgerRepository) FindPageByAccount(accountID uuid.UUID, after *LedgerCursor, limit int) ([]model.LedgerEntry, error) {
+	query := r.db.Where("account_id = ?", accountID)
+	if after != nil {
+		query = query.Where("(created_at, id) > (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	var entries []model.LedgerEntry
+	if err := query.Order("created_at, id").Limit(limit).Find(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// BalancesAsOf returns the running balance of the last entry booked at or
+// before asOf for each account. Accounts without entries by then are omitted.
+func (r *LedgerRepository) BalancesAsOf(accountIDs []uuid.UUID, asOf time.Time) ([]AccountBalance, error) {
+	var balances []AccountBalance
+	err := r.db.Raw(`
+		SELECT DISTINCT ON (account_id) account_id, running_balance AS balance
+		FROM ledger_entries
+		WHERE account_id IN ? AND created_at <= ?
+		ORDER BY account_id, created_at DESC, id DESC`,
+		accountIDs, asOf,
+	).Scan(&balances)
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// BalancesAsOfValueDate sums the entries whose value date is on or before
+// valueDate for each account. Entries without a value date fall back to their
+// booking date in UTC.
+func (r *LedgerRepository) BalancesAsOfValueDate(accountIDs []uuid.UUID, valueDate time.Time) ([]AccountBalance, error) {
+	var balances []AccountBalance
+	err := r.db.Raw(`
+		SELECT account_id,
+			SUM(CASE WHEN entry_type = 'credit' THEN amount WHEN entry_type = 'debit' THEN -amount ELSE 0 END) AS balance
+		FROM ledger_entries
+		WHERE account_id IN ? AND COALESCE(value_date, (created_at AT TIME ZONE 'UTC')::date) <= ?
+		GROUP BY account_id`,
+		accountIDs, valueDate.Format(time.DateOnly),
+	).Scan(&balances)
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// SumBetween returns the net amount (credits minus debits) of the account's
+// entries with after < created_at < before. A zero after means from the
+// beginning of the ledger.
+func (r *LedgerRepository) SumBetween(accountID uuid.UUID, after, before time.Time) (float64, error) {
+	var sum struct{ Total float64 }
+	err := r.db.Raw(`
+		SELECT COALESCE(SUM(CASE WHEN entry_type = 'credit' THEN amount WHEN entry_type = 'debit' THEN -amount ELSE 0 END), 0) AS total
+		FROM ledger_entries
+		WHERE account_id = ? AND created_at > ? AND created_at < ?`,
+		accountID, after, before,
+	).Scan(&sum)
+	if err != nil {
+		return 0, err
+	}
+	return sum.Total, nil
+}
+
+// TotalsBetween aggregates the account's entries booked in [from, to).
+func (r *LedgerRepository) TotalsBetween(accountID uuid.UUID, from, to time.Time) (*EntryTotals, error) {
+	var totals EntryTotals
+	err := r.db.Raw(`
+		SELECT
+			COUNT(*) FILTER (WHERE entry_type = 'debit') AS debit_count,
+			COALESCE(SUM(amount) FILTER (WHERE entry_type = 'debit'), 0) AS debit_total,
+			COUNT(*) FILTER (WHERE entry_type = 'credit') AS credit_count,
+			COALESCE(SUM(amount) FILTER (WHERE entry_type = 'credit'), 0) AS credit_total
+		FROM ledger_entries
+		WHERE account_id = ? AND created_at >= ? AND created_at < ?`,
+		accountID, from, to,
+	).Scan(&totals)
+	if err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
+
+// StreamStatementLines calls fn for each of the account's entries booked in
+// [from, to), in booking order, without loading the period into memory.
+func (r *LedgerRepository) StreamStatementLines(accountID uuid.UUID, from, to time.Time, fn func(line *StatementLine) error) error {
+	rows, err := r.db.Raw(`
+		SELECT le.id AS entry_id, le.transaction_id,
+			COALESCE(t.transaction_reference, '') AS reference,
+			COALESCE(t.transaction_type, '') AS transaction_type,
+			COALESCE(t.description, '') AS description,
+			le.entry_type, le.amount, le.running_balance,
+			le.created_at AS booked_at, le.value_date,
+			cp.account_id AS counterparty_account_id,
+			COALESCE(ca.account_number, '') AS counterparty_account_number
+		FROM ledger_entries le
+		LEFT JOIN transactions t ON t.id = le.transaction_id
+		LEFT JOIN LATERAL (
+			SELECT o.account_id FROM ledger_entries o
+			WHERE o.transaction_id = le.transaction_id AND o.account_id <> le.account_id
+			ORDER BY o.created_at
+			LIMIT 1
+		) cp ON true
+		LEFT JOIN accounts ca ON ca.id = cp.account_id
+		WHERE le.account_id = ? AND le.created_at >= ? AND le.created_at < ?
+		ORDER BY le.created_at, le.id`,
+		accountID, from, to,
+	).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line StatementLine
+		if err := r.db.ScanRows(rows, &line); err != nil {
+			return err
+		}
+		if err := fn(&line); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// FindOrphanedBetween returns the entries booked in [from, to) whose
+// transaction does not exist.
+func (r *LedgerRepository) FindOrphanedBetween(from, to time.Time) ([]model.LedgerEntry, error) {
+	var entries []model.LedgerEntry
+	err := r.db.Raw(`
+		SELECT le.* FROM ledger_entries le
+		LEFT JOIN transactions t ON t.id = le.transaction_id
+		WHERE t.id IS NULL AND le.created_at >= ? AND le.created_at < ?
+		ORDER BY le.created_at, le.id`,
+		from, to,
+	).Scan(&entries)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindOrphanedByTransactionIDs returns the entries that reference any of the
+// given transaction IDs although no such transaction exists.
+func (r *LedgerRepository) FindOrphanedByTransactionIDs(transactionIDs []uuid.UUID) ([]model.LedgerEntry, error) {
+	var entries []model.LedgerEntry
+	err := r.db.Raw(`
+		SELECT le.* FROM ledger_entries le
+		LEFT JOIN transactions t ON t.id = le.transaction_id
+		WHERE t.id IS NULL AND le.transaction_id IN ?
+		ORDER BY le.created_at, le.id`,
+		transactionIDs,
+	).Scan(&entries)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// DebitStats summarises the debits booked on an account.
+type DebitStats struct {
+	Count   int64
+	Average float64
+}
+
+func (r *LedgerRepository) DebitStatsSince(accountID uuid.UUID, since time.Time) (*DebitStats, error) {
+	var stats DebitStats
+	err := r.db.Raw(`
+		SELECT COUNT(*) AS count, COALESCE(AVG(amount), 0) AS average
+		FROM ledger_entries
+		WHERE account_id = ? AND entry_type = 'debit' AND created_at >= ?`,
+		accountID, since,
+	).Scan(&stats)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// HasPaidSince reports whether a transaction booked since the given time
+// debited fromAccountID and credited toAccountID. Only the source's debits in
+// that range are scanned, so the lookup does not grow with its history.
+func (r *LedgerRepository) HasPaidSince(fromAccountID, toAccountID uuid.UUID, since time.Time) (bool, error) {
+	var paid bool
+	err := r.db.Raw(`
+		SELECT EXISTS (
+			SELECT 1 FROM ledger_entries d
+			JOIN ledger_entries c ON c.transaction_id = d.transaction_id
+				AND c.account_id = ? AND c.entry_type = 'credit'
+			WHERE d.account_id = ? AND d.created_at >= ? AND d.entry_type = 'debit'
+		)`,
+		toAccountID, fromAccountID, since,
+	).Scan(&paid)
+	if err != nil {
+		return false, err
+	}
+	return paid, nil
+}
+
+// LedgerSummary aggregates an account's entries booked after a point in time.
+type LedgerSummary struct {
+	EntryCount         int64
+	Net                float64 // credits minus debits
+	LastRunningBalance *float64
+	LastEntryAt        *time.Time
+}
+
+// Summarize aggregates the account's entries booked after the given time in
+// the database, so the entries never have to be loaded.
+func (r *LedgerRepository) Summarize(accountID uuid.UUID, after time.Time) (*LedgerSummary, error) {
+	var summary LedgerSummary
+	err := r.db.Raw(`
+		SELECT COUNT(*) AS entry_count,
+			COALESCE(SUM(CASE entry_type WHEN 'credit' THEN amount WHEN 'debit' THEN -amount ELSE 0 END), 0) AS net,
+			(
+				SELECT l.running_balance FROM ledger_entries l
+				WHERE l.account_id = ? AND l.created_at > ?
+				ORDER BY l.created_at DESC, l.id DESC
+				LIMIT 1
+			) AS last_running_balance,
+			MAX(created_at) AS last_entry_at
+		FROM ledger_entries
+		WHERE account_id = ? AND created_at > ?`,
+		accountID, after, accountID, after,
+	).Scan(&summary)
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// RunningBalanceBreak is the first entry whose running balance does not
+// follow from the previous one.
+type RunningBalanceBreak struct {
+	EntryID        uuid.UUID
+	Position       int64
+	EntryType      string
+	Amount         float64
+	RunningBalance float64
+	Previous       float64
+	Expected       float64
+	CreatedAt      time.Time
+}
+
+// FindFirstRunningBalanceBreak replays the entries booked after the given
+// time in booking order from the opening balance with a window function and
+// returns the first entry whose recorded running balance differs, or nil.
+func (r *LedgerRepository) FindFirstRunningBalanceBreak(accountID uuid.UUID, after time.Time, opening float64) (*RunningBalanceBreak, error) {
+	var breaks []RunningBalanceBreak
+	err := r.db.Raw(`
+		SELECT entry_id, position, entry_type, amount, running_balance,
+			expected - signed AS previous, expected, created_at
+		FROM (
+			SELECT le.id AS entry_id, le.entry_type, le.amount, le.running_balance, le.created_at,
+				ROW_NUMBER() OVER w AS position,
+				CASE le.entry_type WHEN 'credit' THEN le.amount WHEN 'debit' THEN -le.amount ELSE 0 END AS signed,
+				ROUND(?::numeric + SUM(CASE le.entry_type WHEN 'credit' THEN le.amount WHEN 'debit' THEN -le.amount ELSE 0 END) OVER w, 4) AS expected
+			FROM ledger_entries le
+			WHERE le.account_id = ? AND le.created_at > ?
+			WINDOW w AS (ORDER BY le.created_at, le.id ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)
+		) replay
+		WHERE ROUND(running_balance, 4) <> expected
+		ORDER BY position
+		LIMIT 1`,
+		opening, accountID, after,
+	).Scan(&breaks)
+	if err != nil {
+		return nil, err
+	}
+	if len(breaks) == 0 {
+		return nil, nil
+	}
+	return &breaks[0], nil
+}
+
+// DuplicateLeg is a transaction that posted the same side to an account
+// more than once.
+type DuplicateLeg struct {
+	TransactionID uuid.UUID
+	EntryType     string
+	Count         int64
+}
+
+// FindDuplicateLegs groups the account's entries by transaction and side, for
+// the transactions with an entry booked after the given time. Earlier legs of
+// those transactions are counted too, so a leg replayed after a checkpoint is
+// caught.
+func (r *LedgerRepository) FindDuplicateLegs(accountID uuid.UUID, after time.Time) ([]DuplicateLeg, error) {
+	var legs []DuplicateLeg
+	err := r.db.Raw(`
+		SELECT transaction_id, entry_type, COUNT(*) AS count
+		FROM ledger_entries
+		WHERE account_id = ? AND transaction_id IN (
+			SELECT transaction_id FROM ledger_entries WHERE account_id = ? AND created_at > ?
+		)
+		GROUP BY transaction_id, entry_type
+		HAVING COUNT(*) > 1
+		ORDER BY MIN(created_at), transaction_id, entry_type`,
+		accountID, accountID, after,
+	).Scan(&legs)
+	if err != nil {
+		return nil, err
+	}
+	return legs, nil
+}
+
+// DebitBurst is the busiest window of debits on an account.
+type DebitBurst struct {
+	Count   int64
+	StartAt time.Time
+}
+
+// FindDebitBurst returns the first window of the given length, among debits
+// booked after the given time, holding more than limit debits, or nil.
+func (r *LedgerRepository) FindDebitBurst(accountID uuid.UUID, after time.Time, window time.Duration, limit int) (*DebitBurst, error) {
+	var bursts []DebitBurst
+	err := r.db.Raw(`
+		SELECT count, start_at
+		FROM (
+			SELECT COUNT(*) OVER w AS count,
+				MIN(created_at) OVER w AS start_at,
+				created_at
+			FROM ledger_entries
+			WHERE account_id = ? AND entry_type = 'debit' AND created_at > ?
+			WINDOW w AS (ORDER BY created_at RANGE BETWEEN ?::interval PRECEDING AND CURRENT ROW)
+		) windows
+		WHERE count > ?
+		ORDER BY created_at
+		LIMIT 1`,
+		accountID, after, fmt.Sprintf("%d microseconds", window.Microseconds()), limit,
+	).Scan(&bursts)
+	if err != nil {
+		return nil, err
+	}
+	if len(bursts) == 0 {
+		return nil, nil
+	}
+	return &bursts[0], nil
+}
+
+// AccountBalanceAudit compares one account's stored balance with its ledger.
+type AccountBalanceAudit struct {
+	AccountID          uuid.UUID  `json:"account_id"`
+	AccountNumber      string     `json:"account_number"`
+	ActualBalance      float64    `json:"actual_balance"`
+	ExpectedBalance    float64    `json:"expected_balance"`
+	Discrepancy        float64    `json:"discrepancy"`
+	EntryCount         int64      `json:"entry_count"`
+	LastRunningBalance *float64   `json:"last_running_balance,omitempty"`
+	CheckpointID       *uuid.UUID `json:"checkpoint_id,omitempty"`
+}
+
+// AuditBalances checks every account in one grouped query: the expected
+// balance is the latest verified checkpoint plus the entries booked after
+// it. With mismatchedOnly, only accounts whose stored balance or last running
+// balance differs from the expected balance are returned.
+func (r *LedgerRepository) AuditBalances(mismatchedOnly bool) ([]AccountBalanceAudit, error) {
+	var audits []AccountBalanceAudit
+	err := r.db.Raw(`
+		SELECT account_id, account_number, actual_balance, expected_balance,
+			actual_balance - expected_balance AS discrepancy,
+			entry_count, last_running_balance, checkpoint_id
+		FROM (
+			SELECT a.id AS account_id, a.account_number, a.balance AS actual_balance,
+				COALESCE(cp.balance, 0) + COALESCE(SUM(CASE le.entry_type WHEN 'credit' THEN le.amount WHEN 'debit' THEN -le.amount ELSE 0 END), 0) AS expected_balance,
+				COALESCE(cp.entry_count, 0) + COUNT(le.id) AS entry_count,
+				last.running_balance AS last_running_balance,
+				cp.id AS checkpoint_id
+			FROM accounts a
+			LEFT JOIN LATERAL (
+				SELECT c.id, c.balance, c.entry_count, c.covered_until FROM balance_checkpoints c
+				WHERE c.account_id = a.id AND c.verified
+				ORDER BY c.covered_until DESC
+				LIMIT 1
+			) cp ON true
+			LEFT JOIN ledger_entries le ON le.account_id = a.id
+				AND le.created_at > COALESCE(cp.covered_until, '-infinity'::timestamptz)
+			LEFT JOIN LATERAL (
+				SELECT l.running_balance FROM ledger_entries l
+				WHERE l.account_id = a.id
+				ORDER BY l.created_at DESC, l.id DESC
+				LIMIT 1
+			) last ON true
+			GROUP BY a.id, a.account_number, a.balance, cp.id, cp.balance, cp.entry_count, last.running_balance
+		) audited
+		WHERE NOT ? OR actual_balance <> expected_balance
+			OR (last_running_balance IS NOT NULL AND last_running_balance <> expected_balance)
+		ORDER BY account_number`,
+		mismatchedOnly,
+	).Scan(&audits)
+	if err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
+
+// ReplayedEntry is a ledger entry of an account together with the balance
+// replayed from the account's first entry up to and including it, and the
+// header of its transaction. The header fields are nil for an entry whose
+// transaction does not exist.
+type ReplayedEntry struct {
+	Position             int64     `json:"position"`
+	EntryID              uuid.UUID `json:"entry_id"`
+	TransactionID        uuid.UUID `json:"transaction_id"`
+	EntryType            string    `json:"entry_type"`
+	Amount               float64   `json:"amount"`
+	RunningBalance       float64   `json:"running_balance"`
+	ReplayedBalance      float64   `json:"replayed_balance"`
+	CreatedAt            time.Time `json:"created_at"`
+	TransactionReference *string   `json:"transaction_reference"`
+	TransactionType      *string   `json:"transaction_type"`
+	TransactionStatus    *string   `json:"transaction_status"`
+	Description          *string   `json:"description"`
+}
+
+// ReplayEntries replays all of the account's entries in booking order and
+// returns those at positions from through to, counted from 1. The replay
+// always starts at the first entry, so the cost grows with the history
+// rather than with the page.
+func (r *LedgerRepository) ReplayEntries(accountID uuid.UUID, from, to int64) ([]ReplayedEntry, error) {
+	var entries []ReplayedEntry
+	err := r.db.Raw(`
+		SELECT replay.*, t.transaction_reference, t.transaction_type, t.status AS transaction_status, t.description
+		FROM (
+			SELECT le.id AS entry_id, le.transaction_id, le.entry_type, le.amount, le.running_balance, le.created_at,
+				ROW_NUMBER() OVER w AS position,
+				ROUND(SUM(CASE le.entry_type WHEN 'credit' THEN le.amount WHEN 'debit' THEN -le.amount ELSE 0 END) OVER w, 4) AS replayed_balance
+			FROM ledger_entries le
+			WHERE le.account_id = ?
+			WINDOW w AS (ORDER BY le.created_at, le.id ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)
+		) replay
+		LEFT JOIN transactions t ON t.id = replay.transaction_id
+		WHERE replay.position BETWEEN ? AND ?
+		ORDER BY replay.position`,
+		accountID, from, to,
+	).Scan(&entries)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Counterparty is a leg of a transaction posted to another account.
+type Counterparty struct {
+	TransactionID uuid.UUID `json:"-"`
+	AccountID     uuid.UUID `json:"account_id"`
+	AccountNumber *string   `json:"account_number"`
+	EntryType     string    `json:"entry_type"`
+	Amount        float64   `json:"amount"`
+}
+
+// FindCounterparties returns the legs of the transactions that were posted
+// to accounts other than the given one.
+func (r *LedgerRepository) FindCounterparties(transactionIDs []uuid.UUID, accountID uuid.UUID) ([]Counterparty, error) {
+	if len(transactionIDs) == 0 {
+		return nil, nil
+	}
+
+	var counterparties []Counterparty
+	err := r.db.Raw(`
+		SELECT le.transaction_id, le.account_id, a.account_number, le.entry_type, le.amount
+		FROM ledger_entries le
+		LEFT JOIN accounts a ON a.id = le.account_id
+		WHERE le.transaction_id IN ? AND le.account_id <> ?
+		ORDER BY le.transaction_id, le.created_at, le.id`,
+		transactionIDs, accountID,
+	).Scan(&counterparties)
+	if err != nil {
+		return nil, err
+	}
+	return counterparties, nil
+}
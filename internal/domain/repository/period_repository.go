@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PeriodRepository struct {
+	db database.DB
+}
+
+func NewPeriodRepository(db database.DBManager) *PeriodRepository {
+	return &PeriodRepository{db: db}
+}
+
+func (r *PeriodRepository) WithTx(tx database.DB) *PeriodRepository {
+	return &PeriodRepository{db: tx}
+}
+
+func (r *PeriodRepository) WithContext(ctx context.Context) *PeriodRepository {
+	return &PeriodRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *PeriodRepository) Create(period *model.AccountingPeriod) error {
+	return r.db.Create(period)
+}
+
+func (r *PeriodRepository) Update(period *model.AccountingPeriod) error {
+	return r.db.Save(period)
+}
+
+func (r *PeriodRepository) FindByID(id uuid.UUID) (*model.AccountingPeriod, error) {
+	var period model.AccountingPeriod
+	if err := r.db.Where("id = ?", id).First(&period); err != nil {
+		return nil, err
+	}
+	return &period, nil
+}
+
+func (r *PeriodRepository) FindAll() ([]model.AccountingPeriod, error) {
+	var periods []model.AccountingPeriod
+	if err := r.db.Order("starts_at DESC, period_type").Find(&periods); err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+// Exists reports whether a period of the given type starting at startsAt has been closed.
+func (r *PeriodRepository) Exists(periodType string, startsAt time.Time) (bool, error) {
+	var periods []model.AccountingPeriod
+	if err := r.db.Where("period_type = ? AND starts_at = ?", periodType, startsAt).Limit(1).Find(&periods); err != nil {
+		return false, err
+	}
+	return len(periods) > 0, nil
+}
+
+// FindLatestClosed returns the closed period with the latest end, or nil if
+// no period has been closed. Everything booked before its end is locked.
+func (r *PeriodRepository) FindLatestClosed() (*model.AccountingPeriod, error) {
+	var period model.AccountingPeriod
+	if err := r.db.Where("status = ?", "closed").Order("ends_at DESC").First(&period); err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &period, nil
+}
+
+// periodLockKey identifies the advisory lock between postings and period
+// closes. It spells "periods" in ASCII.
+const periodLockKey int64 = 0x706572696f6473
+
+// LockForPosting takes the period lock in shared mode until the transaction
+// ends. Postings share it; closing a period waits for them.
+func (r *PeriodRepository) LockForPosting() error {
+	_, err := r.db.Exec("SELECT pg_advisory_xact_lock_shared(?)", periodLockKey)
+	return err
+}
+
+// LockForClose takes the period lock exclusively until the transaction ends,
+// waiting for in-flight postings to commit and holding off new ones.
+func (r *PeriodRepository) LockForClose() error {
+	_, err := r.db.Exec("SELECT pg_advisory_xact_lock(?)", periodLockKey)
+	return err
+}
+
+// CreateSnapshots stores the ledger balance and entry count of every account
+// as of the end of the period, in a single statement.
+func (r *PeriodRepository) CreateSnapshots(period *model.AccountingPeriod) (int64, error) {
+	return r.db.Exec(`
+		INSERT INTO period_balance_snapshots (id, period_id, account_id, currency_code, balance, entry_count, created_at)
+		SELECT gen_random_uuid(), ?, a.id, a.currency_code,
+			COALESCE(SUM(CASE WHEN le.entry_type = 'credit' THEN le.amount WHEN le.entry_type = 'debit' THEN -le.amount ELSE 0 END), 0),
+			COUNT(le.id),
+			?
+		FROM accounts a
+		LEFT JOIN ledger_entries le ON le.account_id = a.id AND le.created_at < ?
+		GROUP BY a.id, a.currency_code`,
+		period.ID, time.Now(), period.EndsAt,
+	)
+}
+
+func (r *PeriodRepository) FindSnapshots(periodID uuid.UUID) ([]model.PeriodBalanceSnapshot, error) {
+	var snapshots []model.PeriodBalanceSnapshot
+	if err := r.db.Where("period_id = ?", periodID).Order("currency_code, account_id").Find(&snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// FindLatestSnapshot returns the account's snapshot from the most recently
+// ending closed period, or nil if there is none.
+func (r *PeriodRepository) FindLatestSnapshot(accountID uuid.UUID) (*model.PeriodBalanceSnapshot, error) {
+	var snapshot model.PeriodBalanceSnapshot
+	err := r.db.Raw(`
+		SELECT s.* FROM period_balance_snapshots s
+		JOIN accounting_periods p ON p.id = s.period_id
+		WHERE s.account_id = ?
+		ORDER BY p.ends_at DESC
+		LIMIT 1`,
+		accountID,
+	).Scan(&snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.ID == uuid.Nil {
+		return nil, nil
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountTotals are the debit and credit totals of one account. Entries whose
+// account no longer exists are reported with an empty account number.
+type AccountTotals struct {
+	AccountID     uuid.UUID
+	AccountNumber string
+	AccountType   string
+	CurrencyCode  string
+	EntryCount    int64
+	TotalDebits   float64
+	TotalCredits  float64
+}
+
+type CurrencyTotals struct {
+	CurrencyCode string
+	AccountCount int64
+	EntryCount   int64
+	TotalDebits  float64
+	TotalCredits float64
+}
+
+type GeneralLedgerFilter struct {
+	From         time.Time
+	To           time.Time
+	AccountID    *uuid.UUID
+	CurrencyCode string
+}
+
+type GeneralLedgerAccountTotals struct {
+	AccountID      uuid.UUID
+	AccountNumber  string
+	AccountType    string
+	CurrencyCode   string
+	OpeningBalance float64
+	EntryCount     int64
+	TotalDebits    float64
+	TotalCredits   float64
+}
+
+type GeneralLedgerLine struct {
+	AccountID       uuid.UUID  `json:"-"`
+	EntryID         uuid.UUID  `json:"entry_id"`
+	TransactionID   uuid.UUID  `json:"transaction_id"`
+	Reference       string     `json:"reference"`
+	TransactionType string     `json:"transaction_type"`
+	Description     string     `json:"description"`
+	EntryType       string     `json:"entry_type"`
+	Amount          float64    `json:"amount"`
+	RunningBalance  float64    `json:"running_balance"`
+	BookedAt        time.Time  `json:"booked_at"`
+	ValueDate       *time.Time `json:"value_date,omitempty"`
+}
+
+type ReportRepository struct {
+	db database.DB
+}
+
+func NewReportRepository(db database.DBManager) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// AccountTotalsAsOf aggregates every entry booked at or before asOf per account.
+func (r *ReportRepository) AccountTotalsAsOf(asOf time.Time) ([]AccountTotals, error) {
+	var totals []AccountTotals
+	err := r.db.Raw(`
+		SELECT le.account_id,
+			COALESCE(a.account_number, '') AS account_number,
+			COALESCE(a.account_type, '') AS account_type,
+			COALESCE(a.currency_code, '') AS currency_code,
+			COUNT(*) AS entry_count,
+			COALESCE(SUM(le.amount) FILTER (WHERE le.entry_type = 'debit'), 0) AS total_debits,
+			COALESCE(SUM(le.amount) FILTER (WHERE le.entry_type = 'credit'), 0) AS total_credits
+		FROM ledger_entries le
+		LEFT JOIN accounts a ON a.id = le.account_id
+		WHERE le.created_at <= ?
+		GROUP BY le.account_id, a.account_number, a.account_type, a.currency_code
+		ORDER BY currency_code, account_number`,
+		asOf,
+	).Scan(&totals)
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// CurrencyTotalsAsOf aggregates every entry booked at or before asOf per
+// currency of the account it is posted to.
+func (r *ReportRepository) CurrencyTotalsAsOf(asOf time.Time) ([]CurrencyTotals, error) {
+	var totals []CurrencyTotals
+	err := r.db.Raw(`
+		SELECT COALESCE(a.currency_code, '') AS currency_code,
+			COUNT(DISTINCT le.account_id) AS account_count,
+			COUNT(*) AS entry_count,
+			COALESCE(SUM(le.amount) FILTER (WHERE le.entry_type = 'debit'), 0) AS total_debits,
+			COALESCE(SUM(le.amount) FILTER (WHERE le.entry_type = 'credit'), 0) AS total_credits
+		FROM ledger_entries le
+		LEFT JOIN accounts a ON a.id = le.account_id
+		WHERE le.created_at <= ?
+		GROUP BY 1
+		ORDER BY 1`,
+		asOf,
+	).Scan(&totals)
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// AccountTotalsBetween aggregates the entries booked in [from, to) per account
+// matching the filter, together with their net balance before from.
+func (r *ReportRepository) AccountTotalsBetween(filter GeneralLedgerFilter) ([]GeneralLedgerAccountTotals, error) {
+	var totals []GeneralLedgerAccountTotals
+	err := r.db.Raw(`
+		SELECT a.id AS account_id, a.account_number, a.account_type, a.currency_code,
+			COALESCE(SUM(CASE WHEN le.entry_type = 'credit' THEN le.amount ELSE -le.amount END) FILTER (WHERE le.created_at < @from), 0) AS opening_balance,
+			COUNT(le.id) FILTER (WHERE le.created_at >= @from) AS entry_count,
+			COALESCE(SUM(le.amount) FILTER (WHERE le.created_at >= @from AND le.entry_type = 'debit'), 0) AS total_debits,
+			COALESCE(SUM(le.amount) FILTER (WHERE le.created_at >= @from AND le.entry_type = 'credit'), 0) AS total_credits
+		FROM accounts a
+		JOIN ledger_entries le ON le.account_id = a.id AND le.created_at < @to
+		WHERE (@account_id::uuid IS NULL OR a.id = @account_id::uuid)
+			AND (@currency = '' OR a.currency_code = @currency)
+		GROUP BY a.id, a.account_number, a.account_type, a.currency_code
+		ORDER BY a.currency_code, a.account_number`,
+		filter.namedArgs(),
+	).Scan(&totals)
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// StreamEntriesBetween calls fn for each entry booked in [from, to) on
+// accounts matching the filter, in the order of AccountTotalsBetween and then
+// by booking time. Rows are read one at a time.
+func (r *ReportRepository) StreamEntriesBetween(filter GeneralLedgerFilter, fn func(line *GeneralLedgerLine) error) error {
+	rows, err := r.db.Raw(`
+		SELECT le.account_id, le.id AS entry_id, le.transaction_id,
+			COALESCE(t.transaction_reference, '') AS reference,
+			COALESCE(t.transaction_type, '') AS transaction_type,
+			COALESCE(t.description, '') AS description,
+			le.entry_type, le.amount, le.running_balance, le.created_at AS booked_at, le.value_date
+		FROM ledger_entries le
+		JOIN accounts a ON a.id = le.account_id
+		LEFT JOIN transactions t ON t.id = le.transaction_id
+		WHERE le.created_at >= @from AND le.created_at < @to
+			AND (@account_id::uuid IS NULL OR a.id = @account_id::uuid)
+			AND (@currency = '' OR a.currency_code = @currency)
+		ORDER BY a.currency_code, a.account_number, le.created_at, le.id`,
+		filter.namedArgs(),
+	).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line GeneralLedgerLine
+		if err := r.db.ScanRows(rows, &line); err != nil {
+			return err
+		}
+		if err := fn(&line); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (f GeneralLedgerFilter) namedArgs() map[string]any {
+	var accountID any
+	if f.AccountID != nil {
+		accountID = *f.AccountID
+	}
+
+	return map[string]any{
+		"from":       f.From,
+		"to":         f.To,
+		"account_id": accountID,
+		"currency":   f.CurrencyCode,
+	}
+}
@@ -1,10 +1,55 @@
 package repository
 
 import (
+	"context"
+	"fmt"
 	"paygo/internal/domain/model"
 	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
 )
 
+// TransactionLegSummary aggregates the ledger legs of one transaction for
+// integrity checks. LegCurrencies counts the distinct currencies of the
+// accounts the legs are booked on; legs on missing accounts are not counted
+// there but in MissingAccountLegs.
+type TransactionLegSummary struct {
+	TransactionID        uuid.UUID
+	TransactionReference string
+	TransactionType      string
+	Status               string
+	Amount               float64
+	CurrencyCode         string
+	CreatedAt            time.Time
+	LegCount             int
+	CreditCount          int
+	DebitCount           int
+	CreditTotal          float64
+	DebitTotal           float64
+	LegCurrencies        int
+	LegCurrency          string
+	ForeignCurrencyLegs  int
+	MissingAccountLegs   int
+}
+
+const transactionLegSummarySelect = `
+	SELECT t.id AS transaction_id, t.transaction_reference, t.transaction_type, t.status,
+		t.amount, t.currency_code, t.created_at,
+		COUNT(le.id) AS leg_count,
+		COUNT(le.id) FILTER (WHERE le.entry_type = 'credit') AS credit_count,
+		COUNT(le.id) FILTER (WHERE le.entry_type = 'debit') AS debit_count,
+		COALESCE(SUM(le.amount) FILTER (WHERE le.entry_type = 'credit'), 0) AS credit_total,
+		COALESCE(SUM(le.amount) FILTER (WHERE le.entry_type = 'debit'), 0) AS debit_total,
+		COUNT(DISTINCT a.currency_code) AS leg_currencies,
+		COALESCE(MIN(a.currency_code), '') AS leg_currency,
+		COUNT(le.id) FILTER (WHERE a.currency_code <> t.currency_code) AS foreign_currency_legs,
+		COUNT(le.id) FILTER (WHERE a.id IS NULL) AS missing_account_legs
+	FROM transactions t
+	LEFT JOIN ledger_entries le ON le.transaction_id = t.id
+	LEFT JOIN accounts a ON a.id = le.account_id`
+
 type TransactionRepository struct {
 	db database.DB
 }
@@ -17,6 +62,10 @@ func (r *TransactionRepository) WithTx(tx database.DB) *TransactionRepository {
 	return &TransactionRepository{db: tx}
 }
 
+func (r *TransactionRepository) WithContext(ctx context.Context) *TransactionRepository {
+	return &TransactionRepository{db: r.db.WithContext(ctx)}
+}
+
 func (r *TransactionRepository) Create(transaction *model.Transaction) error {
 	return r.db.Create(transaction)
 }
@@ -24,3 +73,143 @@ func (r *TransactionRepository) Create(transaction *model.Transaction) error {
 func (r *TransactionRepository) CreateLedgerEntry(entry *model.LedgerEntry) error {
 	return r.db.Create(entry)
 }
+
+func (r *TransactionRepository) FindByID(id uuid.UUID) (*model.Transaction, error) {
+	var transaction model.Transaction
+	if err := r.db.Where("id = ?", id).Preload("LedgerEntries").First(&transaction); err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// FindByIDForUpdate locks the transaction header without loading its legs.
+func (r *TransactionRepository) FindByIDForUpdate(id uuid.UUID) (*model.Transaction, error) {
+	var transaction model.Transaction
+	if err := r.db.Where("id = ?", id).Clauses(clause.Locking{Strength: "UPDATE"}).First(&transaction); err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// FindByIDs returns the transaction headers, oldest first, without their legs.
+func (r *TransactionRepository) FindByIDs(ids []uuid.UUID) ([]model.Transaction, error) {
+	var transactions []model.Transaction
+	if err := r.db.Where("id IN ?", ids).Order("created_at, id").Find(&transactions); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// FindRecentDuplicate returns the latest completed or held transfer since the
+// given time with the same accounts, amount and description, or nil.
+func (r *TransactionRepository) FindRecentDuplicate(fromAccountID, toAccountID uuid.UUID, amount float64, description string, since time.Time) (*model.Transaction, error) {
+	var transactions []model.Transaction
+	err := r.db.
+		Where("source_account_id = ? AND destination_account_id = ? AND amount = ? AND description = ?", fromAccountID, toAccountID, amount, description).
+		Where("transaction_type = ?", "transfer").
+		Where("status IN ? AND created_at >= ?", []string{"completed", "pending_review"}, since).
+		Order("created_at DESC").Limit(1).Find(&transactions)
+	if err != nil {
+		return nil, err
+	}
+	if len(transactions) == 0 {
+		return nil, nil
+	}
+	return &transactions[0], nil
+}
+
+// DuplicateTransfer is a completed transaction that repeats an earlier one
+// from the same account within a window.
+type DuplicateTransfer struct {
+	TransactionID        uuid.UUID
+	TransactionReference string
+	PreviousID           uuid.UUID
+	PreviousReference    string
+	DestinationAccountID uuid.UUID
+	Amount               float64
+	Gap                  float64 // seconds between the two
+}
+
+// FindDuplicateTransfers returns the completed transfers from the account
+// created after the given time that repeat the destination, amount and
+// description of a completed transfer at most window earlier. Transfers
+// confirmed as intended duplicates are skipped. Wallet movements are not
+// checked, since they cannot be confirmed.
+func (r *TransactionRepository) FindDuplicateTransfers(sourceAccountID uuid.UUID, after time.Time, window time.Duration) ([]DuplicateTransfer, error) {
+	var duplicates []DuplicateTransfer
+	err := r.db.Raw(`
+		SELECT t.id AS transaction_id, t.transaction_reference, p.id AS previous_id, p.transaction_reference AS previous_reference,
+			t.destination_account_id, t.amount, EXTRACT(EPOCH FROM t.created_at - p.created_at) AS gap
+		FROM transactions t
+		JOIN LATERAL (
+			SELECT p.id, p.transaction_reference, p.created_at
+			FROM transactions p
+			WHERE p.source_account_id = t.source_account_id
+				AND p.destination_account_id = t.destination_account_id
+				AND p.amount = t.amount AND p.description = t.description
+				AND p.transaction_type = 'transfer' AND p.status = 'completed'
+				AND p.created_at >= t.created_at - ?::interval
+				AND (p.created_at, p.id) < (t.created_at, t.id)
+			ORDER BY p.created_at DESC, p.id DESC
+			LIMIT 1
+		) p ON true
+		WHERE t.source_account_id = ? AND t.transaction_type = 'transfer' AND t.status = 'completed'
+			AND t.created_at > ? AND t.duplicate_of_id IS NULL
+		ORDER BY t.created_at, t.id`,
+		fmt.Sprintf("%d microseconds", window.Microseconds()), sourceAccountID, after,
+	).Scan(&duplicates)
+	if err != nil {
+		return nil, err
+	}
+	return duplicates, nil
+}
+
+func (r *TransactionRepository) Update(transaction *model.Transaction) error {
+	return r.db.Save(transaction)
+}
+
+func (r *TransactionRepository) FindByStatus(status string, limit int) ([]model.Transaction, error) {
+	var transactions []model.Transaction
+	if err := r.db.Where("status = ?", status).Order("created_at").Limit(limit).Find(&transactions); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// FindCorrections returns the corrections posted against the original transaction.
+func (r *TransactionRepository) FindCorrections(originalID uuid.UUID) ([]model.Transaction, error) {
+	var corrections []model.Transaction
+	if err := r.db.Where("original_transaction_id = ?", originalID).Find(&corrections); err != nil {
+		return nil, err
+	}
+	return corrections, nil
+}
+
+func (r *TransactionRepository) LegSummariesByIDs(ids []uuid.UUID) ([]TransactionLegSummary, error) {
+	var summaries []TransactionLegSummary
+	err := r.db.Raw(transactionLegSummarySelect+`
+		WHERE t.id IN ?
+		GROUP BY t.id
+		ORDER BY t.created_at, t.id`,
+		ids,
+	).Scan(&summaries)
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// LegSummariesBetween summarises the transactions created in [from, to).
+func (r *TransactionRepository) LegSummariesBetween(from, to time.Time) ([]TransactionLegSummary, error) {
+	var summaries []TransactionLegSummary
+	err := r.db.Raw(transactionLegSummarySelect+`
+		WHERE t.created_at >= ? AND t.created_at < ?
+		GROUP BY t.id
+		ORDER BY t.created_at, t.id`,
+		from, to,
+	).Scan(&summaries)
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+
+	"github.com/google/uuid"
+)
+
+type UserRepository struct {
+	db database.DB
+}
+
+func NewUserRepository(db database.DBManager) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) WithTx(tx database.DB) *UserRepository {
+	return &UserRepository{db: tx}
+}
+
+func (r *UserRepository) FindByID(id uuid.UUID) (*model.User, error) {
+	var user model.User
+	if err := r.db.Where("id = ?", id).First(&user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) FindByIDs(ids []uuid.UUID) ([]model.User, error) {
+	var users []model.User
+	if err := r.db.Where("id IN ?", ids).Order("id").Find(&users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
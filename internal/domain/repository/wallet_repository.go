@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+
+	"github.com/google/uuid"
+)
+
+type WalletRepository struct {
+	db database.DB
+}
+
+func NewWalletRepository(db database.DBManager) *WalletRepository {
+	return &WalletRepository{db: db}
+}
+
+func (r *WalletRepository) WithTx(tx database.DB) *WalletRepository {
+	return &WalletRepository{db: tx}
+}
+
+func (r *WalletRepository) Create(wallet *model.Wallet) error {
+	return r.db.Create(wallet)
+}
+
+// FindByID loads the wallet with its backing account and fills in its balance.
+func (r *WalletRepository) FindByID(id uuid.UUID) (*model.Wallet, error) {
+	var wallet model.Wallet
+	if err := r.db.Where("id = ?", id).Preload("Account").First(&wallet); err != nil {
+		return nil, err
+	}
+	wallet.Balance = wallet.Account.Balance
+	return &wallet, nil
+}
+
+func (r *WalletRepository) FindByUserID(userID uuid.UUID) ([]model.Wallet, error) {
+	var wallets []model.Wallet
+	if err := r.db.Where("user_id = ?", userID).Preload("Account").Order("currency").Find(&wallets); err != nil {
+		return nil, err
+	}
+	for i := range wallets {
+		wallets[i].Balance = wallets[i].Account.Balance
+	}
+	return wallets, nil
+}
+
+func (r *WalletRepository) Exists(userID uuid.UUID, currency string) (bool, error) {
+	var wallets []model.Wallet
+	if err := r.db.Where("user_id = ? AND currency = ?", userID, currency).Limit(1).Find(&wallets); err != nil {
+		return false, err
+	}
+	return len(wallets) > 0, nil
+}
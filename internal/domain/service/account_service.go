@@ -0,0 +1,170 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/database"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound             = errors.New("user not found")
+	ErrInvalidStatusTransition  = errors.New("invalid account status transition")
+	ErrAccountBalanceNotZero    = errors.New("account balance is not zero")
+	ErrAccountStatusNotManaged  = errors.New("account status is managed elsewhere")
+	errAccountNumberUnavailable = errors.New("could not generate an unused account number")
+)
+
+// accountNumberAttempts bounds the retries when a generated account number is
+// already taken.
+const accountNumberAttempts = 5
+
+// accountStatusTransitions lists the statuses each status may move to through
+// the account API. Closed is final. Dormant accounts return to active only by
+// reactivation, which requires verifying the owner.
+var accountStatusTransitions = map[string][]string{
+	"active":  {"frozen", "blocked", "closed"},
+	"frozen":  {"active", "blocked", "closed"},
+	"blocked": {"active", "frozen", "closed"},
+	"dormant": {"frozen", "blocked", "closed"},
+}
+
+type AccountService struct {
+	db          database.DBManager
+	accountRepo *repository.AccountRepository
+	userRepo    *repository.UserRepository
+}
+
+func NewAccountService(
+	db database.DBManager,
+	accountRepo *repository.AccountRepository,
+	userRepo *repository.UserRepository,
+) *AccountService {
+	return &AccountService{
+		db:          db,
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// OpenAccount opens an empty active account for the user under a newly
+// generated account number.
+func (s *AccountService) OpenAccount(userID uuid.UUID, accountType, currency, actor string) (*model.Account, error) {
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	accountNumber, err := s.generateAccountNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	account := &model.Account{
+		UserID:        userID,
+		AccountNumber: accountNumber,
+		AccountType:   accountType,
+		CurrencyCode:  strings.ToUpper(currency),
+		Status:        "active",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	err = s.db.WithTransaction(func(tx database.DB) error {
+		txAccountRepo := s.accountRepo.WithTx(tx)
+		if err := txAccountRepo.Create(account); err != nil {
+			return fmt.Errorf("failed to create account: %w", err)
+		}
+		return txAccountRepo.CreateStatusChange(&model.AccountStatusChange{
+			AccountID: account.ID,
+			ToStatus:  "active",
+			Reason:    "Account opened",
+			Actor:     actor,
+			CreatedAt: now,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// generateAccountNumber picks a random number in the ACC-NNNNNNN range used
+// for customer accounts. The unique index still rejects a number taken
+// concurrently.
+func (s *AccountService) generateAccountNumber() (string, error) {
+	for range accountNumberAttempts {
+		accountNumber := fmt.Sprintf("ACC-%07d", 1000000+rand.IntN(9000000))
+		exists, err := s.accountRepo.AccountNumberExists(accountNumber)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return accountNumber, nil
+		}
+	}
+	return "", errAccountNumberUnavailable
+}
+
+func (s *AccountService) GetAccount(accountID uuid.UUID) (*model.Account, error) {
+	account, err := s.accountRepo.FindByIDWithoutEntries(accountID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+func (s *AccountService) ListAccounts(filter repository.AccountFilter) ([]model.Account, error) {
+	return s.accountRepo.Find(filter)
+}
+
+// ChangeStatus moves the account to a new status if the transition is
+// allowed. Accounts can only be closed once their balance is zero. Wallet
+// and system accounts are managed by their own services.
+func (s *AccountService) ChangeStatus(accountID uuid.UUID, status, reason, actor string) (*model.Account, error) {
+	var account *model.Account
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txAccountRepo := s.accountRepo.WithTx(tx)
+		var err error
+		if account, err = txAccountRepo.FindByIDForUpdate(accountID); err != nil {
+			if repository.IsNotFound(err) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+
+		if account.AccountType == "system" || account.AccountType == "wallet" {
+			return fmt.Errorf("%w: %s account", ErrAccountStatusNotManaged, account.AccountType)
+		}
+		if !slices.Contains(accountStatusTransitions[account.Status], status) {
+			return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, account.Status, status)
+		}
+		if status == "closed" && (roundAmount(account.Balance) != 0 || roundAmount(account.AvailableBalance) != 0) {
+			return fmt.Errorf("%w: balance is %s", ErrAccountBalanceNotZero, formatAmount(account.Balance))
+		}
+
+		return changeAccountStatus(txAccountRepo, account, status, reason, actor, time.Now())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"time"
+)
+
+// changeAccountStatus sets the account's status and appends the change to
+// its status history. The account must be locked by the caller.
+func changeAccountStatus(repo *repository.AccountRepository, account *model.Account, status, reason, actor string, at time.Time) error {
+	change := &model.AccountStatusChange{
+		AccountID:  account.ID,
+		FromStatus: account.Status,
+		ToStatus:   status,
+		Reason:     reason,
+		Actor:      actor,
+		CreatedAt:  at,
+	}
+
+	account.Status = status
+	account.UpdatedAt = at
+	if _, err := repo.Update(account); err != nil {
+		return err
+	}
+	return repo.CreateStatusChange(change)
+}
+
+// canReceive reports whether the account accepts incoming transfers. Dormant
+// accounts still receive funds; only outgoing transfers are blocked until the
+// owner is verified again.
+func canReceive(account *model.Account) bool {
+	return account.Status == "active" || account.Status == "dormant"
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/alert"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertService sends the findings of fraudulent audits to the alert sinks.
+// Each finding is delivered once until an audit stops reporting it, so an
+// unchanged discrepancy does not alert on every run. Delivery that fails is
+// retried the next time the finding is reported.
+type AlertService struct {
+	alertRepo *repository.AuditAlertRepository
+	sinks     []alert.Sink
+}
+
+func NewAlertService(alertRepo *repository.AuditAlertRepository, sinks []alert.Sink) *AlertService {
+	return &AlertService{
+		alertRepo: alertRepo,
+		sinks:     sinks,
+	}
+}
+
+// AuditCompleted implements AuditObserver. Incomplete audits neither alert
+// nor resolve alerts, since they may have missed findings.
+func (s *AlertService) AuditCompleted(ctx context.Context, result AuditResult) error {
+	if result.Status == AuditStatusIncomplete {
+		return nil
+	}
+
+	now := time.Now()
+	var fingerprints []string
+	var errs []error
+
+	for _, finding := range result.Findings {
+		if severityRanks[finding.Severity] <= severityRanks[SeverityInfo] {
+			continue
+		}
+
+		stored := &model.AuditAlert{
+			Fingerprint:   findingFingerprint(result.AccountID, finding),
+			AccountID:     result.AccountID,
+			AccountNumber: result.AccountNumber,
+			Detector:      finding.Detector,
+			FraudType:     string(finding.FraudType),
+			Severity:      string(finding.Severity),
+			Message:       finding.Message,
+			FirstSeenAt:   now,
+			LastSeenAt:    now,
+		}
+		fingerprints = append(fingerprints, stored.Fingerprint)
+
+		pending, err := s.alertRepo.Record(stored)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to record alert: %w", err))
+			continue
+		}
+		if pending {
+			if err := s.deliver(ctx, stored, result.AuditedAt); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if _, err := s.alertRepo.ResolveMissing(result.AccountID, fingerprints, now); err != nil {
+		errs = append(errs, fmt.Errorf("failed to resolve alerts: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// deliver sends the alert to every sink, and marks it notified only if all
+// of them accepted it.
+func (s *AlertService) deliver(ctx context.Context, stored *model.AuditAlert, detectedAt time.Time) error {
+	payload := alert.Alert{
+		Fingerprint:   stored.Fingerprint,
+		AccountID:     stored.AccountID,
+		AccountNumber: stored.AccountNumber,
+		Detector:      stored.Detector,
+		FraudType:     stored.FraudType,
+		Severity:      stored.Severity,
+		Message:       stored.Message,
+		DetectedAt:    detectedAt,
+	}
+
+	var errs []error
+	for _, sink := range s.sinks {
+		if err := sink.Send(ctx, payload); err != nil {
+			errs = append(errs, fmt.Errorf("alert sink %s: %w", sink.Name(), err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		if markErr := s.alertRepo.MarkFailed(stored.ID, err.Error()); markErr != nil {
+			return errors.Join(err, markErr)
+		}
+		return err
+	}
+	return s.alertRepo.MarkNotified(stored.ID, time.Now())
+}
+
+func (s *AlertService) ListAlerts(filter repository.AuditAlertFilter) ([]model.AuditAlert, error) {
+	return s.alertRepo.Find(filter)
+}
+
+// findingFingerprint identifies a finding across audits. Finding messages
+// carry the amounts involved, so a discrepancy that changes is a new finding.
+func findingFingerprint(accountID uuid.UUID, finding Finding) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s|%s|%s|%s", accountID, finding.Detector, finding.FraudType, finding.Message))
+	return hex.EncodeToString(sum[:])
+}
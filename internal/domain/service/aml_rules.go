@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AMLRuleStructuring = "structuring"
+	AMLRulePassThrough = "pass_through"
+	AMLRuleRoundAmount = "round_amounts"
+	AMLRuleLargeCash   = "large_cash_equivalent"
+)
+
+// AMLSettings are the thresholds of the default AML rules.
+type AMLSettings struct {
+	// ReportingThreshold is the amount above which transfers must be
+	// reported. Structuring looks for transfers within StructuringMargin
+	// (a fraction) below it.
+	ReportingThreshold  float64
+	StructuringMargin   float64
+	StructuringCount    int
+	StructuringWindow   time.Duration
+	PassThroughMinimum  float64
+	PassThroughRatio    float64
+	PassThroughWindow   time.Duration
+	RoundAmountUnit     float64
+	RoundAmountCount    int
+	RoundAmountWindow   time.Duration
+	LargeCashAmount     float64
+	LargeCashWindow     time.Duration
+	CashEquivalentTypes []string
+}
+
+func DefaultAMLSettings() AMLSettings {
+	return AMLSettings{
+		ReportingThreshold:  10000,
+		StructuringMargin:   0.1,
+		StructuringCount:    3,
+		StructuringWindow:   24 * time.Hour,
+		PassThroughMinimum:  1000,
+		PassThroughRatio:    0.9,
+		PassThroughWindow:   24 * time.Hour,
+		RoundAmountUnit:     1000,
+		RoundAmountCount:    3,
+		RoundAmountWindow:   7 * 24 * time.Hour,
+		LargeCashAmount:     10000,
+		LargeCashWindow:     24 * time.Hour,
+		CashEquivalentTypes: []string{"wallet_topup", "wallet_withdrawal"},
+	}
+}
+
+// AMLHit is an account a rule matched, with the transactions that matched.
+type AMLHit struct {
+	AccountID      uuid.UUID
+	UserID         uuid.UUID
+	Amount         float64
+	Summary        string
+	TransactionIDs model.StringArray
+}
+
+// AMLRule is one AML monitoring rule. Evaluate looks at the rule's window
+// of transactions ending at until.
+type AMLRule interface {
+	Name() string
+	Evaluate(ctx context.Context, until time.Time) ([]AMLHit, error)
+}
+
+func NewDefaultAMLRules(amlRepo *repository.AMLRepository, settings AMLSettings) []AMLRule {
+	return []AMLRule{
+		&StructuringRule{amlRepo: amlRepo, settings: settings},
+		&PassThroughRule{amlRepo: amlRepo, settings: settings},
+		&RoundAmountRule{amlRepo: amlRepo, settings: settings},
+		&LargeCashRule{amlRepo: amlRepo, settings: settings},
+	}
+}
+
+// StructuringRule flags accounts that split payments into several transfers
+// just under the reporting threshold.
+type StructuringRule struct {
+	amlRepo  *repository.AMLRepository
+	settings AMLSettings
+}
+
+func (*StructuringRule) Name() string { return AMLRuleStructuring }
+
+func (r *StructuringRule) Evaluate(ctx context.Context, until time.Time) ([]AMLHit, error) {
+	upper := r.settings.ReportingThreshold
+	lower := roundAmount(upper * (1 - r.settings.StructuringMargin))
+
+	rows, err := r.amlRepo.WithContext(ctx).FindStructuring(until.Add(-r.settings.StructuringWindow), until, lower, upper, r.settings.StructuringCount)
+	if err != nil {
+		return nil, err
+	}
+	return toAMLHits(rows, func(row repository.AMLRuleHit) string {
+		return fmt.Sprintf("%d transfers totalling %.2f between %.2f and %.2f within %v",
+			row.Count, row.Total, lower, upper, r.settings.StructuringWindow)
+	}), nil
+}
+
+// PassThroughRule flags accounts that send money on shortly after receiving
+// it, leaving little behind.
+type PassThroughRule struct {
+	amlRepo  *repository.AMLRepository
+	settings AMLSettings
+}
+
+func (*PassThroughRule) Name() string { return AMLRulePassThrough }
+
+func (r *PassThroughRule) Evaluate(ctx context.Context, until time.Time) ([]AMLHit, error) {
+	rows, err := r.amlRepo.WithContext(ctx).FindPassThrough(until.Add(-r.settings.PassThroughWindow), until, r.settings.PassThroughMinimum, r.settings.PassThroughRatio)
+	if err != nil {
+		return nil, err
+	}
+	return toAMLHits(rows, func(row repository.AMLRuleHit) string {
+		return fmt.Sprintf("Received %.2f and sent on %.2f (%.0f%%) within %v",
+			row.Total, row.Outgoing, 100*row.Outgoing/row.Total, r.settings.PassThroughWindow)
+	}), nil
+}
+
+// RoundAmountRule flags accounts that send many transfers of round amounts.
+type RoundAmountRule struct {
+	amlRepo  *repository.AMLRepository
+	settings AMLSettings
+}
+
+func (*RoundAmountRule) Name() string { return AMLRuleRoundAmount }
+
+func (r *RoundAmountRule) Evaluate(ctx context.Context, until time.Time) ([]AMLHit, error) {
+	rows, err := r.amlRepo.WithContext(ctx).FindRoundAmounts(until.Add(-r.settings.RoundAmountWindow), until, r.settings.RoundAmountUnit, r.settings.RoundAmountCount)
+	if err != nil {
+		return nil, err
+	}
+	return toAMLHits(rows, func(row repository.AMLRuleHit) string {
+		return fmt.Sprintf("%d transfers in multiples of %.2f totalling %.2f within %v",
+			row.Count, r.settings.RoundAmountUnit, row.Total, r.settings.RoundAmountWindow)
+	}), nil
+}
+
+// LargeCashRule flags large movements into and out of stored value, such as
+// wallet top-ups and withdrawals.
+type LargeCashRule struct {
+	amlRepo  *repository.AMLRepository
+	settings AMLSettings
+}
+
+func (*LargeCashRule) Name() string { return AMLRuleLargeCash }
+
+func (r *LargeCashRule) Evaluate(ctx context.Context, until time.Time) ([]AMLHit, error) {
+	if len(r.settings.CashEquivalentTypes) == 0 {
+		return nil, nil
+	}
+	rows, err := r.amlRepo.WithContext(ctx).FindLargeTransactions(until.Add(-r.settings.LargeCashWindow), until, r.settings.CashEquivalentTypes, r.settings.LargeCashAmount)
+	if err != nil {
+		return nil, err
+	}
+	return toAMLHits(rows, func(row repository.AMLRuleHit) string {
+		return fmt.Sprintf("%d cash-equivalent movements of at least %.2f totalling %.2f",
+			row.Count, r.settings.LargeCashAmount, row.Total)
+	}), nil
+}
+
+func toAMLHits(rows []repository.AMLRuleHit, summarize func(repository.AMLRuleHit) string) []AMLHit {
+	hits := make([]AMLHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, AMLHit{
+			AccountID:      row.AccountID,
+			UserID:         row.UserID,
+			Amount:         roundAmount(row.Total),
+			Summary:        summarize(row),
+			TransactionIDs: row.TransactionIDs,
+		})
+	}
+	return hits
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/database"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAMLAlertNotFound = errors.New("AML alert not found")
+	ErrAMLAlertNotOpen  = errors.New("AML alert is not open")
+	ErrSARNotFound      = errors.New("suspicious activity report not found")
+	ErrSARFiled         = errors.New("suspicious activity report is already filed")
+)
+
+// AMLScanResult lists the alerts a scan created or added transactions to.
+type AMLScanResult struct {
+	ScannedAt time.Time        `json:"scanned_at"`
+	Created   int              `json:"created"`
+	Updated   int              `json:"updated"`
+	Alerts    []model.AMLAlert `json:"alerts"`
+}
+
+// SARExport is everything a suspicious activity report covers: the report
+// with its alerts, and the transactions, accounts and users involved.
+type SARExport struct {
+	Report       *model.SuspiciousActivityReport `json:"report"`
+	Users        []model.User                    `json:"users"`
+	Accounts     []model.Account                 `json:"accounts"`
+	Transactions []model.Transaction             `json:"transactions"`
+	GeneratedAt  time.Time                       `json:"generated_at"`
+}
+
+// AMLService runs the AML monitoring rules over transfers, keeps the
+// resulting alerts, and escalates them into suspicious activity reports.
+type AMLService struct {
+	db              database.DBManager
+	accountRepo     *repository.AccountRepository
+	transactionRepo *repository.TransactionRepository
+	userRepo        *repository.UserRepository
+	amlRepo         *repository.AMLRepository
+	rules           []AMLRule
+}
+
+func NewAMLService(
+	db database.DBManager,
+	accountRepo *repository.AccountRepository,
+	transactionRepo *repository.TransactionRepository,
+	userRepo *repository.UserRepository,
+	amlRepo *repository.AMLRepository,
+	rules []AMLRule,
+) *AMLService {
+	return &AMLService{
+		db:              db,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		userRepo:        userRepo,
+		amlRepo:         amlRepo,
+		rules:           rules,
+	}
+}
+
+// Monitor scans for rule hits. It is meant to be run periodically by the
+// scheduler.
+func (s *AMLService) Monitor(ctx context.Context) error {
+	result, err := s.Scan(ctx)
+	if err != nil {
+		return err
+	}
+	if result.Created > 0 || result.Updated > 0 {
+		log.Printf("AML monitoring: %d alert(s) created, %d updated", result.Created, result.Updated)
+	}
+	return nil
+}
+
+// Scan evaluates every rule over its window ending now and records the hits.
+// Windows overlap between scans; hits whose transactions are all covered by
+// an earlier alert are ignored.
+func (s *AMLService) Scan(ctx context.Context) (*AMLScanResult, error) {
+	result := &AMLScanResult{ScannedAt: time.Now(), Alerts: []model.AMLAlert{}}
+
+	for _, rule := range s.rules {
+		hits, err := rule.Evaluate(ctx, result.ScannedAt)
+		if err != nil {
+			return nil, fmt.Errorf("AML rule %s failed: %w", rule.Name(), err)
+		}
+
+		for _, hit := range hits {
+			alert, created, err := s.record(rule.Name(), hit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to record %s alert for account %s: %w", rule.Name(), hit.AccountID, err)
+			}
+			if alert == nil {
+				continue
+			}
+			if created {
+				result.Created++
+			} else {
+				result.Updated++
+			}
+			result.Alerts = append(result.Alerts, *alert)
+		}
+	}
+
+	return result, nil
+}
+
+// record adds the hit's new transactions to the open alert of the rule on
+// the account, or opens one. It returns nil if the hit has no new
+// transactions.
+func (s *AMLService) record(rule string, hit AMLHit) (alert *model.AMLAlert, created bool, err error) {
+	err = s.db.WithTransaction(func(tx database.DB) error {
+		txAMLRepo := s.amlRepo.WithTx(tx)
+
+		// The account lock serialises concurrent scans, so at most one
+		// open alert per rule and account exists.
+		if _, err := lockAccountsInOrder(s.accountRepo.WithTx(tx), []uuid.UUID{hit.AccountID}); err != nil {
+			return err
+		}
+
+		alerts, err := txAMLRepo.FindAlertsByRuleAndAccount(rule, hit.AccountID)
+		if err != nil {
+			return err
+		}
+
+		var open *model.AMLAlert
+		var covered []string
+		for i := range alerts {
+			covered = append(covered, alerts[i].TransactionIDs...)
+			if alerts[i].Status == "open" {
+				open = &alerts[i]
+			}
+		}
+
+		var fresh model.StringArray
+		for _, id := range hit.TransactionIDs {
+			if !slices.Contains(covered, id) {
+				fresh = append(fresh, id)
+			}
+		}
+		if len(fresh) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		if open != nil {
+			open.TransactionIDs = append(open.TransactionIDs, fresh...)
+			open.Amount = hit.Amount
+			open.Summary = hit.Summary
+			open.UpdatedAt = now
+			alert = open
+			return txAMLRepo.UpdateAlert(open)
+		}
+
+		alert = &model.AMLAlert{
+			Rule:           rule,
+			AccountID:      hit.AccountID,
+			UserID:         hit.UserID,
+			Status:         "open",
+			Amount:         hit.Amount,
+			Summary:        hit.Summary,
+			TransactionIDs: hit.TransactionIDs,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		created = true
+		return txAMLRepo.CreateAlert(alert)
+	})
+
+	if err != nil {
+		return nil, false, err
+	}
+	return alert, created, nil
+}
+
+func (s *AMLService) ListAlerts(filter repository.AMLAlertFilter) ([]model.AMLAlert, error) {
+	return s.amlRepo.FindAlerts(filter)
+}
+
+func (s *AMLService) GetAlert(alertID uuid.UUID) (*model.AMLAlert, error) {
+	alert, err := s.amlRepo.FindAlertByID(alertID, false)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrAMLAlertNotFound
+		}
+		return nil, err
+	}
+	return alert, nil
+}
+
+// DismissAlert closes an open alert as not suspicious.
+func (s *AMLService) DismissAlert(alertID uuid.UUID, note, actor string) (*model.AMLAlert, error) {
+	var alert *model.AMLAlert
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txAMLRepo := s.amlRepo.WithTx(tx)
+
+		var err error
+		alert, err = txAMLRepo.FindAlertByID(alertID, true)
+		if err != nil {
+			if repository.IsNotFound(err) {
+				return ErrAMLAlertNotFound
+			}
+			return err
+		}
+		if alert.Status != "open" {
+			return fmt.Errorf("%w: status is %q", ErrAMLAlertNotOpen, alert.Status)
+		}
+
+		now := time.Now()
+		alert.Status = "dismissed"
+		alert.ReviewedBy = actor
+		alert.ReviewNote = note
+		alert.ReviewedAt = &now
+		alert.UpdatedAt = now
+		return txAMLRepo.UpdateAlert(alert)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+// Escalate opens a draft suspicious activity report for the open alerts.
+func (s *AMLService) Escalate(alertIDs []uuid.UUID, narrative, actor string) (*model.SuspiciousActivityReport, error) {
+	now := time.Now()
+	id := uuid.New()
+	report := &model.SuspiciousActivityReport{
+		ID:        id,
+		Reference: fmt.Sprintf("SAR-%s-%s", now.UTC().Format("20060102"), strings.ToUpper(compactID(id)[:8])),
+		Status:    "draft",
+		Narrative: narrative,
+		CreatedBy: actor,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txAMLRepo := s.amlRepo.WithTx(tx)
+
+		alerts, err := txAMLRepo.FindAlertsByIDsForUpdate(alertIDs)
+		if err != nil {
+			return err
+		}
+		for _, alertID := range alertIDs {
+			if !slices.ContainsFunc(alerts, func(alert model.AMLAlert) bool { return alert.ID == alertID }) {
+				return fmt.Errorf("%w: %s", ErrAMLAlertNotFound, alertID)
+			}
+		}
+
+		if err := txAMLRepo.CreateReport(report); err != nil {
+			return err
+		}
+
+		for i := range alerts {
+			alert := &alerts[i]
+			if alert.Status != "open" {
+				return fmt.Errorf("%w: alert %s is %s", ErrAMLAlertNotOpen, alert.ID, alert.Status)
+			}
+			alert.Status = "escalated"
+			alert.ReportID = &report.ID
+			alert.ReviewedBy = actor
+			alert.ReviewedAt = &now
+			alert.UpdatedAt = now
+			if err := txAMLRepo.UpdateAlert(alert); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return s.GetReport(report.ID)
+}
+
+func (s *AMLService) GetReport(reportID uuid.UUID) (*model.SuspiciousActivityReport, error) {
+	report, err := s.amlRepo.FindReportByID(reportID, false)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrSARNotFound
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *AMLService) ListReports(status string, limit, offset int) ([]model.SuspiciousActivityReport, error) {
+	return s.amlRepo.FindReports(status, limit, offset)
+}
+
+// FileReport marks a draft report as filed with the regulator.
+func (s *AMLService) FileReport(reportID uuid.UUID, actor string) (*model.SuspiciousActivityReport, error) {
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txAMLRepo := s.amlRepo.WithTx(tx)
+
+		report, err := txAMLRepo.FindReportByID(reportID, true)
+		if err != nil {
+			if repository.IsNotFound(err) {
+				return ErrSARNotFound
+			}
+			return err
+		}
+		if report.Status == "filed" {
+			return ErrSARFiled
+		}
+
+		now := time.Now()
+		report.Status = "filed"
+		report.FiledBy = actor
+		report.FiledAt = &now
+		report.UpdatedAt = now
+		// The alerts were loaded for the response and must not be re-saved.
+		report.Alerts = nil
+		return txAMLRepo.UpdateReport(report)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return s.GetReport(reportID)
+}
+
+// ExportReport collects the report with the transactions of its alerts and
+// the accounts and users on either side of them.
+func (s *AMLService) ExportReport(reportID uuid.UUID) (*SARExport, error) {
+	report, err := s.GetReport(reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &SARExport{
+		Report:       report,
+		Users:        []model.User{},
+		Accounts:     []model.Account{},
+		Transactions: []model.Transaction{},
+		GeneratedAt:  time.Now(),
+	}
+
+	var transactionIDs []uuid.UUID
+	accountIDs := make(map[uuid.UUID]bool)
+	for _, alert := range report.Alerts {
+		accountIDs[alert.AccountID] = true
+		for _, value := range alert.TransactionIDs {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("alert %s has an invalid transaction ID %q", alert.ID, value)
+			}
+			if !slices.Contains(transactionIDs, id) {
+				transactionIDs = append(transactionIDs, id)
+			}
+		}
+	}
+
+	if len(transactionIDs) > 0 {
+		if export.Transactions, err = s.transactionRepo.FindByIDs(transactionIDs); err != nil {
+			return nil, err
+		}
+	}
+	for _, transaction := range export.Transactions {
+		for _, id := range []*uuid.UUID{transaction.SourceAccountID, transaction.DestinationAccountID} {
+			if id != nil {
+				accountIDs[*id] = true
+			}
+		}
+	}
+
+	if len(accountIDs) > 0 {
+		ids := make([]uuid.UUID, 0, len(accountIDs))
+		for id := range accountIDs {
+			ids = append(ids, id)
+		}
+		if export.Accounts, err = s.accountRepo.FindByIDs(ids); err != nil {
+			return nil, err
+		}
+		slices.SortFunc(export.Accounts, func(a, b model.Account) int {
+			return strings.Compare(a.AccountNumber, b.AccountNumber)
+		})
+	}
+
+	var userIDs []uuid.UUID
+	for _, account := range export.Accounts {
+		if !slices.Contains(userIDs, account.UserID) {
+			userIDs = append(userIDs, account.UserID)
+		}
+	}
+	if len(userIDs) > 0 {
+		if export.Users, err = s.userRepo.FindByIDs(userIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return export, nil
+}
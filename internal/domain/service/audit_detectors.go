@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"paygo/internal/domain/repository"
+	"time"
+)
+
+// DetectorSettings tunes the built-in detectors. Transfers repeated within
+// DuplicateWindow are reported as duplicates; more than VelocityMaxDebits
+// debits within VelocityWindow are reported as unusual velocity.
+type DetectorSettings struct {
+	DuplicateWindow   time.Duration
+	VelocityMaxDebits int
+	VelocityWindow    time.Duration
+}
+
+// NewDefaultDetectorRegistry registers the built-in detectors.
+func NewDefaultDetectorRegistry(
+	ledgerRepo *repository.LedgerRepository,
+	periodRepo *repository.PeriodRepository,
+	transactionRepo *repository.TransactionRepository,
+	settings DetectorSettings,
+) *DetectorRegistry {
+	registry := NewDetectorRegistry()
+	for _, detector := range []Detector{
+		BalanceMismatchDetector{},
+		&RunningBalanceDetector{ledgerRepo: ledgerRepo},
+		&ClosedPeriodDetector{ledgerRepo: ledgerRepo, periodRepo: periodRepo},
+		&DuplicateLegDetector{ledgerRepo: ledgerRepo},
+		&VelocityDetector{ledgerRepo: ledgerRepo, MaxDebits: settings.VelocityMaxDebits, Window: settings.VelocityWindow},
+		&DuplicateTransferDetector{transactionRepo: transactionRepo, Window: settings.DuplicateWindow},
+	} {
+		if err := registry.Register(detector); err != nil {
+			panic(err)
+		}
+	}
+	return registry
+}
+
+// BalanceMismatchDetector compares the stored balance with the sum of the
+// ledger entries.
+type BalanceMismatchDetector struct{}
+
+func (BalanceMismatchDetector) Name() string              { return "balance_mismatch" }
+func (BalanceMismatchDetector) FraudType() FraudType      { return FraudTypeBalanceMismatch }
+func (BalanceMismatchDetector) DefaultSeverity() Severity { return SeverityCritical }
+
+func (BalanceMismatchDetector) Detect(_ context.Context, audit *AccountAudit) ([]string, error) {
+	if audit.Account.Balance == audit.ExpectedBalance {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf(
+		"Balance mismatch detected: actual=%.4f, expected=%.4f, discrepancy=%.4f",
+		audit.Account.Balance, audit.ExpectedBalance, audit.Account.Balance-audit.ExpectedBalance,
+	)}, nil
+}
+
+// RunningBalanceDetector replays the entries in booking order from the
+// opening balance and verifies that each entry's running balance is the
+// previous one plus or minus its amount. Only the first break is reported:
+// every later running balance is off by the same amount.
+type RunningBalanceDetector struct {
+	ledgerRepo *repository.LedgerRepository
+}
+
+func (*RunningBalanceDetector) Name() string              { return "running_balance" }
+func (*RunningBalanceDetector) FraudType() FraudType      { return FraudTypeRunningBalanceBreak }
+func (*RunningBalanceDetector) DefaultSeverity() Severity { return SeverityHigh }
+
+func (d *RunningBalanceDetector) Detect(ctx context.Context, audit *AccountAudit) ([]string, error) {
+	entry, err := d.ledgerRepo.WithContext(ctx).FindFirstRunningBalanceBreak(audit.Account.ID, audit.Since, roundAmount(audit.OpeningBalance))
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay running balances: %w", err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	return []string{fmt.Sprintf(
+		"Running balance break at entry %s (#%d since %s, booked %s): previous=%.4f, %s %.4f, expected=%.4f, recorded=%.4f",
+		entry.EntryID, entry.Position, openingPoint(audit), entry.CreatedAt.Format(time.RFC3339Nano),
+		entry.Previous, entry.EntryType, entry.Amount, entry.Expected, entry.RunningBalance,
+	)}, nil
+}
+
+func openingPoint(audit *AccountAudit) string {
+	if audit.Checkpoint == nil {
+		return "account opening"
+	}
+	return "checkpoint " + audit.Checkpoint.ID.String()
+}
+
+// ClosedPeriodDetector recomputes the ledger balance at the end of the latest
+// closed period and compares it with the snapshot taken when the period was
+// closed. Any difference means entries inside a closed period were inserted,
+// changed or deleted afterwards.
+type ClosedPeriodDetector struct {
+	ledgerRepo *repository.LedgerRepository
+	periodRepo *repository.PeriodRepository
+}
+
+func (*ClosedPeriodDetector) Name() string              { return "closed_period" }
+func (*ClosedPeriodDetector) FraudType() FraudType      { return FraudTypeClosedPeriodAltered }
+func (*ClosedPeriodDetector) DefaultSeverity() Severity { return SeverityCritical }
+
+func (d *ClosedPeriodDetector) Detect(ctx context.Context, audit *AccountAudit) ([]string, error) {
+	periodRepo := d.periodRepo.WithContext(ctx)
+
+	snapshot, err := periodRepo.FindLatestSnapshot(audit.Account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch closed period snapshot: %w", err)
+	}
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	period, err := periodRepo.FindByID(snapshot.PeriodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch closed period: %w", err)
+	}
+
+	ledgerBalance, err := d.ledgerRepo.WithContext(ctx).SumBetween(audit.Account.ID, time.Time{}, period.EndsAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute closed period balance: %w", err)
+	}
+
+	if roundAmount(ledgerBalance) == roundAmount(snapshot.Balance) {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf(
+		"Closed period %s (%s) altered: balance at close=%.4f, recomputed=%.4f",
+		period.StartsAt.Format(time.DateOnly), period.PeriodType, snapshot.Balance, ledgerBalance,
+	)}, nil
+}
+
+// DuplicateLegDetector flags a transaction that posted the same side to the
+// account more than once, the signature of a replayed ledger insert.
+type DuplicateLegDetector struct {
+	ledgerRepo *repository.LedgerRepository
+}
+
+func (*DuplicateLegDetector) Name() string              { return "duplicate_legs" }
+func (*DuplicateLegDetector) FraudType() FraudType      { return FraudTypeDuplicateLeg }
+func (*DuplicateLegDetector) DefaultSeverity() Severity { return SeverityHigh }
+
+func (d *DuplicateLegDetector) Detect(ctx context.Context, audit *AccountAudit) ([]string, error) {
+	legs, err := d.ledgerRepo.WithContext(ctx).FindDuplicateLegs(audit.Account.ID, audit.Since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for duplicate legs: %w", err)
+	}
+
+	var messages []string
+	for _, leg := range legs {
+		messages = append(messages, fmt.Sprintf(
+			"Transaction %s posted %d %s legs to the account", leg.TransactionID, leg.Count, leg.EntryType,
+		))
+	}
+	return messages, nil
+}
+
+// VelocityDetector flags bursts of outgoing payments: more than MaxDebits
+// debits within any Window.
+type VelocityDetector struct {
+	ledgerRepo *repository.LedgerRepository
+	MaxDebits  int
+	Window     time.Duration
+}
+
+func (*VelocityDetector) Name() string              { return "velocity" }
+func (*VelocityDetector) FraudType() FraudType      { return FraudTypeVelocity }
+func (*VelocityDetector) DefaultSeverity() Severity { return SeverityLow }
+
+func (d *VelocityDetector) Detect(ctx context.Context, audit *AccountAudit) ([]string, error) {
+	if d.MaxDebits <= 0 || d.Window <= 0 {
+		return nil, nil
+	}
+
+	burst, err := d.ledgerRepo.WithContext(ctx).FindDebitBurst(audit.Account.ID, audit.Since, d.Window, d.MaxDebits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check debit velocity: %w", err)
+	}
+	if burst == nil {
+		return nil, nil
+	}
+
+	return []string{fmt.Sprintf(
+		"%d debits within %v starting %s, limit is %d",
+		burst.Count, d.Window, burst.StartAt.Format(time.RFC3339), d.MaxDebits,
+	)}, nil
+}
+
+// DuplicateTransferDetector flags completed transfers that repeat an earlier
+// one from the account, to the same destination with the same amount and
+// description, within Window. Repeats the client confirmed are not flagged,
+// nor are wallet movements, which have no way to confirm them.
+type DuplicateTransferDetector struct {
+	transactionRepo *repository.TransactionRepository
+	Window          time.Duration
+}
+
+func (*DuplicateTransferDetector) Name() string              { return "duplicate_transfers" }
+func (*DuplicateTransferDetector) FraudType() FraudType      { return FraudTypeDuplicateTransfer }
+func (*DuplicateTransferDetector) DefaultSeverity() Severity { return SeverityMedium }
+
+func (d *DuplicateTransferDetector) Detect(ctx context.Context, audit *AccountAudit) ([]string, error) {
+	if d.Window <= 0 {
+		return nil, nil
+	}
+
+	duplicates, err := d.transactionRepo.WithContext(ctx).FindDuplicateTransfers(audit.Account.ID, audit.Since, d.Window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for duplicate transfers: %w", err)
+	}
+
+	var messages []string
+	for _, duplicate := range duplicates {
+		messages = append(messages, fmt.Sprintf(
+			"Transaction %s repeats %s (%.4f to account %s) %.1fs later",
+			duplicate.TransactionReference, duplicate.PreviousReference, duplicate.Amount, duplicate.DestinationAccountID, duplicate.Gap,
+		))
+	}
+	return messages, nil
+}
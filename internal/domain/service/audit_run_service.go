@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditRunRunning   = "running"
+	AuditRunCompleted = "completed"
+	AuditRunFailed    = "failed"
+
+	AuditRunFull        = "full"
+	AuditRunIncremental = "incremental"
+
+	auditRunPageSize = 500
+	// A running run whose progress has not moved for this long was abandoned,
+	// e.g. by a server restart, and no longer blocks new runs.
+	auditRunStaleAfter = 15 * time.Minute
+	// A page can take longer than auditRunStaleAfter to audit, so a live run
+	// refreshes its UpdatedAt this often while one is in progress.
+	auditRunHeartbeat = auditRunStaleAfter / 3
+	// Entries are stamped before they commit, so an incremental run looks
+	// back this far before the start of the previous run.
+	auditRunOverlap = 5 * time.Minute
+)
+
+var (
+	ErrAuditRunNotFound   = errors.New("audit run not found")
+	ErrAuditRunInProgress = errors.New("an audit run is already in progress")
+)
+
+// Run comparison outcomes for a single account.
+const (
+	ChangeAdded     = "added"     // only audited in the target run
+	ChangeRemoved   = "removed"   // only audited in the base run
+	ChangeRegressed = "regressed" // valid in the base run, not in the target run
+	ChangeResolved  = "resolved"  // not valid in the base run, valid in the target run
+	ChangeChanged   = "changed"
+)
+
+type FindingChange struct {
+	Change string `json:"change"`
+	repository.FindingDifference
+}
+
+type AuditRunComparison struct {
+	BaseRun   *model.AuditRun `json:"base_run"`
+	TargetRun *model.AuditRun `json:"target_run"`
+	Summary   map[string]int  `json:"summary"`
+	Changes   []FindingChange `json:"changes"`
+}
+
+type AuditRunService struct {
+	db           database.DBManager
+	accountRepo  *repository.AccountRepository
+	auditRunRepo *repository.AuditRunRepository
+	auditService *AuditService
+}
+
+func NewAuditRunService(
+	db database.DBManager,
+	accountRepo *repository.AccountRepository,
+	auditRunRepo *repository.AuditRunRepository,
+	auditService *AuditService,
+) *AuditRunService {
+	return &AuditRunService{
+		db:           db,
+		accountRepo:  accountRepo,
+		auditRunRepo: auditRunRepo,
+		auditService: auditService,
+	}
+}
+
+// StartRun records a new run and audits the accounts in the background. An
+// incremental run only audits the accounts changed since the last completed
+// run, and audits every account if no run has completed yet. Only one run
+// may be in progress at a time.
+func (s *AuditRunService) StartRun(startedBy string, incremental bool) (*model.AuditRun, error) {
+	run, err := s.begin(startedBy, incremental)
+	if err != nil {
+		return nil, err
+	}
+
+	// The run outlives the request that started it.
+	go func(run model.AuditRun) {
+		s.finishRun(&run, s.execute(context.Background(), &run))
+	}(*run)
+
+	return run, nil
+}
+
+// RunIncremental runs an incremental audit and waits for it to finish. It is
+// meant to be run periodically by the scheduler, and skips its turn while
+// another run is in progress.
+func (s *AuditRunService) RunIncremental(ctx context.Context) error {
+	run, err := s.begin(systemActor, true)
+	if errors.Is(err, ErrAuditRunInProgress) {
+		log.Printf("Incremental audit skipped: %v", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.execute(ctx, run)
+	s.finishRun(run, err)
+	return err
+}
+
+// begin records a new run. The check for a run in progress and the insert
+// happen under an advisory lock, so concurrent requests cannot both start one.
+func (s *AuditRunService) begin(startedBy string, incremental bool) (*model.AuditRun, error) {
+	var run *model.AuditRun
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		scoped := s.withTx(tx)
+		if err := scoped.auditRunRepo.LockRunStart(); err != nil {
+			return err
+		}
+
+		var err error
+		run, err = scoped.createRun(startedBy, incremental)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (s *AuditRunService) createRun(startedBy string, incremental bool) (*model.AuditRun, error) {
+	running, err := s.auditRunRepo.FindRunning()
+	if err != nil {
+		return nil, err
+	}
+	for i := range running {
+		run := &running[i]
+		if time.Since(run.UpdatedAt) < auditRunStaleAfter {
+			return nil, ErrAuditRunInProgress
+		}
+		s.finishRun(run, fmt.Errorf("abandoned: no progress since %s", run.UpdatedAt.Format(time.RFC3339)))
+	}
+
+	run := &model.AuditRun{
+		Status:    AuditRunRunning,
+		Mode:      AuditRunFull,
+		StartedBy: startedBy,
+		StartedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if incremental {
+		last, err := s.auditRunRepo.FindLastCompleted()
+		if err != nil {
+			return nil, err
+		}
+		if last != nil {
+			since := last.StartedAt.Add(-auditRunOverlap)
+			run.Mode = AuditRunIncremental
+			run.ChangedSince = &since
+		}
+	}
+
+	if run.ChangedSince != nil {
+		run.TotalAccounts, err = s.accountRepo.CountChanged(*run.ChangedSince)
+	} else {
+		run.TotalAccounts, err = s.accountRepo.Count()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to count accounts: %w", err)
+	}
+
+	if err := s.auditRunRepo.CreateRun(run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (s *AuditRunService) withTx(tx database.DB) *AuditRunService {
+	scoped := *s
+	scoped.accountRepo = s.accountRepo.WithTx(tx)
+	scoped.auditRunRepo = s.auditRunRepo.WithTx(tx)
+	return &scoped
+}
+
+// execute pages through the run's accounts in ID order, audits each page and
+// persists its findings before moving on.
+func (s *AuditRunService) execute(ctx context.Context, run *model.AuditRun) error {
+	ctx = withAuditRun(ctx)
+	afterID := uuid.Nil
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var accounts []model.Account
+		var err error
+		if run.ChangedSince != nil {
+			accounts, err = s.accountRepo.FindChangedPage(*run.ChangedSince, afterID, auditRunPageSize)
+		} else {
+			accounts, err = s.accountRepo.FindPage(afterID, auditRunPageSize)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list accounts: %w", err)
+		}
+		if len(accounts) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(accounts))
+		for i, account := range accounts {
+			ids[i] = account.ID
+		}
+
+		stopHeartbeat := s.heartbeat(run.ID)
+		results := s.auditService.AuditAccounts(ctx, ids)
+		stopHeartbeat()
+
+		findings := make([]model.AuditFinding, 0, len(ids))
+		for _, result := range results {
+			findings = append(findings, newAuditFinding(run.ID, result))
+
+			switch result.Status {
+			case AuditStatusValid:
+				run.ValidCount++
+			case AuditStatusSuspicious:
+				run.SuspiciousCount++
+			case AuditStatusFraudulent:
+				run.FraudulentCount++
+			default:
+				run.IncompleteCount++
+			}
+		}
+
+		if err := s.auditRunRepo.CreateFindings(findings); err != nil {
+			return fmt.Errorf("failed to store findings: %w", err)
+		}
+
+		run.AuditedAccounts += int64(len(findings))
+		// Accounts opened during the run are audited too.
+		run.TotalAccounts = max(run.TotalAccounts, run.AuditedAccounts)
+		run.UpdatedAt = time.Now()
+		if err := s.auditRunRepo.UpdateRun(run); err != nil {
+			return fmt.Errorf("failed to record progress: %w", err)
+		}
+
+		afterID = accounts[len(accounts)-1].ID
+	}
+}
+
+// heartbeat touches the run every auditRunHeartbeat until the returned func
+// is called, so other servers do not take it for abandoned. The func waits for
+// the last touch to finish.
+func (s *AuditRunService) heartbeat(runID uuid.UUID) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(auditRunHeartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if err := s.auditRunRepo.TouchRun(runID, now); err != nil {
+					log.Printf("Failed to record heartbeat of audit run %s: %v", runID, err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func (s *AuditRunService) finishRun(run *model.AuditRun, runErr error) {
+	now := time.Now()
+	run.Status = AuditRunCompleted
+	if runErr != nil {
+		run.Status = AuditRunFailed
+		run.Error = runErr.Error()
+	}
+	run.FinishedAt = &now
+	run.UpdatedAt = now
+
+	if err := s.auditRunRepo.UpdateRun(run); err != nil {
+		log.Printf("Failed to finish audit run %s: %v", run.ID, err)
+		return
+	}
+	log.Printf("Audit run %s %s: %d/%d accounts audited, %d fraudulent, %d incomplete",
+		run.ID, run.Status, run.AuditedAccounts, run.TotalAccounts, run.FraudulentCount, run.IncompleteCount)
+}
+
+func newAuditFinding(runID uuid.UUID, result AuditResult) model.AuditFinding {
+	fraudTypes := make(model.StringArray, 0, len(result.FraudTypes))
+	for _, fraudType := range result.FraudTypes {
+		fraudTypes = append(fraudTypes, string(fraudType))
+	}
+
+	return model.AuditFinding{
+		RunID:              runID,
+		AccountID:          result.AccountID,
+		AccountNumber:      result.AccountNumber,
+		Status:             string(result.Status),
+		FraudTypes:         fraudTypes,
+		Findings:           toDetectorFindings(result.Findings),
+		Errors:             model.StringArray(result.Errors),
+		ExpectedBalance:    result.ExpectedBalance,
+		ActualBalance:      result.ActualBalance,
+		BalanceDiscrepancy: result.BalanceDiscrepancy,
+		LedgerEntriesCount: int64(result.LedgerEntriesCount),
+		CheckpointID:       result.CheckpointID,
+		AuditedAt:          result.AuditedAt,
+	}
+}
+
+func toDetectorFindings(findings []Finding) model.DetectorFindings {
+	stored := make(model.DetectorFindings, 0, len(findings))
+	for _, finding := range findings {
+		stored = append(stored, model.DetectorFinding{
+			Detector:  finding.Detector,
+			FraudType: string(finding.FraudType),
+			Severity:  string(finding.Severity),
+			Message:   finding.Message,
+		})
+	}
+	return stored
+}
+
+func (s *AuditRunService) GetRun(runID uuid.UUID) (*model.AuditRun, error) {
+	run, err := s.auditRunRepo.FindRunByID(runID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrAuditRunNotFound
+		}
+		return nil, err
+	}
+	return run, nil
+}
+
+func (s *AuditRunService) ListRuns(limit int) ([]model.AuditRun, error) {
+	return s.auditRunRepo.FindRuns(limit)
+}
+
+func (s *AuditRunService) Findings(runID uuid.UUID, filter repository.FindingFilter) ([]model.AuditFinding, int64, error) {
+	if _, err := s.GetRun(runID); err != nil {
+		return nil, 0, err
+	}
+	return s.auditRunRepo.FindFindings(runID, filter)
+}
+
+// CompareRuns lists every account whose finding differs between the base and
+// the target run, classified by how it changed.
+func (s *AuditRunService) CompareRuns(baseRunID, targetRunID uuid.UUID) (*AuditRunComparison, error) {
+	baseRun, err := s.GetRun(baseRunID)
+	if err != nil {
+		return nil, err
+	}
+	targetRun, err := s.GetRun(targetRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	differences, err := s.auditRunRepo.CompareRuns(baseRunID, targetRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &AuditRunComparison{
+		BaseRun:   baseRun,
+		TargetRun: targetRun,
+		Summary:   map[string]int{},
+		Changes:   make([]FindingChange, 0, len(differences)),
+	}
+
+	for _, difference := range differences {
+		change := classifyChange(difference)
+		comparison.Summary[change]++
+		comparison.Changes = append(comparison.Changes, FindingChange{Change: change, FindingDifference: difference})
+	}
+
+	return comparison, nil
+}
+
+func classifyChange(d repository.FindingDifference) string {
+	valid := string(AuditStatusValid)
+	switch {
+	case d.BaseStatus == nil:
+		return ChangeAdded
+	case d.TargetStatus == nil:
+		return ChangeRemoved
+	case *d.BaseStatus == valid && *d.TargetStatus != valid:
+		return ChangeRegressed
+	case *d.BaseStatus != valid && *d.TargetStatus == valid:
+		return ChangeResolved
+	}
+	return ChangeChanged
+}
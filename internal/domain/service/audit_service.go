@@ -1,9 +1,12 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"paygo/internal/domain/model"
+	"log"
 	"paygo/internal/domain/repository"
+	"slices"
 	"sync"
 	"time"
 
@@ -14,6 +17,7 @@ type AuditStatus string
 
 const (
 	AuditStatusValid      AuditStatus = "VALID"
+	AuditStatusSuspicious AuditStatus = "SUSPICIOUS"
 	AuditStatusFraudulent AuditStatus = "FRAUDULENT"
 	AuditStatusIncomplete AuditStatus = "INCOMPLETE"
 )
@@ -21,7 +25,17 @@ const (
 type FraudType string
 
 const (
-	FraudTypeBalanceMismatch FraudType = "BALANCE_MISMATCH"
+	FraudTypeBalanceMismatch     FraudType = "BALANCE_MISMATCH"
+	FraudTypeClosedPeriodAltered FraudType = "CLOSED_PERIOD_ALTERED"
+	FraudTypeRunningBalanceBreak FraudType = "RUNNING_BALANCE_BREAK"
+	FraudTypeDuplicateLeg        FraudType = "DUPLICATE_LEDGER_ENTRY"
+	FraudTypeVelocity            FraudType = "VELOCITY"
+	FraudTypeDuplicateTransfer   FraudType = "DUPLICATE_TRANSFER"
+
+	FraudTypeUnbalancedTransaction FraudType = "UNBALANCED_TRANSACTION"
+	FraudTypeMixedCurrency         FraudType = "MIXED_CURRENCY_TRANSACTION"
+	FraudTypeHeaderMismatch        FraudType = "TRANSACTION_HEADER_MISMATCH"
+	FraudTypeOrphanedEntry         FraudType = "ORPHANED_LEDGER_ENTRY"
 )
 
 type AuditResult struct {
@@ -32,95 +46,336 @@ type AuditResult struct {
 	ActualBalance      float64     `json:"actual_balance"`
 	BalanceDiscrepancy float64     `json:"balance_discrepancy"`
 	FraudTypes         []FraudType `json:"fraud_types,omitempty"`
-	Details            []string    `json:"details,omitempty"`
+	Findings           []Finding   `json:"findings"`
+	Errors             []string    `json:"errors,omitempty"`
 	LedgerEntriesCount int         `json:"ledger_entries_count"`
+	LastRunningBalance *float64    `json:"last_running_balance,omitempty"`
+	CheckpointID       *uuid.UUID  `json:"checkpoint_id,omitempty"`
 	AuditedAt          time.Time   `json:"audited_at"`
 }
 
+// IndexedAuditResult is a result of a batch audit together with the position
+// of its account in the request.
+type IndexedAuditResult struct {
+	Index int `json:"index"`
+	AuditResult
+}
+
+const DefaultAuditConcurrency = 8
+
+// AuditLimits bounds the load a batch audit puts on the database.
+type AuditLimits struct {
+	Concurrency    int
+	AccountTimeout time.Duration
+}
+
+// AuditObserver is notified of every completed account audit, whichever way
+// it was started. Observers may be called concurrently.
+type AuditObserver interface {
+	AuditCompleted(ctx context.Context, result AuditResult) error
+}
+
 type AuditService struct {
-	accountRepo *repository.AccountRepository
+	accountRepo    *repository.AccountRepository
+	checkpointRepo *repository.CheckpointRepository
+	ledgerRepo     *repository.LedgerRepository
+	walletRepo     *repository.WalletRepository
+	detectors      *DetectorRegistry
+	limits         AuditLimits
+	fraudSeverity  Severity
+	observers      []AuditObserver
 }
 
 func NewAuditService(
 	accountRepo *repository.AccountRepository,
+	checkpointRepo *repository.CheckpointRepository,
+	ledgerRepo *repository.LedgerRepository,
+	walletRepo *repository.WalletRepository,
+	detectors *DetectorRegistry,
+	limits AuditLimits,
 ) *AuditService {
 	return &AuditService{
-		accountRepo: accountRepo,
+		accountRepo:    accountRepo,
+		checkpointRepo: checkpointRepo,
+		ledgerRepo:     ledgerRepo,
+		walletRepo:     walletRepo,
+		detectors:      detectors,
+		limits:         limits,
+		fraudSeverity:  SeverityMedium,
 	}
 }
 
-// TODO: (Improvement suggestion) Consider a worker pool pattern with limited concurrency:
-func (s *AuditService) AuditAccounts(accountIDs []uuid.UUID) []AuditResult {
-	resultChan := make(chan AuditResult, len(accountIDs))
-	var wg sync.WaitGroup
+// SetFraudSeverity sets the lowest finding severity that makes an account
+// FRAUDULENT; findings below it and above info make it SUSPICIOUS. It must be
+// called before audits start.
+func (s *AuditService) SetFraudSeverity(severity Severity) {
+	s.fraudSeverity = severity
+}
+
+// AddObserver registers an observer. It must be called before audits start.
+func (s *AuditService) AddObserver(observer AuditObserver) {
+	s.observers = append(s.observers, observer)
+}
+
+// AuditAccounts audits the accounts with at most Concurrency audits in
+// flight and returns the results in input order. Accounts not audited before
+// ctx is cancelled are reported as incomplete.
+func (s *AuditService) AuditAccounts(ctx context.Context, accountIDs []uuid.UUID) []AuditResult {
+	results := make([]AuditResult, len(accountIDs))
+	done := make([]bool, len(accountIDs))
+
+	_ = s.StreamAudits(ctx, accountIDs, func(result IndexedAuditResult) error {
+		results[result.Index] = result.AuditResult
+		done[result.Index] = true
+		return nil
+	})
+
+	for i, accountID := range accountIDs {
+		if !done[i] {
+			results[i] = AuditResult{
+				AccountID:  accountID,
+				Status:     AuditStatusIncomplete,
+				FraudTypes: []FraudType{},
+				Findings:   []Finding{},
+				Errors:     []string{fmt.Sprintf("Audit not run: %v", context.Cause(ctx))},
+				AuditedAt:  time.Now(),
+			}
+		}
+	}
+
+	return results
+}
+
+// StreamAudits audits the accounts with at most Concurrency audits in flight
+// and hands every result to fn as soon as it is ready, in completion order.
+// fn is always called from the calling goroutine. It stops early, returning
+// the error, when fn fails or ctx is cancelled.
+func (s *AuditService) StreamAudits(ctx context.Context, accountIDs []uuid.UUID, fn func(result IndexedAuditResult) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	for _, id := range accountIDs {
+	workers := s.limits.Concurrency
+	if workers <= 0 {
+		workers = DefaultAuditConcurrency
+	}
+	workers = min(workers, len(accountIDs))
+
+	jobs := make(chan int)
+	results := make(chan IndexedAuditResult, workers)
+
+	var wg sync.WaitGroup
+	for range workers {
 		wg.Add(1)
-		go func(accountID uuid.UUID) {
+		go func() {
 			defer wg.Done()
-			resultChan <- s.AuditAccount(accountID)
-		}(id)
+			for i := range jobs {
+				result := IndexedAuditResult{Index: i, AuditResult: s.auditWithTimeout(ctx, accountIDs[i])}
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
 	}
 
+	go func() {
+		defer close(jobs)
+		for i := range accountIDs {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	go func() {
 		wg.Wait()
-		close(resultChan)
+		close(results)
 	}()
 
-	results := make([]AuditResult, 0, len(accountIDs))
-	for result := range resultChan {
-		results = append(results, result)
+	for result := range results {
+		if err := fn(result); err != nil {
+			cancel()
+			for range results {
+			}
+			return err
+		}
 	}
 
-	return results
+	return ctx.Err()
+}
+
+func (s *AuditService) auditWithTimeout(ctx context.Context, accountID uuid.UUID) AuditResult {
+	if s.limits.AccountTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.limits.AccountTimeout)
+		defer cancel()
+	}
+
+	result := s.AuditAccountContext(ctx, accountID)
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && result.Status == AuditStatusIncomplete {
+		result.Errors = append(result.Errors, fmt.Sprintf("Audit timed out after %v", s.limits.AccountTimeout))
+	}
+
+	return result
+}
+
+// AuditWallet audits the ledger account that backs the wallet.
+func (s *AuditService) AuditWallet(walletID uuid.UUID) (AuditResult, error) {
+	wallet, err := s.walletRepo.FindByID(walletID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return AuditResult{}, ErrWalletNotFound
+		}
+		return AuditResult{}, err
+	}
+	return s.AuditAccount(wallet.AccountID), nil
+}
+
+// auditRunKey marks the context of audits made by an audit run, scheduled or
+// started explicitly, as opposed to ad-hoc audits through the API.
+type auditRunKey struct{}
+
+func withAuditRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, auditRunKey{}, true)
+}
+
+// inAuditRun reports whether an observer is notified of an audit made by an
+// audit run.
+func inAuditRun(ctx context.Context) bool {
+	inRun, _ := ctx.Value(auditRunKey{}).(bool)
+	return inRun
 }
 
 func (s *AuditService) AuditAccount(accountID uuid.UUID) AuditResult {
+	return s.AuditAccountContext(context.Background(), accountID)
+}
+
+// AuditAccountContext audits the account with every query bound to ctx. The
+// account is fraudulent if any enabled detector reports a finding above
+// info severity, and incomplete if it could not be fully checked.
+func (s *AuditService) AuditAccountContext(ctx context.Context, accountID uuid.UUID) AuditResult {
+	result := s.withContext(ctx).auditAccount(ctx, accountID)
+
+	// Observers act on the outcome, so they run even if the audit's own
+	// deadline has passed.
+	observerCtx := context.WithoutCancel(ctx)
+	for _, observer := range s.observers {
+		if err := observer.AuditCompleted(observerCtx, result); err != nil {
+			log.Printf("Audit observer failed for account %s: %v", accountID, err)
+		}
+	}
+
+	return result
+}
+
+func (s *AuditService) auditAccount(ctx context.Context, accountID uuid.UUID) AuditResult {
 	result := AuditResult{
 		AccountID:  accountID,
 		Status:     AuditStatusValid,
 		FraudTypes: []FraudType{},
-		Details:    []string{},
+		Findings:   []Finding{},
 		AuditedAt:  time.Now(),
 	}
 
-	account, err := s.accountRepo.FindByID(accountID, false)
+	checkpoint, err := s.checkpointRepo.FindLatestVerified(accountID)
 	if err != nil {
 		result.Status = AuditStatusIncomplete
-		result.Details = append(result.Details, fmt.Sprintf("Failed to fetch account: %v", err))
+		result.Errors = append(result.Errors, fmt.Sprintf("Failed to fetch balance checkpoint: %v", err))
 		return result
 	}
 
+	// Only the entries booked after the latest verified checkpoint are
+	// aggregated; everything before it is represented by the checkpoint
+	// balance.
+	var since time.Time
+	if checkpoint != nil {
+		since = checkpoint.CoveredUntil
+	}
+
+	account, err := s.accountRepo.FindByIDWithoutEntries(accountID)
+	if err != nil {
+		result.Status = AuditStatusIncomplete
+		result.Errors = append(result.Errors, fmt.Sprintf("Failed to fetch account: %v", err))
+		return result
+	}
+
+	summary, err := s.ledgerRepo.Summarize(accountID, since)
+	if err != nil {
+		result.Status = AuditStatusIncomplete
+		result.Errors = append(result.Errors, fmt.Sprintf("Failed to summarize ledger entries: %v", err))
+		return result
+	}
+
+	audit := &AccountAudit{
+		Account:            account,
+		Checkpoint:         checkpoint,
+		Since:              since,
+		EntryCount:         summary.EntryCount,
+		LastRunningBalance: summary.LastRunningBalance,
+	}
+
 	result.AccountNumber = account.AccountNumber
 	result.ActualBalance = account.Balance
-	result.LedgerEntriesCount = len(account.LedgerEntries)
+	result.LedgerEntriesCount = int(summary.EntryCount)
+	result.LastRunningBalance = summary.LastRunningBalance
 
-	expectedBalance := s.calculateExpectedBalanceFromLedger(account)
+	expectedBalance := roundAmount(summary.Net)
+	if checkpoint != nil {
+		audit.OpeningBalance = checkpoint.Balance
+		result.CheckpointID = &checkpoint.ID
+		result.LedgerEntriesCount += int(checkpoint.EntryCount)
+		expectedBalance = roundAmount(checkpoint.Balance + summary.Net)
+	}
+	audit.ExpectedBalance = expectedBalance
 	result.ExpectedBalance = expectedBalance
 	result.BalanceDiscrepancy = account.Balance - expectedBalance
 
-	if account.Balance != expectedBalance {
-		result.Status = AuditStatusFraudulent
-		result.FraudTypes = append(result.FraudTypes, FraudTypeBalanceMismatch)
-		result.Details = append(result.Details, fmt.Sprintf(
-			"Balance mismatch detected: actual=%.4f, expected=%.4f, discrepancy=%.4f",
-			account.Balance, expectedBalance, result.BalanceDiscrepancy,
-		))
+	findings, errs := s.detectors.Run(ctx, audit)
+	for _, finding := range findings {
+		result.Findings = append(result.Findings, finding)
+		rank := severityRanks[finding.Severity]
+		if rank <= severityRanks[SeverityInfo] {
+			continue
+		}
+		if rank >= severityRanks[s.fraudSeverity] {
+			result.Status = AuditStatusFraudulent
+		} else if result.Status == AuditStatusValid {
+			result.Status = AuditStatusSuspicious
+		}
+		if !slices.Contains(result.FraudTypes, finding.FraudType) {
+			result.FraudTypes = append(result.FraudTypes, finding.FraudType)
+		}
+	}
+	for _, err := range errs {
+		result.Errors = append(result.Errors, err.Error())
+		if result.Status != AuditStatusFraudulent {
+			result.Status = AuditStatusIncomplete
+		}
 	}
 
 	return result
 }
 
-func (s *AuditService) calculateExpectedBalanceFromLedger(account *model.Account) float64 {
-	balance := 0.0
+// Detectors lists the registered detectors and their settings.
+func (s *AuditService) Detectors() []DetectorInfo {
+	return s.detectors.Detectors()
+}
 
-	for _, entry := range account.LedgerEntries {
-		if entry.EntryType == "credit" {
-			balance += entry.Amount
-		} else if entry.EntryType == "debit" {
-			balance -= entry.Amount
-		}
-	}
+// AuditBalances checks the stored balance of every account against its
+// ledger in a single grouped query, without running the detectors. It is
+// meant for quick sweeps; use an audit run for the full checks.
+func (s *AuditService) AuditBalances(ctx context.Context, mismatchedOnly bool) ([]repository.AccountBalanceAudit, error) {
+	return s.ledgerRepo.WithContext(ctx).AuditBalances(mismatchedOnly)
+}
 
-	return balance
+func (s *AuditService) withContext(ctx context.Context) *AuditService {
+	scoped := *s
+	scoped.accountRepo = s.accountRepo.WithContext(ctx)
+	scoped.checkpointRepo = s.checkpointRepo.WithContext(ctx)
+	scoped.ledgerRepo = s.ledgerRepo.WithContext(ctx)
+	return &scoped
 }
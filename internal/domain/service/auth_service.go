@@ -0,0 +1,338 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/database"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrEmailTaken          = errors.New("email is already registered")
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrUserInactive        = errors.New("user is not active")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrInvalidAccessToken  = errors.New("invalid or expired access token")
+)
+
+const tokenIssuer = "paygo"
+
+// Registered users are customers and may only act on their own accounts and
+// wallets. Staff run the back office; the role is granted in the database.
+const (
+	RoleCustomer = "customer"
+	RoleStaff    = "staff"
+)
+
+// dummyPasswordHash is compared against when the email is unknown, so a login
+// takes as long whether or not the user exists.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("paygo-dummy-password"), bcrypt.DefaultCost)
+
+// TokenPair is returned by login and refresh. The access token goes in the
+// Authorization header as a bearer token; the refresh token is exchanged for
+// a new pair once the access token expires and is then no longer valid.
+type TokenPair struct {
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresIn        int       `json:"expires_in"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// AccessClaims are the claims of an access token. The subject is the user ID
+// and sid the login session, i.e. the refresh token family. A role change
+// applies from the next refresh.
+type AccessClaims struct {
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	SessionID uuid.UUID `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// Principal is the authenticated user of a request.
+type Principal struct {
+	UserID    uuid.UUID
+	Email     string
+	Role      string
+	SessionID uuid.UUID
+}
+
+// IsStaff reports whether the user may act on other users' resources.
+func (p *Principal) IsStaff() bool {
+	return p.Role == RoleStaff
+}
+
+type AuthService struct {
+	db              database.DBManager
+	authRepo        *repository.AuthRepository
+	userRepo        *repository.UserRepository
+	secret          []byte
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
+
+func NewAuthService(
+	db database.DBManager,
+	authRepo *repository.AuthRepository,
+	userRepo *repository.UserRepository,
+	secret string,
+	accessTokenTTL time.Duration,
+	refreshTokenTTL time.Duration,
+) *AuthService {
+	return &AuthService{
+		db:              db,
+		authRepo:        authRepo,
+		userRepo:        userRepo,
+		secret:          []byte(secret),
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+// Register creates an active, unverified user with a bcrypt password hash.
+func (s *AuthService) Register(email, password, firstName, lastName string) (*model.User, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	exists, err := s.authRepo.EmailExists(email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrEmailTaken
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	now := time.Now()
+	user := &model.User{
+		Email:        email,
+		PasswordHash: string(hash),
+		FirstName:    strings.TrimSpace(firstName),
+		LastName:     strings.TrimSpace(lastName),
+		Status:       "active",
+		Role:         RoleCustomer,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := s.authRepo.CreateUser(user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+// Login checks the password and starts a new session.
+func (s *AuthService) Login(email, password string) (*TokenPair, error) {
+	user, err := s.authRepo.FindUserByEmail(strings.TrimSpace(email))
+	if err != nil {
+		if repository.IsNotFound(err) {
+			_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if user.Status != "active" {
+		return nil, ErrUserInactive
+	}
+
+	var pair *TokenPair
+	err = s.db.WithTransaction(func(tx database.DB) error {
+		var err error
+		pair, _, err = s.issue(s.authRepo.WithTx(tx), user, uuid.New(), time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pair, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair in the same session.
+// Presenting a token that was already exchanged means it leaked, so the
+// whole session is revoked.
+func (s *AuthService) Refresh(refreshToken string) (*TokenPair, error) {
+	var pair *TokenPair
+	reused := false
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txAuthRepo := s.authRepo.WithTx(tx)
+		now := time.Now()
+
+		token, err := txAuthRepo.FindRefreshTokenForUpdate(hashToken(refreshToken))
+		if err != nil {
+			if repository.IsNotFound(err) {
+				return ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		if token.RevokedAt != nil {
+			if token.ReplacedByID != nil {
+				reused = true
+				return txAuthRepo.RevokeFamily(token.FamilyID, now)
+			}
+			return ErrInvalidRefreshToken
+		}
+		if !now.Before(token.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+
+		user, err := s.findActiveUser(tx, token.UserID)
+		if err != nil {
+			return err
+		}
+
+		var next *model.RefreshToken
+		if pair, next, err = s.issue(txAuthRepo, user, token.FamilyID, now); err != nil {
+			return err
+		}
+
+		token.RevokedAt = &now
+		token.ReplacedByID = &next.ID
+		return txAuthRepo.UpdateRefreshToken(token)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	// The revocation must commit, so reuse is reported after the transaction.
+	if reused {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return pair, nil
+}
+
+// Logout revokes the session of the refresh token. Unknown or already revoked
+// tokens are ignored.
+func (s *AuthService) Logout(refreshToken string) error {
+	return s.db.WithTransaction(func(tx database.DB) error {
+		txAuthRepo := s.authRepo.WithTx(tx)
+		token, err := txAuthRepo.FindRefreshTokenForUpdate(hashToken(refreshToken))
+		if err != nil {
+			if repository.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		return txAuthRepo.RevokeFamily(token.FamilyID, time.Now())
+	})
+}
+
+// LogoutAll revokes every session of the user.
+func (s *AuthService) LogoutAll(userID uuid.UUID) (int64, error) {
+	return s.authRepo.RevokeUser(userID, time.Now())
+}
+
+// Authenticate verifies an access token and that its session is still live.
+func (s *AuthService) Authenticate(accessToken string) (*Principal, error) {
+	claims := &AccessClaims{}
+	_, err := jwt.ParseWithClaims(accessToken, claims, func(*jwt.Token) (any, error) {
+		return s.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, ErrInvalidAccessToken
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, ErrInvalidAccessToken
+	}
+
+	active, err := s.authRepo.SessionActive(claims.SessionID, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, ErrInvalidAccessToken
+	}
+
+	return &Principal{UserID: userID, Email: claims.Email, Role: claims.Role, SessionID: claims.SessionID}, nil
+}
+
+// issue signs an access token and stores a new refresh token for the session.
+func (s *AuthService) issue(repo *repository.AuthRepository, user *model.User, sessionID uuid.UUID, now time.Time) (*TokenPair, *model.RefreshToken, error) {
+	claims := AccessClaims{
+		Email:     user.Email,
+		Role:      user.Role,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Subject:   user.ID.String(),
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTokenTTL)),
+		},
+	}
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(secret)
+
+	token := &model.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  sessionID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: now.Add(s.refreshTokenTTL),
+		CreatedAt: now,
+	}
+	if err := repo.CreateRefreshToken(token); err != nil {
+		return nil, nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(s.accessTokenTTL.Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: token.ExpiresAt,
+	}, token, nil
+}
+
+func (s *AuthService) findActiveUser(tx database.DB, userID uuid.UUID) (*model.User, error) {
+	user, err := s.userRepo.WithTx(tx).FindByID(userID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if user.Status != "active" {
+		return nil, ErrUserInactive
+	}
+	return user, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAccountNotFound = errors.New("account not found")
+	ErrInvalidCursor   = errors.New("invalid ledger cursor")
+)
+
+type BalanceBasis string
+
+const (
+	// BalanceBasisBooking uses the time entries were booked (LedgerEntry.CreatedAt).
+	BalanceBasisBooking BalanceBasis = "booking"
+	// BalanceBasisValue uses the date funds became effective (LedgerEntry.ValueDate).
+	BalanceBasisValue BalanceBasis = "value"
+)
+
+func ParseBalanceBasis(value string) (BalanceBasis, error) {
+	switch BalanceBasis(value) {
+	case "", BalanceBasisBooking:
+		return BalanceBasisBooking, nil
+	case BalanceBasisValue:
+		return BalanceBasisValue, nil
+	}
+	return "", fmt.Errorf("invalid balance basis %q, expected %q or %q", value, BalanceBasisBooking, BalanceBasisValue)
+}
+
+type PointInTimeBalance struct {
+	AccountID     uuid.UUID    `json:"account_id"`
+	AccountNumber string       `json:"account_number"`
+	CurrencyCode  string       `json:"currency_code"`
+	AsOf          time.Time    `json:"as_of"`
+	Basis         BalanceBasis `json:"basis"`
+	Balance       float64      `json:"balance"`
+}
+
+type BalanceService struct {
+	accountRepo *repository.AccountRepository
+	ledgerRepo  *repository.LedgerRepository
+}
+
+func NewBalanceService(
+	accountRepo *repository.AccountRepository,
+	ledgerRepo *repository.LedgerRepository,
+) *BalanceService {
+	return &BalanceService{
+		accountRepo: accountRepo,
+		ledgerRepo:  ledgerRepo,
+	}
+}
+
+func (s *BalanceService) BalanceAsOf(accountID uuid.UUID, asOf time.Time, basis BalanceBasis) (*PointInTimeBalance, error) {
+	balances, missing, err := s.BalancesAsOf([]uuid.UUID{accountID}, asOf, basis)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(missing) > 0 {
+		return nil, ErrAccountNotFound
+	}
+
+	return &balances[0], nil
+}
+
+// BalancesAsOf computes the balances of many accounts in a single query. With
+// the booking basis the balance is the running balance of the last entry booked
+// at or before asOf; with the value basis it is the sum of entries valued on or
+// before the UTC date of asOf. Unknown account IDs are returned in missing.
+func (s *BalanceService) BalancesAsOf(accountIDs []uuid.UUID, asOf time.Time, basis BalanceBasis) (balances []PointInTimeBalance, missing []uuid.UUID, err error) {
+	accounts, err := s.accountRepo.FindByIDs(accountIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch accounts: %w", err)
+	}
+
+	var ledgerBalances []repository.AccountBalance
+	if basis == BalanceBasisValue {
+		ledgerBalances, err = s.ledgerRepo.BalancesAsOfValueDate(accountIDs, valueDateOf(asOf))
+	} else {
+		ledgerBalances, err = s.ledgerRepo.BalancesAsOf(accountIDs, asOf)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compute balances: %w", err)
+	}
+
+	byAccount := make(map[uuid.UUID]float64, len(ledgerBalances))
+	for _, b := range ledgerBalances {
+		byAccount[b.AccountID] = b.Balance
+	}
+
+	found := make(map[uuid.UUID]int, len(accounts))
+	for i, account := range accounts {
+		found[account.ID] = i
+	}
+
+	balances = make([]PointInTimeBalance, 0, len(accountIDs))
+	for _, id := range accountIDs {
+		i, ok := found[id]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+
+		balances = append(balances, PointInTimeBalance{
+			AccountID:     id,
+			AccountNumber: accounts[i].AccountNumber,
+			CurrencyCode:  accounts[i].CurrencyCode,
+			AsOf:          asOf,
+			Basis:         basis,
+			Balance:       roundAmount(byAccount[id]),
+		})
+	}
+
+	return balances, missing, nil
+}
+
+// LedgerPage is one page of an account's ledger entries in booking order.
+// NextCursor is empty on the last page.
+type LedgerPage struct {
+	Entries    []model.LedgerEntry `json:"entries"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// LedgerEntries pages through the account's ledger, starting after the
+// cursor returned with the previous page.
+func (s *BalanceService) LedgerEntries(accountID uuid.UUID, cursor string, limit int) (*LedgerPage, error) {
+	if _, err := s.accountRepo.FindByIDWithoutEntries(accountID); err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	var after *repository.LedgerCursor
+	if cursor != "" {
+		decoded, err := decodeLedgerCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = decoded
+	}
+
+	// One extra entry tells whether another page follows.
+	entries, err := s.ledgerRepo.FindPageByAccount(accountID, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &LedgerPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		last := page.Entries[limit-1]
+		page.NextCursor = encodeLedgerCursor(repository.LedgerCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return page, nil
+}
+
+// encodeLedgerCursor makes an opaque cursor of the booking position.
+func encodeLedgerCursor(cursor repository.LedgerCursor) string {
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixMicro(), 10) + ":" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeLedgerCursor(value string) (*repository.LedgerCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	unixMicro, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	entryID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &repository.LedgerCursor{CreatedAt: time.UnixMicro(unixMicro).UTC(), ID: entryID}, nil
+}
+
+// valueDateOf returns the UTC calendar date of t.
+func valueDateOf(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
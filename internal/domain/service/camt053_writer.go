@@ -0,0 +1,179 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/iso20022"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const camt053Issuer = "PAYGO"
+
+// Camt053StatementWriter renders a statement as an ISO 20022 camt.053.001.02
+// document. The schema puts the closing balance and the transaction totals
+// before the entries, so they are taken from a summary computed up front and
+// checked against the streamed entries at the end.
+type Camt053StatementWriter struct {
+	enc      *iso20022.StatementEncoder
+	expected StatementSummary
+	currency string
+}
+
+func NewCamt053StatementWriter(w io.Writer, expected StatementSummary) *Camt053StatementWriter {
+	return &Camt053StatementWriter{
+		enc:      iso20022.NewStatementEncoder(w),
+		expected: expected,
+	}
+}
+
+func (c *Camt053StatementWriter) WriteHeader(header StatementHeader) error {
+	c.currency = header.CurrencyCode
+
+	groupHeader := iso20022.GroupHeader{
+		MsgId:   "CAMT053-" + compactID(uuid.New())[:24],
+		CreDtTm: iso20022.NewISODateTime(header.GeneratedAt),
+	}
+
+	openingAmount, openingIndicator := iso20022.NewAmount(header.CurrencyCode, header.OpeningBalance)
+	closingAmount, closingIndicator := iso20022.NewAmount(header.CurrencyCode, c.expected.ClosingBalance)
+	netAmount, netIndicator := iso20022.NewAmount(header.CurrencyCode, c.expected.TotalCredits-c.expected.TotalDebits)
+	openingDate := iso20022.NewISODate(header.From.UTC())
+	closingDate := iso20022.NewISODate(header.To.Add(-time.Nanosecond).UTC())
+
+	stmt := &iso20022.Statement{
+		Id:      truncateText(fmt.Sprintf("%s-%s-%s", header.AccountNumber, header.From.UTC().Format("20060102"), header.To.UTC().Format("20060102")), 35),
+		CreDtTm: iso20022.NewISODateTime(header.GeneratedAt),
+		FrToDt: &iso20022.DateTimePeriod{
+			FrDtTm: iso20022.NewISODateTime(header.From),
+			ToDtTm: iso20022.NewISODateTime(header.To),
+		},
+		Acct: camt053Account(header.AccountNumber, header.CurrencyCode),
+		Bal: []iso20022.CashBalance{
+			{
+				Tp:        iso20022.BalanceType{CdOrPrtry: iso20022.BalanceTypeCode{Cd: iso20022.BalanceTypeOpeningBooked}},
+				Amt:       openingAmount,
+				CdtDbtInd: openingIndicator,
+				Dt:        iso20022.DateAndDateTimeChoice{Dt: &openingDate},
+			},
+			{
+				Tp:        iso20022.BalanceType{CdOrPrtry: iso20022.BalanceTypeCode{Cd: iso20022.BalanceTypeClosingBooked}},
+				Amt:       closingAmount,
+				CdtDbtInd: closingIndicator,
+				Dt:        iso20022.DateAndDateTimeChoice{Dt: &closingDate},
+			},
+		},
+		TxsSummry: &iso20022.TotalTransactions{
+			TtlNtries: &iso20022.NumberAndSumOfTransactions{
+				NbOfNtries:    strconv.Itoa(c.expected.EntryCount),
+				Sum:           iso20022.FormatDecimal(c.expected.TotalCredits + c.expected.TotalDebits),
+				TtlNetNtryAmt: netAmount.Value,
+				CdtDbtInd:     netIndicator,
+			},
+			TtlCdtNtries: &iso20022.NumberAndSumOfTransactions{
+				NbOfNtries: strconv.Itoa(c.expected.CreditCount),
+				Sum:        iso20022.FormatDecimal(c.expected.TotalCredits),
+			},
+			TtlDbtNtries: &iso20022.NumberAndSumOfTransactions{
+				NbOfNtries: strconv.Itoa(c.expected.DebitCount),
+				Sum:        iso20022.FormatDecimal(c.expected.TotalDebits),
+			},
+		},
+	}
+
+	return c.enc.Begin(groupHeader, stmt)
+}
+
+func (c *Camt053StatementWriter) WriteLine(line *repository.StatementLine) error {
+	signed, family := line.Amount, "RCDT"
+	if line.EntryType == "debit" {
+		signed, family = -line.Amount, "ICDT"
+	}
+	amount, indicator := iso20022.NewAmount(c.currency, signed)
+
+	bookedAt := iso20022.NewISODateTime(line.BookedAt)
+	valueDate := iso20022.NewISODate(line.BookedAt.UTC())
+	if line.ValueDate != nil {
+		valueDate = iso20022.NewISODate(*line.ValueDate)
+	}
+
+	transactionType := strings.ToUpper(line.TransactionType)
+	if transactionType == "" {
+		transactionType = "UNKNOWN"
+	}
+
+	details := iso20022.TransactionDetails{
+		Refs: &iso20022.TransactionReferences{
+			AcctSvcrRef: compactID(line.TransactionID),
+			EndToEndId:  truncateText(line.Reference, 35),
+			TxId:        truncateText(line.Reference, 35),
+		},
+	}
+
+	if line.CounterpartyAccountNumber != "" {
+		counterparty := camt053Account(line.CounterpartyAccountNumber, "")
+		if line.EntryType == "debit" {
+			details.RltdPties = &iso20022.RelatedParties{CdtrAcct: &counterparty}
+		} else {
+			details.RltdPties = &iso20022.RelatedParties{DbtrAcct: &counterparty}
+		}
+	}
+
+	if line.Description != "" {
+		details.RmtInf = &iso20022.RemittanceInformation{Ustrd: []string{truncateText(line.Description, 140)}}
+	}
+
+	entry := &iso20022.ReportEntry{
+		NtryRef:     compactID(line.EntryID),
+		Amt:         amount,
+		CdtDbtInd:   indicator,
+		Sts:         iso20022.EntryStatusBooked,
+		BookgDt:     &iso20022.DateAndDateTimeChoice{DtTm: &bookedAt},
+		ValDt:       &iso20022.DateAndDateTimeChoice{Dt: &valueDate},
+		AcctSvcrRef: compactID(line.EntryID),
+		BkTxCd: iso20022.BankTransactionCode{
+			Domn: &iso20022.BankTransactionCodeDomain{
+				Cd:   "PMNT",
+				Fmly: iso20022.BankTransactionCodeFamily{Cd: family, SubFmlyCd: "BOOK"},
+			},
+			Prtry: &iso20022.ProprietaryBankTransactionCode{Cd: truncateText(transactionType, 35), Issr: camt053Issuer},
+		},
+		NtryDtls: []iso20022.EntryDetails{{TxDtls: []iso20022.TransactionDetails{details}}},
+	}
+
+	return c.enc.Entry(entry)
+}
+
+func (c *Camt053StatementWriter) WriteSummary(summary StatementSummary) error {
+	if summary.EntryCount != c.expected.EntryCount ||
+		summary.TotalDebits != c.expected.TotalDebits ||
+		summary.TotalCredits != c.expected.TotalCredits {
+		return fmt.Errorf("statement entries changed while exporting: expected %d entries, streamed %d",
+			c.expected.EntryCount, summary.EntryCount)
+	}
+	return c.enc.End()
+}
+
+func camt053Account(accountNumber, currency string) iso20022.CashAccount {
+	return iso20022.CashAccount{
+		Id:  iso20022.AccountIdentification{Othr: &iso20022.GenericAccountIdentification{Id: truncateText(accountNumber, 34)}},
+		Ccy: currency,
+	}
+}
+
+// compactID renders a UUID without hyphens so it fits Max35Text references.
+func compactID(id uuid.UUID) string {
+	return strings.ReplaceAll(id.String(), "-", "")
+}
+
+func truncateText(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
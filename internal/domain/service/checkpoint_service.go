@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CheckpointService struct {
+	accountRepo     *repository.AccountRepository
+	ledgerRepo      *repository.LedgerRepository
+	transactionRepo *repository.TransactionRepository
+	checkpointRepo  *repository.CheckpointRepository
+	// settleDelay keeps checkpoints behind the ledger head so that transfers
+	// still in flight cannot commit entries into an already covered range.
+	settleDelay time.Duration
+	// duplicateWindow is the audit's duplicate transfer window; checkpoints
+	// stop short of duplicates within it.
+	duplicateWindow time.Duration
+}
+
+func NewCheckpointService(
+	accountRepo *repository.AccountRepository,
+	ledgerRepo *repository.LedgerRepository,
+	transactionRepo *repository.TransactionRepository,
+	checkpointRepo *repository.CheckpointRepository,
+	settleDelay time.Duration,
+	duplicateWindow time.Duration,
+) *CheckpointService {
+	return &CheckpointService{
+		accountRepo:     accountRepo,
+		ledgerRepo:      ledgerRepo,
+		transactionRepo: transactionRepo,
+		checkpointRepo:  checkpointRepo,
+		settleDelay:     settleDelay,
+		duplicateWindow: duplicateWindow,
+	}
+}
+
+// CreateCheckpoints extends the checkpoint chain of every account. It is meant
+// to be run periodically by the scheduler.
+func (s *CheckpointService) CreateCheckpoints(ctx context.Context) error {
+	accounts, err := s.accountRepo.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	until := time.Now().Add(-s.settleDelay)
+	created := 0
+
+	for _, account := range accounts {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		checkpoint, err := s.CreateCheckpoint(account.ID, until)
+		if err != nil {
+			log.Printf("Failed to create checkpoint for account %s: %v", account.ID, err)
+			continue
+		}
+		if checkpoint != nil {
+			created++
+		}
+	}
+
+	log.Printf("Balance checkpoints created: %d (accounts: %d)", created, len(accounts))
+	return nil
+}
+
+// CreateCheckpoint covers the account's entries booked up to until with a new
+// checkpoint chained to the latest verified one. It returns nil when there are
+// no new entries to cover.
+//
+// Audits only run their detectors on entries after the checkpoint, so a
+// checkpoint never covers a running balance break, a duplicate leg or a
+// duplicate transfer: it stops just before the first one, which keeps the
+// finding in every audit until the ledger is corrected.
+func (s *CheckpointService) CreateCheckpoint(accountID uuid.UUID, until time.Time) (*model.BalanceCheckpoint, error) {
+	base, err := s.latestValidCheckpoint(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var after time.Time
+	if base != nil {
+		if !until.After(base.CoveredUntil) {
+			return nil, nil
+		}
+		after = base.CoveredUntil
+	}
+
+	entries, err := s.ledgerRepo.FindByAccountBetween(accountID, after, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ledger entries: %w", err)
+	}
+
+	opening := 0.0
+	if base != nil {
+		opening = base.Balance
+	}
+	clean, reason, err := s.cleanPrefix(accountID, after, opening, entries)
+	if err != nil {
+		return nil, err
+	}
+	if clean < len(entries) {
+		log.Printf("Checkpoint of account %s stops before entry %s: %s", accountID, entries[clean].ID, reason)
+		// Entries booked in the same microsecond fall on the far side too.
+		until = entries[clean].CreatedAt.Add(-time.Microsecond)
+		for clean > 0 && entries[clean-1].CreatedAt.After(until) {
+			clean--
+		}
+		entries = entries[:clean]
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	lastEntryID := entries[len(entries)-1].ID
+	checkpoint := &model.BalanceCheckpoint{
+		AccountID:    accountID,
+		CoveredUntil: until,
+		LastEntryID:  &lastEntryID,
+		EntryCount:   int64(len(entries)),
+		Balance:      sumLedgerEntries(entries),
+		EntriesHash:  hashLedgerEntries("", entries),
+		Verified:     true,
+		VerifiedAt:   &now,
+		CreatedAt:    now,
+	}
+
+	if base != nil {
+		checkpoint.PreviousCheckpointID = &base.ID
+		checkpoint.EntryCount += base.EntryCount
+		checkpoint.Balance = roundAmount(base.Balance + checkpoint.Balance)
+		checkpoint.EntriesHash = hashLedgerEntries(base.EntriesHash, entries)
+	}
+
+	if err := s.checkpointRepo.Create(checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+// cleanPrefix returns how many of the entries, booked after the given time,
+// can be sealed: those before the first running balance break and the first
+// entry of a transaction the duplicate leg or duplicate transfer detectors
+// flag. The reason is set when it stops short.
+func (s *CheckpointService) cleanPrefix(accountID uuid.UUID, after time.Time, opening float64, entries []model.LedgerEntry) (int, string, error) {
+	flagged := map[uuid.UUID]string{}
+
+	legs, err := s.ledgerRepo.FindDuplicateLegs(accountID, after)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to check for duplicate legs: %w", err)
+	}
+	for _, leg := range legs {
+		flagged[leg.TransactionID] = fmt.Sprintf("transaction %s posted %d %s legs", leg.TransactionID, leg.Count, leg.EntryType)
+	}
+
+	if s.duplicateWindow > 0 {
+		duplicates, err := s.transactionRepo.FindDuplicateTransfers(accountID, after, s.duplicateWindow)
+		if err != nil {
+			return 0, "", fmt.Errorf("failed to check for duplicate transfers: %w", err)
+		}
+		for _, duplicate := range duplicates {
+			if _, ok := flagged[duplicate.TransactionID]; !ok {
+				flagged[duplicate.TransactionID] = fmt.Sprintf("transfer %s repeats %s", duplicate.TransactionReference, duplicate.PreviousReference)
+			}
+		}
+	}
+
+	balance := roundAmount(opening)
+	for i, entry := range entries {
+		if reason, ok := flagged[entry.TransactionID]; ok {
+			return i, reason, nil
+		}
+
+		switch entry.EntryType {
+		case "credit":
+			balance = roundAmount(balance + entry.Amount)
+		case "debit":
+			balance = roundAmount(balance - entry.Amount)
+		}
+		if roundAmount(entry.RunningBalance) != balance {
+			return i, fmt.Sprintf("running balance break, expected=%.4f, recorded=%.4f", balance, entry.RunningBalance), nil
+		}
+	}
+
+	return len(entries), "", nil
+}
+
+// VerifyCheckpoints re-verifies every verified checkpoint of every account. A
+// checkpoint is only re-verified when it is extended, and audits skip the
+// entries it covers, so an entry changed under an older checkpoint would
+// otherwise go unnoticed. It is meant to be run periodically by the scheduler.
+func (s *CheckpointService) VerifyCheckpoints(ctx context.Context) error {
+	accounts, err := s.accountRepo.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	invalidated := int64(0)
+	for _, account := range accounts {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		count, err := s.VerifyChain(account.ID)
+		if err != nil {
+			log.Printf("Failed to verify checkpoints of account %s: %v", account.ID, err)
+			continue
+		}
+		invalidated += count
+	}
+
+	log.Printf("Balance checkpoints verified (accounts: %d, invalidated: %d)", len(accounts), invalidated)
+	return nil
+}
+
+// VerifyChain verifies the account's checkpoints from the oldest one on, one
+// segment at a time. Each must also chain onto the verified checkpoint before
+// it, so one built on a checkpoint invalidated meanwhile does not pass. The
+// first checkpoint that fails and every later one are marked unverified, so
+// audits fall back to the last checkpoint that still holds. It returns how
+// many were marked.
+func (s *CheckpointService) VerifyChain(accountID uuid.UUID) (int64, error) {
+	checkpoints, err := s.checkpointRepo.FindVerified(accountID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load checkpoints: %w", err)
+	}
+
+	var previousID *uuid.UUID
+	for i := range checkpoints {
+		checkpoint := &checkpoints[i]
+		valid := checkpoint.PreviousCheckpointID == nil && previousID == nil ||
+			checkpoint.PreviousCheckpointID != nil && previousID != nil && *checkpoint.PreviousCheckpointID == *previousID
+		if valid {
+			if valid, err = s.VerifyCheckpoint(checkpoint); err != nil {
+				return 0, err
+			}
+		}
+		if valid {
+			previousID = &checkpoint.ID
+			continue
+		}
+
+		log.Printf("Checkpoint %s of account %s failed verification, marking it and later checkpoints as unverified", checkpoint.ID, accountID)
+		count, err := s.checkpointRepo.InvalidateFrom(accountID, checkpoint.CoveredUntil)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update checkpoints: %w", err)
+		}
+		return count, nil
+	}
+
+	return 0, nil
+}
+
+// VerifyCheckpoint recomputes the hash, entry count and balance of the
+// entries covered between the checkpoint and its predecessor.
+func (s *CheckpointService) VerifyCheckpoint(checkpoint *model.BalanceCheckpoint) (bool, error) {
+	var previous *model.BalanceCheckpoint
+	if checkpoint.PreviousCheckpointID != nil {
+		var err error
+		if previous, err = s.checkpointRepo.FindByID(*checkpoint.PreviousCheckpointID); err != nil {
+			return false, fmt.Errorf("failed to load previous checkpoint: %w", err)
+		}
+	}
+
+	var after time.Time
+	prevHash, prevCount, prevBalance := "", int64(0), 0.0
+	if previous != nil {
+		after = previous.CoveredUntil
+		prevHash, prevCount, prevBalance = previous.EntriesHash, previous.EntryCount, previous.Balance
+	}
+
+	entries, err := s.ledgerRepo.FindByAccountBetween(checkpoint.AccountID, after, checkpoint.CoveredUntil)
+	if err != nil {
+		return false, fmt.Errorf("failed to load ledger entries: %w", err)
+	}
+
+	valid := hashLedgerEntries(prevHash, entries) == checkpoint.EntriesHash &&
+		prevCount+int64(len(entries)) == checkpoint.EntryCount &&
+		roundAmount(prevBalance+sumLedgerEntries(entries)) == roundAmount(checkpoint.Balance)
+
+	return valid, nil
+}
+
+// latestValidCheckpoint re-verifies the newest verified checkpoint before it
+// is extended, demoting it when the entries it covers have changed since.
+func (s *CheckpointService) latestValidCheckpoint(accountID uuid.UUID) (*model.BalanceCheckpoint, error) {
+	for {
+		checkpoint, err := s.checkpointRepo.FindLatestVerified(accountID)
+		if err != nil || checkpoint == nil {
+			return checkpoint, err
+		}
+
+		valid, err := s.VerifyCheckpoint(checkpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		if valid {
+			return checkpoint, nil
+		}
+
+		log.Printf("Checkpoint %s of account %s failed verification, marking as unverified", checkpoint.ID, accountID)
+		checkpoint.Verified = false
+		if err := s.checkpointRepo.Update(checkpoint); err != nil {
+			return nil, fmt.Errorf("failed to update checkpoint: %w", err)
+		}
+	}
+}
+
+func sumLedgerEntries(entries []model.LedgerEntry) float64 {
+	balance := 0.0
+
+	for _, entry := range entries {
+		if entry.EntryType == "credit" {
+			balance += entry.Amount
+		} else if entry.EntryType == "debit" {
+			balance -= entry.Amount
+		}
+	}
+
+	return roundAmount(balance)
+}
+
+// hashLedgerEntries chains the entries onto prevHash so a checkpoint's hash
+// commits to every entry covered since the beginning of the ledger.
+func hashLedgerEntries(prevHash string, entries []model.LedgerEntry) string {
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+
+	for _, entry := range entries {
+		fmt.Fprintf(h, "%s|%s|%s|%.4f|%.4f|%d\n",
+			entry.ID, entry.TransactionID, entry.EntryType, entry.Amount, entry.RunningBalance, entry.CreatedAt.UnixMicro())
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// roundAmount rounds to the precision of the numeric(19,4) money columns.
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*10000) / 10000
+}
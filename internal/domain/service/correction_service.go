@@ -0,0 +1,182 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/database"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrAlreadyReversed     = errors.New("transaction has already been reversed")
+)
+
+// CorrectionService posts corrections. Ledger entries are never edited: a
+// correction is a new transaction booked in the open period that references
+// the original, whose own period may already be closed.
+type CorrectionService struct {
+	db              database.DBManager
+	accountRepo     *repository.AccountRepository
+	transactionRepo *repository.TransactionRepository
+	periodRepo      *repository.PeriodRepository
+}
+
+func NewCorrectionService(
+	db database.DBManager,
+	accountRepo *repository.AccountRepository,
+	transactionRepo *repository.TransactionRepository,
+	periodRepo *repository.PeriodRepository,
+) *CorrectionService {
+	return &CorrectionService{
+		db:              db,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		periodRepo:      periodRepo,
+	}
+}
+
+// ReverseTransaction posts the mirror image of every leg of the original
+// transaction, booked now.
+func (s *CorrectionService) ReverseTransaction(originalID uuid.UUID, reason string) (*model.Transaction, error) {
+	var reversal model.Transaction
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txAccountRepo := s.accountRepo.WithTx(tx)
+		txTransactionRepo := s.transactionRepo.WithTx(tx)
+
+		original, err := txTransactionRepo.FindByID(originalID)
+		if err != nil {
+			if repository.IsNotFound(err) {
+				return ErrTransactionNotFound
+			}
+			return err
+		}
+
+		if original.Status != "completed" || len(original.LedgerEntries) == 0 {
+			return fmt.Errorf("only completed transactions with ledger entries can be reversed, status is %q", original.Status)
+		}
+
+		corrections, err := txTransactionRepo.FindCorrections(originalID)
+		if err != nil {
+			return err
+		}
+		for _, correction := range corrections {
+			if correction.TransactionType == "reversal" {
+				return ErrAlreadyReversed
+			}
+		}
+
+		bookingTime := time.Now()
+		if err := EnsurePeriodOpen(s.periodRepo.WithTx(tx), bookingTime); err != nil {
+			return err
+		}
+
+		accounts, err := s.lockAccounts(txAccountRepo, original.LedgerEntries)
+		if err != nil {
+			return err
+		}
+
+		reversal = model.Transaction{
+			TransactionReference:  fmt.Sprintf("REV-%s", uuid.New().String()[:8]),
+			TransactionType:       "reversal",
+			Amount:                original.Amount,
+			CurrencyCode:          original.CurrencyCode,
+			Status:                "completed",
+			Description:           fmt.Sprintf("Reversal of %s: %s", original.TransactionReference, reason),
+			OriginalTransactionID: &original.ID,
+			CreatedAt:             bookingTime,
+			UpdatedAt:             bookingTime,
+		}
+
+		if err := txTransactionRepo.Create(&reversal); err != nil {
+			return err
+		}
+
+		valueDate := valueDateOf(bookingTime)
+		for _, leg := range original.LedgerEntries {
+			account := accounts[leg.AccountID]
+
+			entry := model.LedgerEntry{
+				TransactionID: reversal.ID,
+				AccountID:     account.ID,
+				Amount:        leg.Amount,
+				ValueDate:     &valueDate,
+				CreatedAt:     time.Now(),
+			}
+
+			if leg.EntryType == "credit" {
+				if account.AccountType != "system" && account.Balance < leg.Amount {
+					return fmt.Errorf("insufficient funds on account %s to reverse the credit", account.AccountNumber)
+				}
+				entry.EntryType = "debit"
+				account.Balance -= leg.Amount
+				account.AvailableBalance -= leg.Amount
+			} else {
+				entry.EntryType = "credit"
+				account.Balance += leg.Amount
+				account.AvailableBalance += leg.Amount
+			}
+			account.UpdatedAt = time.Now()
+			entry.RunningBalance = account.Balance
+
+			if err := txTransactionRepo.CreateLedgerEntry(&entry); err != nil {
+				return err
+			}
+		}
+
+		for _, account := range accounts {
+			if _, err := txAccountRepo.Update(account); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &reversal, nil
+}
+
+// lockAccounts locks the accounts of the legs in ID order so that concurrent
+// corrections cannot deadlock each other.
+func (s *CorrectionService) lockAccounts(repo *repository.AccountRepository, legs []model.LedgerEntry) (map[uuid.UUID]*model.Account, error) {
+	ids := make([]uuid.UUID, 0, len(legs))
+	for _, leg := range legs {
+		ids = append(ids, leg.AccountID)
+	}
+	return lockAccountsInOrder(repo, ids)
+}
+
+// lockAccountsInOrder locks the given accounts in ID order, which every
+// multi-account posting must use to avoid deadlocks.
+func lockAccountsInOrder(repo *repository.AccountRepository, accountIDs []uuid.UUID) (map[uuid.UUID]*model.Account, error) {
+	ids := make([]uuid.UUID, 0, len(accountIDs))
+	seen := make(map[uuid.UUID]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+	accounts := make(map[uuid.UUID]*model.Account, len(ids))
+	for _, id := range ids {
+		account, err := repo.FindByIDForUpdate(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock account %s: %w", id, err)
+		}
+		accounts[id] = account
+	}
+
+	return accounts, nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"paygo/internal/domain/model"
+	"sort"
+	"sync"
+	"time"
+)
+
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+var severityRanks = map[Severity]int{
+	SeverityInfo:     0,
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+func ParseSeverity(value string) (Severity, error) {
+	severity := Severity(value)
+	if _, ok := severityRanks[severity]; !ok {
+		return "", fmt.Errorf("invalid severity %q, expected info, low, medium, high or critical", value)
+	}
+	return severity, nil
+}
+
+// Finding is something a detector found wrong with an account.
+type Finding struct {
+	Detector  string    `json:"detector"`
+	FraudType FraudType `json:"fraud_type"`
+	Severity  Severity  `json:"severity"`
+	Message   string    `json:"message"`
+}
+
+// AccountAudit is what detectors inspect. Detectors query the ledger entries
+// booked after Since, the end of the checkpoint, themselves; everything
+// before is summarised by OpeningBalance. Entries are never loaded into
+// memory, so accounts of any size can be audited.
+type AccountAudit struct {
+	Account            *model.Account
+	Checkpoint         *model.BalanceCheckpoint
+	Since              time.Time
+	OpeningBalance     float64
+	EntryCount         int64 // entries booked after Since
+	LastRunningBalance *float64
+	ExpectedBalance    float64
+}
+
+// Detector is one fraud check run against every audited account. Detect
+// returns the messages of what it found; the registry attaches the fraud
+// type and the configured severity.
+type Detector interface {
+	Name() string
+	FraudType() FraudType
+	DefaultSeverity() Severity
+	Detect(ctx context.Context, audit *AccountAudit) ([]string, error)
+}
+
+// DetectorSetting overrides the defaults of a registered detector.
+type DetectorSetting struct {
+	Enabled  bool
+	Severity Severity
+}
+
+// DetectorInfo describes a registered detector and its effective settings.
+type DetectorInfo struct {
+	Name      string    `json:"name"`
+	FraudType FraudType `json:"fraud_type"`
+	Enabled   bool      `json:"enabled"`
+	Severity  Severity  `json:"severity"`
+}
+
+type registeredDetector struct {
+	detector Detector
+	setting  DetectorSetting
+}
+
+// DetectorRegistry holds the detectors AuditService runs, in registration
+// order, with their enable flag and severity.
+type DetectorRegistry struct {
+	mu        sync.RWMutex
+	detectors []*registeredDetector
+	byName    map[string]*registeredDetector
+}
+
+func NewDetectorRegistry() *DetectorRegistry {
+	return &DetectorRegistry{byName: make(map[string]*registeredDetector)}
+}
+
+// Register adds an enabled detector with its default severity.
+func (r *DetectorRegistry) Register(detector Detector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.byName[detector.Name()]; exists {
+		return fmt.Errorf("detector %q is already registered", detector.Name())
+	}
+
+	entry := &registeredDetector{
+		detector: detector,
+		setting:  DetectorSetting{Enabled: true, Severity: detector.DefaultSeverity()},
+	}
+	r.detectors = append(r.detectors, entry)
+	r.byName[detector.Name()] = entry
+	return nil
+}
+
+func (r *DetectorRegistry) Configure(name string, setting DetectorSetting) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.byName[name]
+	if !ok {
+		return fmt.Errorf("unknown detector %q", name)
+	}
+	if setting.Severity == "" {
+		setting.Severity = entry.setting.Severity
+	}
+	if _, err := ParseSeverity(string(setting.Severity)); err != nil {
+		return err
+	}
+
+	entry.setting = setting
+	return nil
+}
+
+// ApplyConfig disables the named detectors and overrides severities by name.
+func (r *DetectorRegistry) ApplyConfig(disabled []string, severities map[string]string) error {
+	for _, name := range disabled {
+		if err := r.Configure(name, DetectorSetting{Enabled: false}); err != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(severities))
+	for name := range severities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		r.mu.RLock()
+		entry, ok := r.byName[name]
+		r.mu.RUnlock()
+		if !ok {
+			return fmt.Errorf("unknown detector %q", name)
+		}
+		if err := r.Configure(name, DetectorSetting{Enabled: entry.setting.Enabled, Severity: Severity(severities[name])}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *DetectorRegistry) Detectors() []DetectorInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	infos := make([]DetectorInfo, 0, len(r.detectors))
+	for _, entry := range r.detectors {
+		infos = append(infos, DetectorInfo{
+			Name:      entry.detector.Name(),
+			FraudType: entry.detector.FraudType(),
+			Enabled:   entry.setting.Enabled,
+			Severity:  entry.setting.Severity,
+		})
+	}
+	return infos
+}
+
+// Run executes every enabled detector. A failing detector does not stop the
+// others; its error is returned alongside the findings of the rest.
+func (r *DetectorRegistry) Run(ctx context.Context, audit *AccountAudit) ([]Finding, []error) {
+	r.mu.RLock()
+	enabled := make([]registeredDetector, 0, len(r.detectors))
+	for _, entry := range r.detectors {
+		if entry.setting.Enabled {
+			enabled = append(enabled, *entry)
+		}
+	}
+	r.mu.RUnlock()
+
+	var findings []Finding
+	var errs []error
+
+	for _, entry := range enabled {
+		messages, err := entry.detector.Detect(ctx, audit)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.detector.Name(), err))
+			continue
+		}
+		for _, message := range messages {
+			findings = append(findings, Finding{
+				Detector:  entry.detector.Name(),
+				FraudType: entry.detector.FraudType(),
+				Severity:  entry.setting.Severity,
+				Message:   message,
+			})
+		}
+	}
+
+	return findings, errs
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrAccountNotDormant = errors.New("account is not dormant")
+
+const dormancyBatchSize = 500
+
+type DormancyService struct {
+	db                database.DBManager
+	accountRepo       *repository.AccountRepository
+	dormancyPeriod    time.Duration
+	escheatmentPeriod time.Duration
+}
+
+func NewDormancyService(
+	db database.DBManager,
+	accountRepo *repository.AccountRepository,
+	dormancyPeriod time.Duration,
+	escheatmentPeriod time.Duration,
+) *DormancyService {
+	return &DormancyService{
+		db:                db,
+		accountRepo:       accountRepo,
+		dormancyPeriod:    dormancyPeriod,
+		escheatmentPeriod: escheatmentPeriod,
+	}
+}
+
+// EscheatmentReport lists dormant accounts that are due to be reported as
+// unclaimed property.
+type EscheatmentReport struct {
+	GeneratedAt      time.Time                    `json:"generated_at"`
+	InactiveBefore   time.Time                    `json:"inactive_before"`
+	TotalsByCurrency map[string]float64           `json:"totals_by_currency"`
+	Accounts         []repository.AccountActivity `json:"accounts"`
+}
+
+// MarkDormant marks active accounts without activity for the dormancy period
+// as dormant. It is meant to be run periodically by the scheduler.
+func (s *DormancyService) MarkDormant(ctx context.Context) error {
+	if s.dormancyPeriod <= 0 {
+		return nil
+	}
+
+	inactiveBefore := time.Now().Add(-s.dormancyPeriod)
+	afterID := uuid.Nil
+	marked := 0
+
+	for {
+		candidates, err := s.accountRepo.WithContext(ctx).FindActivity(repository.AccountActivityFilter{
+			Status:         "active",
+			InactiveBefore: &inactiveBefore,
+			AfterID:        afterID,
+			Limit:          dormancyBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list inactive accounts: %w", err)
+		}
+
+		for _, candidate := range candidates {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			ok, err := s.markDormant(candidate.AccountID, inactiveBefore)
+			if err != nil {
+				log.Printf("Failed to mark account %s dormant: %v", candidate.AccountID, err)
+				continue
+			}
+			if ok {
+				marked++
+			}
+		}
+
+		if len(candidates) < dormancyBatchSize {
+			break
+		}
+		afterID = candidates[len(candidates)-1].AccountID
+	}
+
+	if marked > 0 {
+		log.Printf("Accounts marked dormant: %d", marked)
+	}
+	return nil
+}
+
+// markDormant re-checks the account with its row locked, so a transfer that
+// committed after the candidate list was read keeps it active.
+func (s *DormancyService) markDormant(accountID uuid.UUID, inactiveBefore time.Time) (bool, error) {
+	marked := false
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txAccountRepo := s.accountRepo.WithTx(tx)
+		account, err := txAccountRepo.FindByIDForUpdate(accountID)
+		if err != nil {
+			return err
+		}
+
+		activities, err := txAccountRepo.FindActivity(repository.AccountActivityFilter{
+			AccountID:      &accountID,
+			Status:         "active",
+			InactiveBefore: &inactiveBefore,
+			Limit:          1,
+		})
+		if err != nil || len(activities) == 0 {
+			return err
+		}
+
+		reason := "No activity since " + activities[0].LastActivityAt.UTC().Format(time.RFC3339)
+		if err := changeAccountStatus(txAccountRepo, account, "dormant", reason, systemActor, time.Now()); err != nil {
+			return err
+		}
+		marked = true
+		return nil
+	})
+
+	return marked, err
+}
+
+// ListDormant returns dormant accounts in ID order.
+func (s *DormancyService) ListDormant(limit, offset int) ([]repository.AccountActivity, error) {
+	return s.accountRepo.FindActivity(repository.AccountActivityFilter{
+		Status: "dormant",
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// Reactivate returns a dormant account to active once the owner's identity has
+// been verified again. The verification is recorded in the status history.
+func (s *DormancyService) Reactivate(accountID uuid.UUID, method, reference, note, actor string) (*model.Account, error) {
+	var account *model.Account
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txAccountRepo := s.accountRepo.WithTx(tx)
+		var err error
+		if account, err = txAccountRepo.FindByIDForUpdate(accountID); err != nil {
+			if repository.IsNotFound(err) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+
+		if account.Status != "dormant" {
+			return fmt.Errorf("%w: status is %q", ErrAccountNotDormant, account.Status)
+		}
+
+		reason := fmt.Sprintf("Verified by %s (%s)", method, reference)
+		if note != "" {
+			reason += ": " + note
+		}
+		return changeAccountStatus(txAccountRepo, account, "active", reason, actor, time.Now())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// EscheatmentReport lists dormant accounts with a balance that have had no
+// activity for the escheatment period.
+func (s *DormancyService) EscheatmentReport() (*EscheatmentReport, error) {
+	now := time.Now()
+	report := &EscheatmentReport{
+		GeneratedAt:      now,
+		InactiveBefore:   now.Add(-s.escheatmentPeriod),
+		TotalsByCurrency: map[string]float64{},
+		Accounts:         []repository.AccountActivity{},
+	}
+
+	afterID := uuid.Nil
+	for {
+		accounts, err := s.accountRepo.FindActivity(repository.AccountActivityFilter{
+			Status:         "dormant",
+			InactiveBefore: &report.InactiveBefore,
+			NonZeroBalance: true,
+			AfterID:        afterID,
+			Limit:          dormancyBatchSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, account := range accounts {
+			report.TotalsByCurrency[account.CurrencyCode] = roundAmount(report.TotalsByCurrency[account.CurrencyCode] + account.Balance)
+		}
+		report.Accounts = append(report.Accounts, accounts...)
+
+		if len(accounts) < dormancyBatchSize {
+			break
+		}
+		afterID = accounts[len(accounts)-1].AccountID
+	}
+
+	return report, nil
+}
+
+// StatusHistory returns the account's status changes, oldest first.
+func (s *DormancyService) StatusHistory(accountID uuid.UUID) ([]model.AccountStatusChange, error) {
+	if _, err := s.accountRepo.FindByIDWithoutEntries(accountID); err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+	return s.accountRepo.FindStatusChanges(accountID)
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/database"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrFraudCaseNotFound = errors.New("fraud case not found")
+	ErrFraudCaseResolved = errors.New("fraud case is already resolved")
+	ErrAccountNotFrozen  = errors.New("account is not frozen")
+)
+
+// systemActor records actions taken automatically in a case history.
+const systemActor = "system"
+
+// FraudCaseService opens a fraud case for every account that fails an audit
+// and, if auto-freeze is on, freezes the account until someone unfreezes it.
+// Only audit runs freeze accounts; ad-hoc audits through the API just open or
+// update the case.
+type FraudCaseService struct {
+	db          database.DBManager
+	accountRepo *repository.AccountRepository
+	caseRepo    *repository.FraudCaseRepository
+	autoFreeze  bool
+}
+
+func NewFraudCaseService(
+	db database.DBManager,
+	accountRepo *repository.AccountRepository,
+	caseRepo *repository.FraudCaseRepository,
+	autoFreeze bool,
+) *FraudCaseService {
+	return &FraudCaseService{
+		db:          db,
+		accountRepo: accountRepo,
+		caseRepo:    caseRepo,
+		autoFreeze:  autoFreeze,
+	}
+}
+
+// AuditCompleted implements AuditObserver.
+func (s *FraudCaseService) AuditCompleted(ctx context.Context, result AuditResult) error {
+	if result.Status != AuditStatusFraudulent {
+		return nil
+	}
+	_, err := s.OpenFromAudit(result, inAuditRun(ctx))
+	return err
+}
+
+// OpenFromAudit opens a case for a fraudulent audit result, or adds the new
+// findings to the account's unresolved case. Findings already covered by a
+// resolved case are ignored, so a resolved case is not reopened by the same
+// history. The account is frozen only if freeze is set. System accounts are
+// never frozen, since freezing them would stop interest and fee postings.
+func (s *FraudCaseService) OpenFromAudit(result AuditResult, freeze bool) (*model.FraudCase, error) {
+	var fraudCase *model.FraudCase
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txAccountRepo := s.accountRepo.WithTx(tx)
+		txCaseRepo := s.caseRepo.WithTx(tx)
+
+		// The account lock serialises concurrent audits of the same account,
+		// so at most one unresolved case is opened for it.
+		accounts, err := lockAccountsInOrder(txAccountRepo, []uuid.UUID{result.AccountID})
+		if err != nil {
+			return err
+		}
+		account := accounts[result.AccountID]
+
+		now := time.Now()
+		resolved, err := txCaseRepo.FindResolvedFindings(account.ID)
+		if err != nil {
+			return err
+		}
+		findings := newCaseFindings(result.Findings, resolved)
+		if len(findings) == 0 {
+			return nil
+		}
+
+		existing, err := txCaseRepo.FindUnresolvedByAccount(account.ID)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			fraudCase = existing
+			added := mergeFindings(fraudCase, findings)
+			if len(added) > 0 {
+				fraudCase.UpdatedAt = now
+				if err := txCaseRepo.Update(fraudCase); err != nil {
+					return err
+				}
+				if err := s.addEvent(txCaseRepo, fraudCase.ID, "findings_added", systemActor, describeFindings(added), now); err != nil {
+					return err
+				}
+			}
+		} else {
+			fraudCase = &model.FraudCase{
+				AccountID: account.ID,
+				Status:    "open",
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			mergeFindings(fraudCase, findings)
+			if err := txCaseRepo.Create(fraudCase); err != nil {
+				return err
+			}
+			note := fmt.Sprintf("Audit at %s found: %s", result.AuditedAt.UTC().Format(time.RFC3339), describeFindings(fraudCase.Findings))
+			if err := s.addEvent(txCaseRepo, fraudCase.ID, "opened", systemActor, note, now); err != nil {
+				return err
+			}
+		}
+
+		if !freeze || !s.autoFreeze || account.Status != "active" || account.AccountType == "system" {
+			return nil
+		}
+
+		if err := changeAccountStatus(txAccountRepo, account, "frozen", "Fraud case "+fraudCase.ID.String(), systemActor, now); err != nil {
+			return err
+		}
+		return s.addEvent(txCaseRepo, fraudCase.ID, "frozen", systemActor, "Account frozen automatically", now)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fraudCase, nil
+}
+
+func (s *FraudCaseService) GetCase(caseID uuid.UUID) (*model.FraudCase, error) {
+	fraudCase, err := s.caseRepo.FindByID(caseID, false)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrFraudCaseNotFound
+		}
+		return nil, err
+	}
+	return fraudCase, nil
+}
+
+func (s *FraudCaseService) ListCases(filter repository.FraudCaseFilter) ([]model.FraudCase, error) {
+	return s.caseRepo.Find(filter)
+}
+
+// Assign hands the case to an investigator and moves it to investigating.
+func (s *FraudCaseService) Assign(caseID uuid.UUID, assignee, actor string) (*model.FraudCase, error) {
+	return s.update(caseID, func(_ database.DB, txCaseRepo *repository.FraudCaseRepository, fraudCase *model.FraudCase, now time.Time) error {
+		if fraudCase.Status == "resolved" {
+			return ErrFraudCaseResolved
+		}
+		fraudCase.AssignedTo = assignee
+		fraudCase.Status = "investigating"
+		return s.addEvent(txCaseRepo, fraudCase.ID, "assigned", actor, "Assigned to "+assignee, now)
+	})
+}
+
+func (s *FraudCaseService) Comment(caseID uuid.UUID, comment, actor string) (*model.FraudCase, error) {
+	return s.update(caseID, func(_ database.DB, txCaseRepo *repository.FraudCaseRepository, fraudCase *model.FraudCase, now time.Time) error {
+		if fraudCase.Status == "resolved" {
+			return ErrFraudCaseResolved
+		}
+		return s.addEvent(txCaseRepo, fraudCase.ID, "comment", actor, comment, now)
+	})
+}
+
+// Resolve closes the case. The account stays frozen until it is unfrozen
+// explicitly, whatever the resolution.
+func (s *FraudCaseService) Resolve(caseID uuid.UUID, resolution, note, actor string) (*model.FraudCase, error) {
+	return s.update(caseID, func(_ database.DB, txCaseRepo *repository.FraudCaseRepository, fraudCase *model.FraudCase, now time.Time) error {
+		if fraudCase.Status == "resolved" {
+			return ErrFraudCaseResolved
+		}
+		fraudCase.Status = "resolved"
+		fraudCase.Resolution = resolution
+		fraudCase.ResolvedAt = &now
+		return s.addEvent(txCaseRepo, fraudCase.ID, "resolved", actor, strings.TrimSpace(resolution+": "+note), now)
+	})
+}
+
+// Unfreeze reactivates the case's account. It is allowed on resolved cases.
+func (s *FraudCaseService) Unfreeze(caseID uuid.UUID, note, actor string) (*model.FraudCase, error) {
+	return s.update(caseID, func(tx database.DB, txCaseRepo *repository.FraudCaseRepository, fraudCase *model.FraudCase, now time.Time) error {
+		txAccountRepo := s.accountRepo.WithTx(tx)
+		accounts, err := lockAccountsInOrder(txAccountRepo, []uuid.UUID{fraudCase.AccountID})
+		if err != nil {
+			return err
+		}
+		account := accounts[fraudCase.AccountID]
+
+		if account.Status != "frozen" {
+			return fmt.Errorf("%w: status is %q", ErrAccountNotFrozen, account.Status)
+		}
+
+		if err := changeAccountStatus(txAccountRepo, account, "active", "Fraud case "+fraudCase.ID.String(), actor, now); err != nil {
+			return err
+		}
+		return s.addEvent(txCaseRepo, fraudCase.ID, "unfrozen", actor, note, now)
+	})
+}
+
+// update applies a case action with the case row locked, saves the case and
+// returns it with its full history.
+func (s *FraudCaseService) update(caseID uuid.UUID, apply func(database.DB, *repository.FraudCaseRepository, *model.FraudCase, time.Time) error) (*model.FraudCase, error) {
+	var fraudCase *model.FraudCase
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txCaseRepo := s.caseRepo.WithTx(tx)
+
+		var err error
+		fraudCase, err = txCaseRepo.FindByID(caseID, true)
+		if err != nil {
+			if repository.IsNotFound(err) {
+				return ErrFraudCaseNotFound
+			}
+			return err
+		}
+
+		now := time.Now()
+		if err := apply(tx, txCaseRepo, fraudCase, now); err != nil {
+			return err
+		}
+
+		// The history was appended above and must not be re-saved.
+		fraudCase.Events = nil
+		fraudCase.UpdatedAt = now
+		return txCaseRepo.Update(fraudCase)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCase(caseID)
+}
+
+func (s *FraudCaseService) addEvent(repo *repository.FraudCaseRepository, caseID uuid.UUID, eventType, actor, note string, at time.Time) error {
+	return repo.CreateEvent(&model.FraudCaseEvent{
+		CaseID:    caseID,
+		Type:      eventType,
+		Actor:     actor,
+		Note:      note,
+		CreatedAt: at,
+	})
+}
+
+// mergeFindings adds the findings the case does not have yet and returns them.
+// Informational findings are kept as context but do not add a fraud type.
+// newCaseFindings returns the findings above info that no resolved case
+// covers. Severity is ignored in the comparison, since it can be reconfigured.
+func newCaseFindings(findings []Finding, resolved model.DetectorFindings) model.DetectorFindings {
+	var fresh []Finding
+	for _, finding := range findings {
+		if severityRanks[finding.Severity] <= severityRanks[SeverityInfo] {
+			continue
+		}
+		covered := slices.ContainsFunc(resolved, func(r model.DetectorFinding) bool {
+			return r.Detector == finding.Detector && r.FraudType == string(finding.FraudType) && r.Message == finding.Message
+		})
+		if !covered {
+			fresh = append(fresh, finding)
+		}
+	}
+	return toDetectorFindings(fresh)
+}
+
+func mergeFindings(fraudCase *model.FraudCase, findings model.DetectorFindings) model.DetectorFindings {
+	var added model.DetectorFindings
+	for _, finding := range findings {
+		if slices.Contains(fraudCase.Findings, finding) {
+			continue
+		}
+		fraudCase.Findings = append(fraudCase.Findings, finding)
+		added = append(added, finding)
+		if finding.Severity != string(SeverityInfo) && !slices.Contains(fraudCase.FraudTypes, finding.FraudType) {
+			fraudCase.FraudTypes = append(fraudCase.FraudTypes, finding.FraudType)
+		}
+	}
+	return added
+}
+
+func describeFindings(findings model.DetectorFindings) string {
+	parts := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		parts = append(parts, fmt.Sprintf("[%s] %s", finding.Severity, finding.Message))
+	}
+	return strings.Join(parts, "; ")
+}
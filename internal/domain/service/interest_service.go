@@ -0,0 +1,439 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DayCountActual365 = "ACT/365"
+	DayCountActual360 = "ACT/360"
+	DayCount30360     = "30/360"
+
+	PostingDaily   = "daily"
+	PostingMonthly = "monthly"
+)
+
+var ErrProductNotFound = errors.New("savings product not found")
+
+type InterestService struct {
+	db              database.DBManager
+	accountRepo     *repository.AccountRepository
+	ledgerRepo      *repository.LedgerRepository
+	transactionRepo *repository.TransactionRepository
+	interestRepo    *repository.InterestRepository
+	periodRepo      *repository.PeriodRepository
+}
+
+func NewInterestService(
+	db database.DBManager,
+	accountRepo *repository.AccountRepository,
+	ledgerRepo *repository.LedgerRepository,
+	transactionRepo *repository.TransactionRepository,
+	interestRepo *repository.InterestRepository,
+	periodRepo *repository.PeriodRepository,
+) *InterestService {
+	return &InterestService{
+		db:              db,
+		accountRepo:     accountRepo,
+		ledgerRepo:      ledgerRepo,
+		transactionRepo: transactionRepo,
+		interestRepo:    interestRepo,
+		periodRepo:      periodRepo,
+	}
+}
+
+// dayCountFraction returns the fraction of a year between start and end under
+// the given convention. 30/360 uses the US (bond basis) day adjustments.
+func dayCountFraction(convention string, start, end time.Time) (float64, error) {
+	switch convention {
+	case DayCountActual365:
+		return end.Sub(start).Hours() / 24 / 365, nil
+	case DayCountActual360:
+		return end.Sub(start).Hours() / 24 / 360, nil
+	case DayCount30360:
+		d1, d2 := start.Day(), end.Day()
+		if d1 == 31 {
+			d1 = 30
+		}
+		if d2 == 31 && d1 == 30 {
+			d2 = 30
+		}
+		days := 360*(end.Year()-start.Year()) + 30*(int(end.Month())-int(start.Month())) + (d2 - d1)
+		return float64(days) / 360, nil
+	}
+	return 0, fmt.Errorf("invalid day count convention %q, expected %q, %q or %q", convention, DayCountActual365, DayCountActual360, DayCount30360)
+}
+
+// postingCutoff returns the start of the current posting period: accruals for
+// days before it are due to be paid out.
+func postingCutoff(frequency string, now time.Time) (time.Time, error) {
+	now = now.UTC()
+	switch frequency {
+	case PostingDaily:
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	case PostingMonthly:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid posting frequency %q, expected %q or %q", frequency, PostingDaily, PostingMonthly)
+}
+
+func (s *InterestService) CreateProduct(product *model.SavingsProduct) (*model.SavingsProduct, error) {
+	if _, err := dayCountFraction(product.DayCount, time.Time{}, time.Time{}); err != nil {
+		return nil, err
+	}
+	if _, err := postingCutoff(product.PostingFrequency, time.Now()); err != nil {
+		return nil, err
+	}
+	if product.AnnualRate < 0 {
+		return nil, errors.New("annual rate cannot be negative")
+	}
+
+	expenseAccount, err := s.accountRepo.FindByIDWithoutEntries(product.ExpenseAccountID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, fmt.Errorf("interest expense account: %w", ErrAccountNotFound)
+		}
+		return nil, err
+	}
+	if expenseAccount.AccountType != "system" {
+		return nil, errors.New("interest must be paid from a system account")
+	}
+	if expenseAccount.CurrencyCode != product.CurrencyCode {
+		return nil, fmt.Errorf("interest expense account is in %s, product is in %s", expenseAccount.CurrencyCode, product.CurrencyCode)
+	}
+
+	product.Status = "active"
+	product.CreatedAt = time.Now()
+	product.UpdatedAt = time.Now()
+
+	if err := s.interestRepo.CreateProduct(product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+func (s *InterestService) ListProducts() ([]model.SavingsProduct, error) {
+	return s.interestRepo.FindProducts()
+}
+
+// AssignProduct turns the account into a savings account of the product.
+// Interest accrues from the following day.
+func (s *InterestService) AssignProduct(accountID, productID uuid.UUID) (*model.Account, error) {
+	product, err := s.interestRepo.FindProductByID(productID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	account, err := s.accountRepo.FindByIDWithoutEntries(accountID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	if product.Status != "active" {
+		return nil, errors.New("savings product is not active")
+	}
+	if account.AccountType == "system" || account.AccountType == "wallet" {
+		return nil, fmt.Errorf("%s accounts cannot earn interest", account.AccountType)
+	}
+	if account.CurrencyCode != product.CurrencyCode {
+		return nil, fmt.Errorf("account is in %s, product is in %s", account.CurrencyCode, product.CurrencyCode)
+	}
+
+	now := time.Now()
+	account.ProductID = &product.ID
+	account.ProductAssignedAt = &now
+	account.AccountType = "savings"
+	account.UpdatedAt = now
+
+	return s.accountRepo.Update(account)
+}
+
+func (s *InterestService) Accruals(accountID uuid.UUID, from, to time.Time) ([]model.InterestAccrual, error) {
+	if _, err := s.accountRepo.FindByIDWithoutEntries(accountID); err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+	return s.interestRepo.FindAccruals(accountID, from, to)
+}
+
+// AccrueInterest records daily interest for every savings account up to and
+// including yesterday, catching up on any days missed since the last run. It
+// is meant to be run periodically by the scheduler.
+func (s *InterestService) AccrueInterest(ctx context.Context) error {
+	accounts, err := s.interestRepo.FindSavingsAccounts()
+	if err != nil {
+		return fmt.Errorf("failed to list savings accounts: %w", err)
+	}
+
+	products := make(map[uuid.UUID]*model.SavingsProduct)
+	through := valueDateOf(time.Now()).AddDate(0, 0, -1)
+	accrued := 0
+
+	for _, account := range accounts {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		product, ok := products[*account.ProductID]
+		if !ok {
+			product, err = s.interestRepo.FindProductByID(*account.ProductID)
+			if err != nil {
+				log.Printf("Failed to load savings product %s: %v", *account.ProductID, err)
+				continue
+			}
+			products[product.ID] = product
+		}
+
+		n, err := s.accrueAccount(ctx, &account, product, through)
+		if err != nil {
+			log.Printf("Failed to accrue interest for account %s: %v", account.ID, err)
+		}
+		accrued += n
+	}
+
+	log.Printf("Interest accruals recorded: %d (accounts: %d)", accrued, len(accounts))
+	return nil
+}
+
+// accrueAccount accrues the days after the latest accrual, starting the day
+// after the product was assigned. Accounts assigned before the assignment
+// date was recorded start on the day they were opened.
+func (s *InterestService) accrueAccount(ctx context.Context, account *model.Account, product *model.SavingsProduct, through time.Time) (int, error) {
+	from := valueDateOf(account.CreatedAt)
+	if account.ProductAssignedAt != nil {
+		from = valueDateOf(*account.ProductAssignedAt).AddDate(0, 0, 1)
+	}
+	last, err := s.interestRepo.FindLatestAccrualDate(account.ID)
+	if err != nil {
+		return 0, err
+	}
+	if last != nil {
+		if next := valueDateOf(*last).AddDate(0, 0, 1); next.After(from) {
+			from = next
+		}
+	}
+
+	accrued := 0
+	for day := from; !day.After(through); day = day.AddDate(0, 0, 1) {
+		if err := ctx.Err(); err != nil {
+			return accrued, err
+		}
+		if err := s.AccrueDay(account.ID, product, day); err != nil {
+			return accrued, fmt.Errorf("accrual for %s: %w", day.Format(time.DateOnly), err)
+		}
+		accrued++
+	}
+
+	return accrued, nil
+}
+
+// AccrueDay records the interest earned on the account's end-of-day value-dated
+// balance for one day. Negative balances earn nothing; the day is still
+// recorded so that it is not accrued again.
+func (s *InterestService) AccrueDay(accountID uuid.UUID, product *model.SavingsProduct, day time.Time) error {
+	balances, err := s.ledgerRepo.BalancesAsOfValueDate([]uuid.UUID{accountID}, day)
+	if err != nil {
+		return err
+	}
+
+	var balance float64
+	if len(balances) > 0 {
+		balance = balances[0].Balance
+	}
+
+	fraction, err := dayCountFraction(product.DayCount, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+
+	var amount float64
+	if balance > 0 {
+		amount = math.Round(balance*product.AnnualRate*fraction*1e8) / 1e8
+	}
+
+	return s.interestRepo.CreateAccrual(&model.InterestAccrual{
+		AccountID:   accountID,
+		ProductID:   product.ID,
+		AccrualDate: day,
+		Balance:     roundAmount(balance),
+		AnnualRate:  product.AnnualRate,
+		DayCount:    product.DayCount,
+		Amount:      amount,
+		Status:      "accrued",
+		CreatedAt:   time.Now(),
+	})
+}
+
+// PostInterest pays out the accrued interest of every savings account whose
+// posting period has ended. It is meant to be run periodically by the
+// scheduler.
+func (s *InterestService) PostInterest(ctx context.Context) error {
+	accounts, err := s.interestRepo.FindSavingsAccounts()
+	if err != nil {
+		return fmt.Errorf("failed to list savings accounts: %w", err)
+	}
+
+	products := make(map[uuid.UUID]*model.SavingsProduct)
+	posted := 0
+
+	for _, account := range accounts {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		product, ok := products[*account.ProductID]
+		if !ok {
+			product, err = s.interestRepo.FindProductByID(*account.ProductID)
+			if err != nil {
+				log.Printf("Failed to load savings product %s: %v", *account.ProductID, err)
+				continue
+			}
+			products[product.ID] = product
+		}
+
+		cutoff, err := postingCutoff(product.PostingFrequency, time.Now())
+		if err != nil {
+			log.Printf("Failed to post interest for account %s: %v", account.ID, err)
+			continue
+		}
+
+		transaction, err := s.PostAccruals(account.ID, product, cutoff)
+		if err != nil {
+			log.Printf("Failed to post interest for account %s: %v", account.ID, err)
+			continue
+		}
+		if transaction != nil {
+			posted++
+		}
+	}
+
+	log.Printf("Interest postings created: %d (accounts: %d)", posted, len(accounts))
+	return nil
+}
+
+// PostAccruals pays the account's unposted accruals for days before cutoff
+// from the product's interest-expense account in a single transaction. The
+// accruals are locked and marked posted in the same database transaction, and
+// the reference is derived from the last accrual date, so a day is never paid
+// twice. It returns nil when there is nothing to pay.
+func (s *InterestService) PostAccruals(accountID uuid.UUID, product *model.SavingsProduct, cutoff time.Time) (*model.Transaction, error) {
+	var posting *model.Transaction
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txInterestRepo := s.interestRepo.WithTx(tx)
+		txTransactionRepo := s.transactionRepo.WithTx(tx)
+
+		accruals, err := txInterestRepo.FindUnpostedBefore(accountID, cutoff)
+		if err != nil {
+			return err
+		}
+		if len(accruals) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, 0, len(accruals))
+		var total float64
+		for _, accrual := range accruals {
+			ids = append(ids, accrual.ID)
+			total += accrual.Amount
+		}
+		amount := roundAmount(total)
+		bookingTime := time.Now()
+
+		// Nothing to pay for days spent at or below zero.
+		if amount == 0 {
+			return txInterestRepo.MarkPosted(ids, nil, bookingTime)
+		}
+
+		if err := EnsurePeriodOpen(s.periodRepo.WithTx(tx), bookingTime); err != nil {
+			return err
+		}
+
+		txAccountRepo := s.accountRepo.WithTx(tx)
+		accounts, err := lockAccountsInOrder(txAccountRepo, []uuid.UUID{accountID, product.ExpenseAccountID})
+		if err != nil {
+			return err
+		}
+		account, expenseAccount := accounts[accountID], accounts[product.ExpenseAccountID]
+
+		firstDay, lastDay := accruals[0].AccrualDate, accruals[len(accruals)-1].AccrualDate
+		posting = &model.Transaction{
+			TransactionReference: fmt.Sprintf("INT-%s-%s", compactID(accountID)[:12], lastDay.Format("20060102")),
+			TransactionType:      "interest",
+			Amount:               amount,
+			CurrencyCode:         account.CurrencyCode,
+			Status:               "completed",
+			Description: fmt.Sprintf("Interest %s to %s at %.4f%% %s",
+				firstDay.Format(time.DateOnly), lastDay.Format(time.DateOnly), product.AnnualRate*100, product.DayCount),
+			CreatedAt: bookingTime,
+			UpdatedAt: bookingTime,
+		}
+		if err := txTransactionRepo.Create(posting); err != nil {
+			return err
+		}
+
+		valueDate := valueDateOf(bookingTime)
+
+		expenseAccount.Balance -= amount
+		expenseAccount.AvailableBalance -= amount
+		expenseAccount.UpdatedAt = bookingTime
+		if err := txTransactionRepo.CreateLedgerEntry(&model.LedgerEntry{
+			TransactionID:  posting.ID,
+			AccountID:      expenseAccount.ID,
+			EntryType:      "debit",
+			Amount:         amount,
+			RunningBalance: expenseAccount.Balance,
+			ValueDate:      &valueDate,
+			CreatedAt:      time.Now(),
+		}); err != nil {
+			return err
+		}
+
+		account.Balance += amount
+		account.AvailableBalance += amount
+		account.UpdatedAt = bookingTime
+		if err := txTransactionRepo.CreateLedgerEntry(&model.LedgerEntry{
+			TransactionID:  posting.ID,
+			AccountID:      account.ID,
+			EntryType:      "credit",
+			Amount:         amount,
+			RunningBalance: account.Balance,
+			ValueDate:      &valueDate,
+			CreatedAt:      time.Now(),
+		}); err != nil {
+			return err
+		}
+
+		for _, a := range []*model.Account{expenseAccount, account} {
+			if _, err := txAccountRepo.Update(a); err != nil {
+				return err
+			}
+		}
+
+		return txInterestRepo.MarkPosted(ids, &posting.ID, bookingTime)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return posting, nil
+}
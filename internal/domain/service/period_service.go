@@ -0,0 +1,148 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PeriodTypeDay   = "day"
+	PeriodTypeMonth = "month"
+)
+
+var (
+	ErrPeriodClosed        = errors.New("booking date falls in a closed accounting period")
+	ErrPeriodAlreadyClosed = errors.New("accounting period is already closed")
+	ErrPeriodNotEnded      = errors.New("accounting period has not ended yet")
+	ErrPeriodNotFound      = errors.New("accounting period not found")
+)
+
+type PeriodService struct {
+	db         database.DBManager
+	periodRepo *repository.PeriodRepository
+}
+
+func NewPeriodService(
+	db database.DBManager,
+	periodRepo *repository.PeriodRepository,
+) *PeriodService {
+	return &PeriodService{
+		db:         db,
+		periodRepo: periodRepo,
+	}
+}
+
+// PeriodBounds returns the UTC start (inclusive) and end (exclusive) of the
+// day or month containing date.
+func PeriodBounds(periodType string, date time.Time) (time.Time, time.Time, error) {
+	date = date.UTC()
+
+	switch periodType {
+	case PeriodTypeDay:
+		start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 1), nil
+	case PeriodTypeMonth:
+		start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0), nil
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid period type %q, expected %q or %q", periodType, PeriodTypeDay, PeriodTypeMonth)
+}
+
+// ClosePeriod closes the day or month containing date and snapshots the
+// ledger balance of every account at its end. Once closed, nothing can be
+// booked before the end of the period. Postings in flight when the close
+// starts commit before the snapshot is taken.
+func (s *PeriodService) ClosePeriod(periodType string, date time.Time, actor string) (*model.AccountingPeriod, error) {
+	start, end, err := PeriodBounds(periodType, date)
+	if err != nil {
+		return nil, err
+	}
+
+	if end.After(time.Now()) {
+		return nil, ErrPeriodNotEnded
+	}
+
+	period := model.AccountingPeriod{
+		PeriodType: periodType,
+		StartsAt:   start,
+		EndsAt:     end,
+		Status:     "closed",
+		ClosedBy:   actor,
+		ClosedAt:   time.Now(),
+	}
+
+	err = s.db.WithTransaction(func(tx database.DB) error {
+		txPeriodRepo := s.periodRepo.WithTx(tx)
+		if err := txPeriodRepo.LockForClose(); err != nil {
+			return fmt.Errorf("failed to lock accounting periods: %w", err)
+		}
+
+		exists, err := txPeriodRepo.Exists(periodType, start)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrPeriodAlreadyClosed
+		}
+
+		if err := txPeriodRepo.Create(&period); err != nil {
+			return err
+		}
+
+		count, err := txPeriodRepo.CreateSnapshots(&period)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot balances: %w", err)
+		}
+
+		period.SnapshotCount = count
+		return txPeriodRepo.Update(&period)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+func (s *PeriodService) ListPeriods() ([]model.AccountingPeriod, error) {
+	return s.periodRepo.FindAll()
+}
+
+func (s *PeriodService) Snapshots(periodID uuid.UUID) ([]model.PeriodBalanceSnapshot, error) {
+	if _, err := s.periodRepo.FindByID(periodID); err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrPeriodNotFound
+		}
+		return nil, err
+	}
+	return s.periodRepo.FindSnapshots(periodID)
+}
+
+// EnsurePeriodOpen rejects postings whose booking time falls before the end
+// of the latest closed period. Every service that writes ledger entries must
+// call it inside its database transaction. It holds off closing a period
+// until the transaction ends.
+func EnsurePeriodOpen(repo *repository.PeriodRepository, bookingTime time.Time) error {
+	if err := repo.LockForPosting(); err != nil {
+		return fmt.Errorf("failed to lock accounting periods: %w", err)
+	}
+
+	period, err := repo.FindLatestClosed()
+	if err != nil {
+		return fmt.Errorf("failed to check accounting periods: %w", err)
+	}
+
+	if period != nil && bookingTime.Before(period.EndsAt) {
+		return fmt.Errorf("%w: periods are closed through %s", ErrPeriodClosed, period.EndsAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
@@ -0,0 +1,545 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/bankstatement"
+	"paygo/internal/infra/database"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrStatementNotFound         = errors.New("bank statement not found")
+	ErrStatementAlreadyImported  = errors.New("bank statement has already been imported")
+	ErrStatementLineNotFound     = errors.New("bank statement line not found")
+	ErrStatementLineMatched      = errors.New("bank statement line is already matched")
+	ErrStatementLineNotMatched   = errors.New("bank statement line is not matched")
+	ErrLedgerEntryNotFound       = errors.New("ledger entry not found on the statement's account")
+	ErrLedgerEntryAlreadyMatched = errors.New("ledger entry is already matched to another statement line")
+)
+
+// ReconciliationRules controls automatic matching. A ledger entry matches a
+// statement line when the direction agrees, the amounts differ by at most
+// AmountTolerance and the value dates by at most DateWindowDays.
+type ReconciliationRules struct {
+	DateWindowDays  int
+	AmountTolerance float64
+}
+
+func DefaultReconciliationRules() ReconciliationRules {
+	return ReconciliationRules{
+		DateWindowDays:  2,
+		AmountTolerance: 0.005,
+	}
+}
+
+// StatementImport is a bank statement file to import for a settlement account.
+type StatementImport struct {
+	AccountID  uuid.UUID
+	Format     string
+	File       []byte
+	CSVMapping bankstatement.CSVMapping
+	ImportedBy string
+}
+
+// ReconciliationReport summarises how far a statement is reconciled.
+// UnmatchedEntries are ledger entries of the statement period that no
+// statement line accounts for.
+type ReconciliationReport struct {
+	Statement        *model.BankStatement             `json:"statement"`
+	MatchedCount     int64                            `json:"matched_count"`
+	UnmatchedCount   int64                            `json:"unmatched_count"`
+	SuspiciousCount  int64                            `json:"suspicious_count"`
+	Lines            []model.BankStatementLine        `json:"lines"`
+	UnmatchedEntries []repository.ReconciliationEntry `json:"unmatched_entries"`
+}
+
+type ReconciliationService struct {
+	db            database.DBManager
+	accountRepo   *repository.AccountRepository
+	statementRepo *repository.BankStatementRepository
+	rules         ReconciliationRules
+}
+
+func NewReconciliationService(
+	db database.DBManager,
+	accountRepo *repository.AccountRepository,
+	statementRepo *repository.BankStatementRepository,
+	rules ReconciliationRules,
+) *ReconciliationService {
+	return &ReconciliationService{
+		db:            db,
+		accountRepo:   accountRepo,
+		statementRepo: statementRepo,
+		rules:         rules,
+	}
+}
+
+// Import stores every statement in the file and matches its lines against
+// the account's ledger. A file that was imported before is rejected.
+func (s *ReconciliationService) Import(request StatementImport) ([]model.BankStatement, error) {
+	account, err := s.accountRepo.FindByIDWithoutEntries(request.AccountID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	var parsed []bankstatement.Statement
+	switch request.Format {
+	case bankstatement.FormatCSV:
+		statement, err := bankstatement.ParseCSV(bytes.NewReader(request.File), request.CSVMapping)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV statement: %w", err)
+		}
+		parsed = append(parsed, *statement)
+	case bankstatement.FormatMT940:
+		if parsed, err = bankstatement.ParseMT940(bytes.NewReader(request.File)); err != nil {
+			return nil, fmt.Errorf("invalid MT940 statement: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported statement format %q", request.Format)
+	}
+
+	fileHash := sha256.Sum256(request.File)
+	statements := make([]model.BankStatement, 0, len(parsed))
+
+	for i, source := range parsed {
+		statement, err := newBankStatement(account, request, source)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %w", i+1, err)
+		}
+		statement.FileHash = fmt.Sprintf("%s:%d", hex.EncodeToString(fileHash[:]), i)
+		statements = append(statements, *statement)
+	}
+
+	err = s.db.WithTransaction(func(tx database.DB) error {
+		txStatementRepo := s.statementRepo.WithTx(tx)
+
+		for i := range statements {
+			exists, err := txStatementRepo.ExistsByHash(statements[i].FileHash)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return ErrStatementAlreadyImported
+			}
+
+			if err := txStatementRepo.Create(&statements[i]); err != nil {
+				return err
+			}
+
+			lines := make([]*model.BankStatementLine, len(statements[i].Lines))
+			for j := range statements[i].Lines {
+				lines[j] = &statements[i].Lines[j]
+			}
+			if err := s.matchLines(txStatementRepo, &statements[i], lines); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return statements, nil
+}
+
+func newBankStatement(account *model.Account, request StatementImport, source bankstatement.Statement) (*model.BankStatement, error) {
+	currency := account.CurrencyCode
+	if source.Currency != "" && source.Currency != currency {
+		return nil, fmt.Errorf("statement currency %s does not match account currency %s", source.Currency, currency)
+	}
+
+	now := time.Now()
+	statement := &model.BankStatement{
+		AccountID:      account.ID,
+		Format:         request.Format,
+		Reference:      source.Reference,
+		BankAccount:    source.Account,
+		CurrencyCode:   currency,
+		OpeningBalance: source.OpeningBalance,
+		ClosingBalance: source.ClosingBalance,
+		PeriodStart:    source.Date,
+		PeriodEnd:      source.Date,
+		LineCount:      len(source.Lines),
+		ImportedBy:     request.ImportedBy,
+		ImportedAt:     now,
+	}
+
+	for i, line := range source.Lines {
+		if i == 0 || line.ValueDate.Before(statement.PeriodStart) {
+			statement.PeriodStart = line.ValueDate
+		}
+		if i == 0 || line.ValueDate.After(statement.PeriodEnd) {
+			statement.PeriodEnd = line.ValueDate
+		}
+
+		statement.Lines = append(statement.Lines, model.BankStatementLine{
+			LineNumber:    line.Number,
+			BookingDate:   line.BookingDate,
+			ValueDate:     line.ValueDate,
+			Direction:     line.Direction,
+			Amount:        line.Amount,
+			Reference:     line.Reference,
+			BankReference: line.BankReference,
+			Description:   line.Description,
+			Status:        "unmatched",
+			UpdatedAt:     now,
+		})
+	}
+
+	if statement.PeriodStart.IsZero() {
+		return nil, errors.New("statement has neither lines nor a balance date")
+	}
+
+	return statement, nil
+}
+
+// Rematch runs automatic matching again over the statement's unmatched and
+// suspicious lines, e.g. after late ledger postings. Manual matches are kept.
+func (s *ReconciliationService) Rematch(statementID uuid.UUID) (*ReconciliationReport, error) {
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txStatementRepo := s.statementRepo.WithTx(tx)
+
+		statement, err := s.findStatement(txStatementRepo, statementID)
+		if err != nil {
+			return err
+		}
+
+		open, err := txStatementRepo.FindLines(statementID, "unmatched", "suspicious")
+		if err != nil {
+			return err
+		}
+
+		lines := make([]*model.BankStatementLine, len(open))
+		for i := range open {
+			lines[i] = &open[i]
+			resetMatch(lines[i])
+		}
+		return s.matchLines(txStatementRepo, statement, lines)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Report(statementID, "")
+}
+
+// matchLines pairs the lines with unreconciled ledger entries and saves
+// them. References are tried first for every line, so a reference match is
+// never taken away by an amount and date match on another line.
+func (s *ReconciliationService) matchLines(repo *repository.BankStatementRepository, statement *model.BankStatement, lines []*model.BankStatementLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	window := time.Duration(s.rules.DateWindowDays) * 24 * time.Hour
+	candidates, err := repo.FindUnreconciledEntries(statement.AccountID, statement.PeriodStart.Add(-window), statement.PeriodEnd.Add(window))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	used := make(map[uuid.UUID]bool)
+
+	match := func(line *model.BankStatementLine, entry *repository.ReconciliationEntry, method string) {
+		used[entry.EntryID] = true
+		line.Status = "matched"
+		line.MatchedEntryID = &entry.EntryID
+		line.MatchMethod = method
+		line.MatchedBy = systemActor
+		line.MatchedAt = &now
+	}
+
+	for _, line := range lines {
+		if line.Reference == "" {
+			continue
+		}
+
+		var referenced []*repository.ReconciliationEntry
+		for i := range candidates {
+			if referenceMatches(line, &candidates[i]) {
+				referenced = append(referenced, &candidates[i])
+			}
+		}
+		if len(referenced) == 0 {
+			continue
+		}
+
+		if entry := s.firstAgreeing(line, referenced, used); entry != nil {
+			match(line, entry, "reference")
+			continue
+		}
+
+		// The reference points at the ledger, but nothing there agrees with
+		// the line: the amount, date or direction was changed somewhere.
+		closest := referenced[0]
+		line.Status = "suspicious"
+		line.CandidateEntryID = &closest.EntryID
+		line.MatchNote = s.describeMismatch(line, closest, used[closest.EntryID])
+	}
+
+	for _, line := range lines {
+		if line.Status != "unmatched" {
+			continue
+		}
+
+		var agreeing []*repository.ReconciliationEntry
+		for i := range candidates {
+			if !used[candidates[i].EntryID] && s.agrees(line, &candidates[i]) {
+				agreeing = append(agreeing, &candidates[i])
+			}
+		}
+
+		switch len(agreeing) {
+		case 0:
+		case 1:
+			match(line, agreeing[0], "amount_date")
+		default:
+			line.Status = "suspicious"
+			line.CandidateEntryID = &agreeing[0].EntryID
+			line.MatchNote = fmt.Sprintf("%d ledger entries agree on amount and date; match manually", len(agreeing))
+		}
+	}
+
+	for _, line := range lines {
+		line.UpdatedAt = now
+		if err := repo.UpdateLine(line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ReconciliationService) firstAgreeing(line *model.BankStatementLine, entries []*repository.ReconciliationEntry, used map[uuid.UUID]bool) *repository.ReconciliationEntry {
+	for _, entry := range entries {
+		if !used[entry.EntryID] && s.agrees(line, entry) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// agrees compares direction, amount and value date. Money received on the
+// bank account is a credit on the settlement account.
+func (s *ReconciliationService) agrees(line *model.BankStatementLine, entry *repository.ReconciliationEntry) bool {
+	return line.Direction == entry.EntryType &&
+		math.Abs(line.Amount-entry.Amount) <= s.rules.AmountTolerance &&
+		daysApart(line.ValueDate, entry.ValueDate) <= s.rules.DateWindowDays
+}
+
+func (s *ReconciliationService) describeMismatch(line *model.BankStatementLine, entry *repository.ReconciliationEntry, used bool) string {
+	var problems []string
+	if used {
+		problems = append(problems, "its ledger entry is already matched to another line")
+	}
+	if line.Direction != entry.EntryType {
+		problems = append(problems, fmt.Sprintf("direction %s differs from ledger %s", line.Direction, entry.EntryType))
+	}
+	if math.Abs(line.Amount-entry.Amount) > s.rules.AmountTolerance {
+		problems = append(problems, fmt.Sprintf("amount %.2f differs from ledger %.2f", line.Amount, entry.Amount))
+	}
+	if days := daysApart(line.ValueDate, entry.ValueDate); days > s.rules.DateWindowDays {
+		problems = append(problems, fmt.Sprintf("value date is %d days from ledger", days))
+	}
+	return fmt.Sprintf("Reference %s: %s", entry.Reference, strings.Join(problems, "; "))
+}
+
+// referenceMatches reports whether the line carries the entry's transaction
+// reference, either as its own reference or inside it.
+func referenceMatches(line *model.BankStatementLine, entry *repository.ReconciliationEntry) bool {
+	if entry.Reference == "" {
+		return false
+	}
+	return strings.Contains(strings.ToUpper(line.Reference), strings.ToUpper(entry.Reference))
+}
+
+func daysApart(a, b time.Time) int {
+	a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(a.Sub(b).Hours() / 24)
+	if days < 0 {
+		return -days
+	}
+	return days
+}
+
+func resetMatch(line *model.BankStatementLine) {
+	line.Status = "unmatched"
+	line.MatchedEntryID = nil
+	line.CandidateEntryID = nil
+	line.MatchMethod = ""
+	line.MatchNote = ""
+	line.MatchedBy = ""
+	line.MatchedAt = nil
+}
+
+// MatchLine matches a line with a ledger entry by hand. The amounts and
+// dates do not have to agree; the note should say why they differ.
+func (s *ReconciliationService) MatchLine(lineID, entryID uuid.UUID, note, actor string) (*model.BankStatementLine, error) {
+	var line *model.BankStatementLine
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txStatementRepo := s.statementRepo.WithTx(tx)
+
+		var err error
+		if line, err = s.findLine(txStatementRepo, lineID); err != nil {
+			return err
+		}
+		if line.Status == "matched" {
+			return ErrStatementLineMatched
+		}
+
+		statement, err := s.findStatement(txStatementRepo, line.StatementID)
+		if err != nil {
+			return err
+		}
+
+		entry, err := txStatementRepo.FindEntry(statement.AccountID, entryID)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			return ErrLedgerEntryNotFound
+		}
+
+		matched, err := txStatementRepo.FindLineByMatchedEntry(entryID)
+		if err != nil {
+			return err
+		}
+		if matched != nil {
+			return fmt.Errorf("%w: line %d of statement %s", ErrLedgerEntryAlreadyMatched, matched.LineNumber, matched.StatementID)
+		}
+
+		now := time.Now()
+		resetMatch(line)
+		line.Status = "matched"
+		line.MatchedEntryID = &entry.EntryID
+		line.MatchMethod = "manual"
+		line.MatchNote = note
+		line.MatchedBy = actor
+		line.MatchedAt = &now
+		line.UpdatedAt = now
+		return txStatementRepo.UpdateLine(line)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// UnmatchLine releases the ledger entry of a matched line. The line is left
+// unmatched until it is matched again by hand or by Rematch.
+func (s *ReconciliationService) UnmatchLine(lineID uuid.UUID, note, actor string) (*model.BankStatementLine, error) {
+	var line *model.BankStatementLine
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txStatementRepo := s.statementRepo.WithTx(tx)
+
+		var err error
+		if line, err = s.findLine(txStatementRepo, lineID); err != nil {
+			return err
+		}
+		if line.Status != "matched" {
+			return ErrStatementLineNotMatched
+		}
+
+		now := time.Now()
+		resetMatch(line)
+		line.MatchNote = "Unmatched: " + note
+		line.MatchedBy = actor
+		line.MatchedAt = &now
+		line.UpdatedAt = now
+		return txStatementRepo.UpdateLine(line)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// Report returns the reconciliation state of a statement, listing only the
+// lines in the given status if one is set.
+func (s *ReconciliationService) Report(statementID uuid.UUID, status string) (*ReconciliationReport, error) {
+	statement, err := s.findStatement(s.statementRepo, statementID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReconciliationReport{Statement: statement}
+
+	counts, err := s.statementRepo.CountLinesByStatus(statementID)
+	if err != nil {
+		return nil, err
+	}
+	for _, count := range counts {
+		switch count.Status {
+		case "matched":
+			report.MatchedCount = count.Count
+		case "unmatched":
+			report.UnmatchedCount = count.Count
+		case "suspicious":
+			report.SuspiciousCount = count.Count
+		}
+	}
+
+	var statuses []string
+	if status != "" {
+		statuses = append(statuses, status)
+	}
+	if report.Lines, err = s.statementRepo.FindLines(statementID, statuses...); err != nil {
+		return nil, err
+	}
+
+	if report.UnmatchedEntries, err = s.statementRepo.FindUnreconciledEntries(statement.AccountID, statement.PeriodStart, statement.PeriodEnd); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *ReconciliationService) ListStatements(accountID *uuid.UUID, limit int) ([]model.BankStatement, error) {
+	return s.statementRepo.Find(accountID, limit)
+}
+
+func (s *ReconciliationService) findStatement(repo *repository.BankStatementRepository, statementID uuid.UUID) (*model.BankStatement, error) {
+	statement, err := repo.FindByID(statementID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrStatementNotFound
+		}
+		return nil, err
+	}
+	return statement, nil
+}
+
+func (s *ReconciliationService) findLine(repo *repository.BankStatementRepository, lineID uuid.UUID) (*model.BankStatementLine, error) {
+	line, err := repo.FindLineByID(lineID, true)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrStatementLineNotFound
+		}
+		return nil, err
+	}
+	return line, nil
+}
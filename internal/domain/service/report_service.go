@@ -0,0 +1,194 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"paygo/internal/domain/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TrialBalanceAccount struct {
+	AccountID     uuid.UUID `json:"account_id"`
+	AccountNumber string    `json:"account_number"`
+	AccountType   string    `json:"account_type"`
+	CurrencyCode  string    `json:"currency_code"`
+	EntryCount    int64     `json:"entry_count"`
+	TotalDebits   float64   `json:"total_debits"`
+	TotalCredits  float64   `json:"total_credits"`
+	// Net is credits minus debits, i.e. the ledger balance of the account.
+	Net float64 `json:"net"`
+}
+
+type TrialBalanceCurrency struct {
+	CurrencyCode string  `json:"currency_code"`
+	AccountCount int64   `json:"account_count"`
+	EntryCount   int64   `json:"entry_count"`
+	TotalDebits  float64 `json:"total_debits"`
+	TotalCredits float64 `json:"total_credits"`
+	Net          float64 `json:"net"`
+	Balanced     bool    `json:"balanced"`
+}
+
+// TrialBalance proves the double-entry invariant: in every currency the debits
+// posted must equal the credits posted. Currencies that do not net to zero are
+// listed in UnbalancedCurrencies.
+type TrialBalance struct {
+	AsOf                 time.Time              `json:"as_of"`
+	Balanced             bool                   `json:"balanced"`
+	UnbalancedCurrencies []string               `json:"unbalanced_currencies"`
+	Currencies           []TrialBalanceCurrency `json:"currencies"`
+	Accounts             []TrialBalanceAccount  `json:"accounts"`
+}
+
+type GeneralLedgerAccount struct {
+	AccountID      uuid.UUID `json:"account_id"`
+	AccountNumber  string    `json:"account_number"`
+	AccountType    string    `json:"account_type"`
+	CurrencyCode   string    `json:"currency_code"`
+	OpeningBalance float64   `json:"opening_balance"`
+	EntryCount     int64     `json:"entry_count"`
+	TotalDebits    float64   `json:"total_debits"`
+	TotalCredits   float64   `json:"total_credits"`
+	ClosingBalance float64   `json:"closing_balance"`
+}
+
+// GeneralLedgerWriter renders a general ledger as it is generated. Each
+// WriteAccount starts an account; the lines written after it are its entries
+// in booking order.
+type GeneralLedgerWriter interface {
+	WriteHeader(from, to time.Time) error
+	WriteAccount(account GeneralLedgerAccount) error
+	WriteLine(line *repository.GeneralLedgerLine) error
+	WriteFooter() error
+}
+
+type ReportService struct {
+	reportRepo *repository.ReportRepository
+}
+
+func NewReportService(reportRepo *repository.ReportRepository) *ReportService {
+	return &ReportService{
+		reportRepo: reportRepo,
+	}
+}
+
+func (s *ReportService) TrialBalance(asOf time.Time) (*TrialBalance, error) {
+	accountTotals, err := s.reportRepo.AccountTotalsAsOf(asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate account totals: %w", err)
+	}
+
+	currencyTotals, err := s.reportRepo.CurrencyTotalsAsOf(asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate currency totals: %w", err)
+	}
+
+	report := &TrialBalance{
+		AsOf:                 asOf,
+		Balanced:             true,
+		UnbalancedCurrencies: []string{},
+		Currencies:           make([]TrialBalanceCurrency, 0, len(currencyTotals)),
+		Accounts:             make([]TrialBalanceAccount, 0, len(accountTotals)),
+	}
+
+	for _, t := range currencyTotals {
+		currency := TrialBalanceCurrency{
+			CurrencyCode: t.CurrencyCode,
+			AccountCount: t.AccountCount,
+			EntryCount:   t.EntryCount,
+			TotalDebits:  roundAmount(t.TotalDebits),
+			TotalCredits: roundAmount(t.TotalCredits),
+			Net:          roundAmount(t.TotalCredits - t.TotalDebits),
+		}
+		// Entries on missing accounts have no currency and can never be balanced.
+		currency.Balanced = currency.Net == 0 && t.CurrencyCode != ""
+
+		if !currency.Balanced {
+			report.Balanced = false
+			report.UnbalancedCurrencies = append(report.UnbalancedCurrencies, t.CurrencyCode)
+		}
+		report.Currencies = append(report.Currencies, currency)
+	}
+
+	for _, t := range accountTotals {
+		report.Accounts = append(report.Accounts, TrialBalanceAccount{
+			AccountID:     t.AccountID,
+			AccountNumber: t.AccountNumber,
+			AccountType:   t.AccountType,
+			CurrencyCode:  t.CurrencyCode,
+			EntryCount:    t.EntryCount,
+			TotalDebits:   roundAmount(t.TotalDebits),
+			TotalCredits:  roundAmount(t.TotalCredits),
+			Net:           roundAmount(t.TotalCredits - t.TotalDebits),
+		})
+	}
+
+	return report, nil
+}
+
+// PrepareGeneralLedger aggregates the opening balance and totals of every
+// account in the report. It is separate from WriteGeneralLedger so callers can
+// report errors before any output is written.
+func (s *ReportService) PrepareGeneralLedger(filter repository.GeneralLedgerFilter) ([]GeneralLedgerAccount, error) {
+	if !filter.From.Before(filter.To) {
+		return nil, errors.New("report period start must be before its end")
+	}
+
+	totals, err := s.reportRepo.AccountTotalsBetween(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate account totals: %w", err)
+	}
+
+	accounts := make([]GeneralLedgerAccount, 0, len(totals))
+	for _, t := range totals {
+		accounts = append(accounts, GeneralLedgerAccount{
+			AccountID:      t.AccountID,
+			AccountNumber:  t.AccountNumber,
+			AccountType:    t.AccountType,
+			CurrencyCode:   t.CurrencyCode,
+			OpeningBalance: roundAmount(t.OpeningBalance),
+			EntryCount:     t.EntryCount,
+			TotalDebits:    roundAmount(t.TotalDebits),
+			TotalCredits:   roundAmount(t.TotalCredits),
+			ClosingBalance: roundAmount(t.OpeningBalance + t.TotalCredits - t.TotalDebits),
+		})
+	}
+
+	return accounts, nil
+}
+
+// WriteGeneralLedger streams the entries of the prepared accounts to w. The
+// entries arrive in account order, so only the current account is held.
+func (s *ReportService) WriteGeneralLedger(filter repository.GeneralLedgerFilter, accounts []GeneralLedgerAccount, w GeneralLedgerWriter) error {
+	if err := w.WriteHeader(filter.From, filter.To); err != nil {
+		return err
+	}
+
+	next := 0
+	err := s.reportRepo.StreamEntriesBetween(filter, func(line *repository.GeneralLedgerLine) error {
+		// Accounts without entries in the period are written as they are passed.
+		for next == 0 || accounts[next-1].AccountID != line.AccountID {
+			if next == len(accounts) {
+				return fmt.Errorf("entry %s is on account %s, which is not in the report", line.EntryID, line.AccountID)
+			}
+			if err := w.WriteAccount(accounts[next]); err != nil {
+				return err
+			}
+			next++
+		}
+		return w.WriteLine(line)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream ledger entries: %w", err)
+	}
+
+	for ; next < len(accounts); next++ {
+		if err := w.WriteAccount(accounts[next]); err != nil {
+			return err
+		}
+	}
+
+	return w.WriteFooter()
+}
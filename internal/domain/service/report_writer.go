@@ -0,0 +1,55 @@
+package service
+
+import (
+	"io"
+	"paygo/internal/domain/repository"
+	"time"
+)
+
+// JSONGeneralLedgerWriter writes a general ledger as a single JSON document of
+// the form {"from": from, "to": to, "accounts": [{"account": account,
+// "entries": [...]}, ...]}, one entry at a time.
+type JSONGeneralLedgerWriter struct {
+	w        io.Writer
+	accounts int
+	entries  int
+}
+
+func NewJSONGeneralLedgerWriter(w io.Writer) *JSONGeneralLedgerWriter {
+	return &JSONGeneralLedgerWriter{w: w}
+}
+
+func (j *JSONGeneralLedgerWriter) WriteHeader(from, to time.Time) error {
+	if err := writeJSONField(j.w, `{"from":`, from, ""); err != nil {
+		return err
+	}
+	return writeJSONField(j.w, `,"to":`, to, `,"accounts":[`)
+}
+
+func (j *JSONGeneralLedgerWriter) WriteAccount(account GeneralLedgerAccount) error {
+	prefix := `{"account":`
+	if j.accounts > 0 {
+		prefix = `]},` + prefix
+	}
+	j.accounts++
+	j.entries = 0
+	return writeJSONField(j.w, prefix, account, `,"entries":[`)
+}
+
+func (j *JSONGeneralLedgerWriter) WriteLine(line *repository.GeneralLedgerLine) error {
+	prefix := ","
+	if j.entries == 0 {
+		prefix = ""
+	}
+	j.entries++
+	return writeJSONField(j.w, prefix, line, "")
+}
+
+func (j *JSONGeneralLedgerWriter) WriteFooter() error {
+	suffix := "]}\n"
+	if j.accounts > 0 {
+		suffix = "]}" + suffix
+	}
+	_, err := io.WriteString(j.w, suffix)
+	return err
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/database"
+	"time"
+)
+
+type RiskDecision string
+
+const (
+	RiskAllow  RiskDecision = "allow"
+	RiskReview RiskDecision = "review"
+	RiskDeny   RiskDecision = "deny"
+)
+
+type RiskAssessment struct {
+	Score    int          `json:"score"`
+	Decision RiskDecision `json:"decision"`
+	Reasons  []string     `json:"reasons"`
+}
+
+// RiskScorer is consulted by TransferService before any balance changes. It
+// runs inside the transfer's database transaction, with both accounts locked.
+type RiskScorer interface {
+	Score(tx database.DB, from, to *model.Account, amount float64, at time.Time) (*RiskAssessment, error)
+}
+
+// RiskRules configures RuleRiskScorer. Each matching rule adds its weight to
+// the score; the score decides between allow, review and deny.
+type RiskRules struct {
+	ReviewThreshold int
+	DenyThreshold   int
+
+	// A payment of at least LargeAmount to a beneficiary the source has not
+	// paid within HistoryWindow.
+	LargeAmount          float64
+	NewBeneficiaryWeight int
+
+	// More than RapidCount debits from the source within RapidWindow.
+	RapidCount  int
+	RapidWindow time.Duration
+	RapidWeight int
+
+	// Transfers booked between UnusualHourStart and UnusualHourEnd, UTC.
+	UnusualHourStart  int
+	UnusualHourEnd    int
+	UnusualHourWeight int
+
+	// More than HistoryMultiple times the average debit over HistoryWindow,
+	// once the account has at least HistoryMinDebits debits in it.
+	HistoryWindow    time.Duration
+	HistoryMinDebits int64
+	HistoryMultiple  float64
+	HistoryWeight    int
+}
+
+func DefaultRiskRules() RiskRules {
+	return RiskRules{
+		ReviewThreshold:      50,
+		DenyThreshold:        80,
+		LargeAmount:          1000,
+		NewBeneficiaryWeight: 40,
+		RapidCount:           3,
+		RapidWindow:          10 * time.Minute,
+		RapidWeight:          30,
+		UnusualHourStart:     0,
+		UnusualHourEnd:       5,
+		UnusualHourWeight:    15,
+		HistoryWindow:        90 * 24 * time.Hour,
+		HistoryMinDebits:     3,
+		HistoryMultiple:      5,
+		HistoryWeight:        35,
+	}
+}
+
+type RuleRiskScorer struct {
+	ledgerRepo *repository.LedgerRepository
+	rules      RiskRules
+}
+
+func NewRuleRiskScorer(ledgerRepo *repository.LedgerRepository, rules RiskRules) *RuleRiskScorer {
+	return &RuleRiskScorer{
+		ledgerRepo: ledgerRepo,
+		rules:      rules,
+	}
+}
+
+func (s *RuleRiskScorer) Score(tx database.DB, from, to *model.Account, amount float64, at time.Time) (*RiskAssessment, error) {
+	rules := s.rules
+	ledgerRepo := s.ledgerRepo.WithTx(tx)
+	assessment := &RiskAssessment{Reasons: []string{}}
+
+	add := func(weight int, format string, args ...any) {
+		assessment.Score += weight
+		assessment.Reasons = append(assessment.Reasons, fmt.Sprintf(format, args...))
+	}
+
+	if amount >= rules.LargeAmount {
+		paid, err := ledgerRepo.HasPaidSince(from.ID, to.ID, at.Add(-rules.HistoryWindow))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check beneficiary history: %w", err)
+		}
+		if !paid {
+			add(rules.NewBeneficiaryWeight, "payment of %.2f to beneficiary %s not paid in the last %v", amount, to.AccountNumber, rules.HistoryWindow)
+		}
+	}
+
+	recent, err := ledgerRepo.DebitStatsSince(from.ID, at.Add(-rules.RapidWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check recent debits: %w", err)
+	}
+	if recent.Count >= int64(rules.RapidCount) {
+		add(rules.RapidWeight, "%d debits in the last %v", recent.Count, rules.RapidWindow)
+	}
+
+	if hour := at.UTC().Hour(); hour >= rules.UnusualHourStart && hour < rules.UnusualHourEnd {
+		add(rules.UnusualHourWeight, "booked at %02d:00 UTC", hour)
+	}
+
+	history, err := ledgerRepo.DebitStatsSince(from.ID, at.Add(-rules.HistoryWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check debit history: %w", err)
+	}
+	if history.Count >= rules.HistoryMinDebits && amount > history.Average*rules.HistoryMultiple {
+		add(rules.HistoryWeight, "amount is %.1fx the average debit of %.2f", amount/history.Average, history.Average)
+	}
+
+	assessment.Score = min(assessment.Score, 100)
+	switch {
+	case assessment.Score >= rules.DenyThreshold:
+		assessment.Decision = RiskDeny
+	case assessment.Score >= rules.ReviewThreshold:
+		assessment.Decision = RiskReview
+	default:
+		assessment.Decision = RiskAllow
+	}
+
+	return assessment, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"encoding/csv"
+	"io"
+	"paygo/internal/domain/model"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var sarCSVColumns = []string{
+	"report_reference", "report_status", "rules", "transaction_id", "transaction_reference",
+	"transaction_type", "status", "amount", "currency", "created_at", "description",
+	"source_account", "source_user_id", "source_user_name", "source_user_email",
+	"destination_account", "destination_user_id", "destination_user_name", "destination_user_email",
+}
+
+// WriteSARCSV writes a report export as CSV with one row per transaction,
+// listing the rules that flagged it and both parties.
+func WriteSARCSV(w io.Writer, export *SARExport) error {
+	accounts := make(map[uuid.UUID]model.Account, len(export.Accounts))
+	for _, account := range export.Accounts {
+		accounts[account.ID] = account
+	}
+	users := make(map[uuid.UUID]model.User, len(export.Users))
+	for _, user := range export.Users {
+		users[user.ID] = user
+	}
+	rules := make(map[string][]string)
+	for _, alert := range export.Report.Alerts {
+		for _, id := range alert.TransactionIDs {
+			if !slices.Contains(rules[id], alert.Rule) {
+				rules[id] = append(rules[id], alert.Rule)
+			}
+		}
+	}
+
+	party := func(accountID *uuid.UUID) []string {
+		if accountID == nil {
+			return []string{"", "", "", ""}
+		}
+		account, ok := accounts[*accountID]
+		if !ok {
+			return []string{accountID.String(), "", "", ""}
+		}
+		user := users[account.UserID]
+		return []string{
+			account.AccountNumber,
+			account.UserID.String(),
+			strings.TrimSpace(user.FirstName + " " + user.LastName),
+			user.Email,
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(sarCSVColumns); err != nil {
+		return err
+	}
+	for _, transaction := range export.Transactions {
+		row := []string{
+			export.Report.Reference,
+			export.Report.Status,
+			strings.Join(rules[transaction.ID.String()], ";"),
+			transaction.ID.String(),
+			transaction.TransactionReference,
+			transaction.TransactionType,
+			transaction.Status,
+			formatAmount(transaction.Amount),
+			transaction.CurrencyCode,
+			formatTimestamp(transaction.CreatedAt),
+			transaction.Description,
+		}
+		row = append(row, party(transaction.SourceAccountID)...)
+		row = append(row, party(transaction.DestinationAccountID)...)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"paygo/internal/domain/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StatementHeader struct {
+	AccountID      uuid.UUID `json:"account_id"`
+	AccountNumber  string    `json:"account_number"`
+	CurrencyCode   string    `json:"currency_code"`
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	OpeningBalance float64   `json:"opening_balance"`
+	GeneratedAt    time.Time `json:"generated_at"`
+}
+
+type StatementSummary struct {
+	EntryCount     int     `json:"entry_count"`
+	DebitCount     int     `json:"debit_count"`
+	CreditCount    int     `json:"credit_count"`
+	TotalDebits    float64 `json:"total_debits"`
+	TotalCredits   float64 `json:"total_credits"`
+	ClosingBalance float64 `json:"closing_balance"`
+}
+
+// StatementWriter renders a statement as it is generated. WriteLine is called
+// once per ledger entry in booking order, between WriteHeader and WriteSummary.
+type StatementWriter interface {
+	WriteHeader(header StatementHeader) error
+	WriteLine(line *repository.StatementLine) error
+	WriteSummary(summary StatementSummary) error
+}
+
+type StatementService struct {
+	accountRepo    *repository.AccountRepository
+	ledgerRepo     *repository.LedgerRepository
+	checkpointRepo *repository.CheckpointRepository
+}
+
+func NewStatementService(
+	accountRepo *repository.AccountRepository,
+	ledgerRepo *repository.LedgerRepository,
+	checkpointRepo *repository.CheckpointRepository,
+) *StatementService {
+	return &StatementService{
+		accountRepo:    accountRepo,
+		ledgerRepo:     ledgerRepo,
+		checkpointRepo: checkpointRepo,
+	}
+}
+
+// PrepareStatement resolves the account and the opening balance of the period
+// [from, to). It is separate from WriteStatement so callers can report errors
+// before any output is written.
+func (s *StatementService) PrepareStatement(accountID uuid.UUID, from, to time.Time) (*StatementHeader, error) {
+	if !from.Before(to) {
+		return nil, errors.New("statement period start must be before its end")
+	}
+
+	account, err := s.accountRepo.FindByIDWithoutEntries(accountID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
+	}
+
+	openingBalance, err := s.OpeningBalance(accountID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StatementHeader{
+		AccountID:      account.ID,
+		AccountNumber:  account.AccountNumber,
+		CurrencyCode:   account.CurrencyCode,
+		From:           from,
+		To:             to,
+		OpeningBalance: openingBalance,
+		GeneratedAt:    time.Now(),
+	}, nil
+}
+
+// WriteStatement streams the statement lines of a prepared statement to w.
+func (s *StatementService) WriteStatement(header *StatementHeader, w StatementWriter) error {
+	if err := w.WriteHeader(*header); err != nil {
+		return err
+	}
+
+	summary := StatementSummary{}
+	err := s.ledgerRepo.StreamStatementLines(header.AccountID, header.From, header.To, func(line *repository.StatementLine) error {
+		summary.EntryCount++
+		if line.EntryType == "credit" {
+			summary.CreditCount++
+			summary.TotalCredits += line.Amount
+		} else if line.EntryType == "debit" {
+			summary.DebitCount++
+			summary.TotalDebits += line.Amount
+		}
+		return w.WriteLine(line)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream statement lines: %w", err)
+	}
+
+	summary.TotalDebits = roundAmount(summary.TotalDebits)
+	summary.TotalCredits = roundAmount(summary.TotalCredits)
+	summary.ClosingBalance = roundAmount(header.OpeningBalance + summary.TotalCredits - summary.TotalDebits)
+
+	return w.WriteSummary(summary)
+}
+
+// SummarizeStatement computes the statement totals with a single aggregate
+// query, for formats that need them before the entries.
+func (s *StatementService) SummarizeStatement(header *StatementHeader) (*StatementSummary, error) {
+	totals, err := s.ledgerRepo.TotalsBetween(header.AccountID, header.From, header.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute statement totals: %w", err)
+	}
+
+	summary := &StatementSummary{
+		EntryCount:   totals.DebitCount + totals.CreditCount,
+		DebitCount:   totals.DebitCount,
+		CreditCount:  totals.CreditCount,
+		TotalDebits:  roundAmount(totals.DebitTotal),
+		TotalCredits: roundAmount(totals.CreditTotal),
+	}
+	summary.ClosingBalance = roundAmount(header.OpeningBalance + summary.TotalCredits - summary.TotalDebits)
+
+	return summary, nil
+}
+
+// OpeningBalance returns the balance of the account just before at. It starts
+// from the latest verified checkpoint before at and only sums the entries
+// booked after it.
+func (s *StatementService) OpeningBalance(accountID uuid.UUID, at time.Time) (float64, error) {
+	checkpoint, err := s.checkpointRepo.FindLatestVerifiedBefore(accountID, at)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch balance checkpoint: %w", err)
+	}
+
+	var after time.Time
+	balance := 0.0
+	if checkpoint != nil {
+		after = checkpoint.CoveredUntil
+		balance = checkpoint.Balance
+	}
+
+	sum, err := s.ledgerRepo.SumBetween(accountID, after, at)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute opening balance: %w", err)
+	}
+
+	return roundAmount(balance + sum), nil
+}
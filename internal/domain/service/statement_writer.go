@@ -0,0 +1,131 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"paygo/internal/domain/repository"
+	"strconv"
+	"time"
+)
+
+// JSONStatementWriter writes a statement as a single JSON document of the form
+// {"statement": header, "entries": [...], "summary": summary}, one entry at a time.
+type JSONStatementWriter struct {
+	w       io.Writer
+	entries int
+}
+
+func NewJSONStatementWriter(w io.Writer) *JSONStatementWriter {
+	return &JSONStatementWriter{w: w}
+}
+
+func (j *JSONStatementWriter) WriteHeader(header StatementHeader) error {
+	return j.writeField(`{"statement":`, header, `,"entries":[`)
+}
+
+func (j *JSONStatementWriter) WriteLine(line *repository.StatementLine) error {
+	prefix := ","
+	if j.entries == 0 {
+		prefix = ""
+	}
+	j.entries++
+	return j.writeField(prefix, line, "")
+}
+
+func (j *JSONStatementWriter) WriteSummary(summary StatementSummary) error {
+	return j.writeField(`],"summary":`, summary, "}\n")
+}
+
+func (j *JSONStatementWriter) writeField(prefix string, value any, suffix string) error {
+	return writeJSONField(j.w, prefix, value, suffix)
+}
+
+// writeJSONField writes value as JSON between prefix and suffix, for writers
+// that assemble a document piece by piece.
+func writeJSONField(w io.Writer, prefix string, value any, suffix string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, prefix); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, suffix)
+	return err
+}
+
+var statementCSVColumns = []string{
+	"booked_at", "value_date", "reference", "transaction_type", "description",
+	"counterparty_account", "debit", "credit", "running_balance",
+}
+
+// CSVStatementWriter writes a statement as CSV. The opening balance is the
+// first row after the column header and the totals and closing balance are the
+// last rows, with the label in the description column.
+type CSVStatementWriter struct {
+	w      *csv.Writer
+	header StatementHeader
+}
+
+func NewCSVStatementWriter(w io.Writer) *CSVStatementWriter {
+	return &CSVStatementWriter{w: csv.NewWriter(w)}
+}
+
+func (c *CSVStatementWriter) WriteHeader(header StatementHeader) error {
+	c.header = header
+	if err := c.w.Write(statementCSVColumns); err != nil {
+		return err
+	}
+	return c.w.Write([]string{
+		formatTimestamp(header.From), "", "", "", "Opening balance", "", "", "", formatAmount(header.OpeningBalance),
+	})
+}
+
+func (c *CSVStatementWriter) WriteLine(line *repository.StatementLine) error {
+	debit, credit := "", ""
+	if line.EntryType == "debit" {
+		debit = formatAmount(line.Amount)
+	} else {
+		credit = formatAmount(line.Amount)
+	}
+
+	valueDate := ""
+	if line.ValueDate != nil {
+		valueDate = line.ValueDate.Format(time.DateOnly)
+	}
+
+	return c.w.Write([]string{
+		formatTimestamp(line.BookedAt),
+		valueDate,
+		line.Reference,
+		line.TransactionType,
+		line.Description,
+		line.CounterpartyAccountNumber,
+		debit,
+		credit,
+		formatAmount(line.RunningBalance),
+	})
+}
+
+func (c *CSVStatementWriter) WriteSummary(summary StatementSummary) error {
+	rows := [][]string{
+		{"", "", "", "", "Total", "", formatAmount(summary.TotalDebits), formatAmount(summary.TotalCredits), ""},
+		{formatTimestamp(c.header.To), "", "", "", "Closing balance", "", "", "", formatAmount(summary.ClosingBalance)},
+	}
+	if err := c.w.WriteAll(rows); err != nil {
+		return err
+	}
+	return c.w.Error()
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 4, 64)
+}
+
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
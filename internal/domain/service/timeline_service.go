@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"paygo/internal/domain/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimelineEntry is a replayed ledger entry with the other legs of its
+// transaction. Drift is the stored running balance minus the replayed one.
+type TimelineEntry struct {
+	repository.ReplayedEntry
+	Drift          float64                   `json:"drift"`
+	Diverged       bool                      `json:"diverged"`
+	Counterparties []repository.Counterparty `json:"counterparties"`
+}
+
+// AccountTimeline replays an account's ledger from its first entry to find
+// where a balance discrepancy started.
+type AccountTimeline struct {
+	AccountID          uuid.UUID `json:"account_id"`
+	AccountNumber      string    `json:"account_number"`
+	Balance            float64   `json:"balance"`
+	LedgerBalance      float64   `json:"ledger_balance"`
+	BalanceDiscrepancy float64   `json:"balance_discrepancy"`
+	LastRunningBalance *float64  `json:"last_running_balance,omitempty"`
+	EntryCount         int64     `json:"entry_count"`
+	// Conclusion says in one sentence what the replay found.
+	Conclusion      string          `json:"conclusion"`
+	FirstDivergence *TimelineEntry  `json:"first_divergence,omitempty"`
+	Entries         []TimelineEntry `json:"entries"`
+}
+
+// TimelineWindow selects the entries a timeline returns. With From set, it
+// returns Limit entries starting at that position. Otherwise it returns
+// Context entries on either side of the first divergence, or the last
+// 2*Context+1 entries if there is none.
+type TimelineWindow struct {
+	From    int64
+	Limit   int
+	Context int
+}
+
+type TimelineService struct {
+	accountRepo *repository.AccountRepository
+	ledgerRepo  *repository.LedgerRepository
+}
+
+func NewTimelineService(accountRepo *repository.AccountRepository, ledgerRepo *repository.LedgerRepository) *TimelineService {
+	return &TimelineService{
+		accountRepo: accountRepo,
+		ledgerRepo:  ledgerRepo,
+	}
+}
+
+// Timeline replays the account's entries in booking order and compares each
+// replayed balance with the stored running balance. Checkpoints are ignored
+// on purpose: the replay must not trust anything the ledger itself recorded.
+func (s *TimelineService) Timeline(ctx context.Context, accountID uuid.UUID, window TimelineWindow) (*AccountTimeline, error) {
+	accountRepo := s.accountRepo.WithContext(ctx)
+	ledgerRepo := s.ledgerRepo.WithContext(ctx)
+
+	account, err := accountRepo.FindByIDWithoutEntries(accountID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	summary, err := ledgerRepo.Summarize(accountID, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize ledger entries: %w", err)
+	}
+
+	ledgerBalance := roundAmount(summary.Net)
+	timeline := &AccountTimeline{
+		AccountID:          account.ID,
+		AccountNumber:      account.AccountNumber,
+		Balance:            account.Balance,
+		LedgerBalance:      ledgerBalance,
+		BalanceDiscrepancy: roundAmount(account.Balance - ledgerBalance),
+		LastRunningBalance: summary.LastRunningBalance,
+		EntryCount:         summary.EntryCount,
+		Entries:            []TimelineEntry{},
+	}
+
+	divergence, err := ledgerRepo.FindFirstRunningBalanceBreak(accountID, time.Time{}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay running balances: %w", err)
+	}
+
+	from, to := window.From, window.From+int64(window.Limit)-1
+	if window.From <= 0 {
+		around := summary.EntryCount - int64(window.Context)
+		if divergence != nil {
+			around = divergence.Position
+		}
+		from, to = around-int64(window.Context), around+int64(window.Context)
+	}
+
+	if to >= max(from, 1) {
+		timeline.Entries, err = s.entries(ledgerRepo, accountID, max(from, 1), to)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if divergence != nil {
+		for i := range timeline.Entries {
+			if timeline.Entries[i].Position == divergence.Position {
+				timeline.FirstDivergence = &timeline.Entries[i]
+			}
+		}
+		if timeline.FirstDivergence == nil {
+			entries, err := s.entries(ledgerRepo, accountID, divergence.Position, divergence.Position)
+			if err != nil {
+				return nil, err
+			}
+			if len(entries) > 0 {
+				timeline.FirstDivergence = &entries[0]
+			}
+		}
+	}
+
+	timeline.Conclusion = concludeTimeline(timeline, divergence)
+	return timeline, nil
+}
+
+func (s *TimelineService) entries(ledgerRepo *repository.LedgerRepository, accountID uuid.UUID, from, to int64) ([]TimelineEntry, error) {
+	replayed, err := ledgerRepo.ReplayEntries(accountID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay ledger entries: %w", err)
+	}
+
+	transactionIDs := make([]uuid.UUID, 0, len(replayed))
+	for _, entry := range replayed {
+		transactionIDs = append(transactionIDs, entry.TransactionID)
+	}
+	counterparties, err := ledgerRepo.FindCounterparties(transactionIDs, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch counterparties: %w", err)
+	}
+	byTransaction := make(map[uuid.UUID][]repository.Counterparty)
+	for _, counterparty := range counterparties {
+		byTransaction[counterparty.TransactionID] = append(byTransaction[counterparty.TransactionID], counterparty)
+	}
+
+	entries := make([]TimelineEntry, 0, len(replayed))
+	for _, entry := range replayed {
+		drift := roundAmount(entry.RunningBalance - entry.ReplayedBalance)
+		parties := byTransaction[entry.TransactionID]
+		if parties == nil {
+			parties = []repository.Counterparty{}
+		}
+		entries = append(entries, TimelineEntry{
+			ReplayedEntry:  entry,
+			Drift:          drift,
+			Diverged:       drift != 0,
+			Counterparties: parties,
+		})
+	}
+	return entries, nil
+}
+
+func concludeTimeline(timeline *AccountTimeline, divergence *repository.RunningBalanceBreak) string {
+	switch {
+	case divergence != nil:
+		return fmt.Sprintf(
+			"Running balances diverge from the replay at entry #%d, booked %s: recorded=%.4f, replayed=%.4f",
+			divergence.Position, divergence.CreatedAt.Format(time.RFC3339Nano), divergence.RunningBalance, divergence.Expected,
+		)
+	case timeline.BalanceDiscrepancy != 0:
+		return fmt.Sprintf(
+			"Every running balance matches the replay, but the stored balance differs from the ledger by %.4f: it was changed without a ledger entry",
+			timeline.BalanceDiscrepancy,
+		)
+	}
+	return "Every running balance matches the replay and the stored balance matches the ledger"
+}
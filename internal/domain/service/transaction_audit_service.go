@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TransactionAuditResult struct {
+	TransactionID        uuid.UUID   `json:"transaction_id"`
+	TransactionReference string      `json:"transaction_reference"`
+	TransactionType      string      `json:"transaction_type"`
+	Status               AuditStatus `json:"status"`
+	Amount               float64     `json:"amount"`
+	CurrencyCode         string      `json:"currency_code"`
+	LegCount             int         `json:"leg_count"`
+	TotalCredits         float64     `json:"total_credits"`
+	TotalDebits          float64     `json:"total_debits"`
+	FraudTypes           []FraudType `json:"fraud_types"`
+	Details              []string    `json:"details"`
+}
+
+// TransactionAuditReport lists only the transactions that failed a check;
+// the counters cover everything audited.
+type TransactionAuditReport struct {
+	TransactionsChecked int                      `json:"transactions_checked"`
+	ValidCount          int                      `json:"valid_count"`
+	FraudulentCount     int                      `json:"fraudulent_count"`
+	Results             []TransactionAuditResult `json:"results"`
+	OrphanedEntries     []model.LedgerEntry      `json:"orphaned_entries"`
+	NotFound            []uuid.UUID              `json:"not_found,omitempty"`
+	AuditedAt           time.Time                `json:"audited_at"`
+}
+
+// TransactionAuditService checks the double-entry integrity of transactions:
+// every transaction's legs must net to zero in a single currency and agree
+// with its header, and every ledger entry must belong to a transaction.
+type TransactionAuditService struct {
+	transactionRepo *repository.TransactionRepository
+	ledgerRepo      *repository.LedgerRepository
+}
+
+func NewTransactionAuditService(
+	transactionRepo *repository.TransactionRepository,
+	ledgerRepo *repository.LedgerRepository,
+) *TransactionAuditService {
+	return &TransactionAuditService{
+		transactionRepo: transactionRepo,
+		ledgerRepo:      ledgerRepo,
+	}
+}
+
+func (s *TransactionAuditService) AuditTransactions(ctx context.Context, transactionIDs []uuid.UUID) (*TransactionAuditReport, error) {
+	summaries, err := s.transactionRepo.WithContext(ctx).LegSummariesByIDs(transactionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transactions: %w", err)
+	}
+
+	found := make(map[uuid.UUID]bool, len(summaries))
+	for _, summary := range summaries {
+		found[summary.TransactionID] = true
+	}
+
+	var missing []uuid.UUID
+	for _, id := range transactionIDs {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	// A missing transaction is only suspicious if entries still point at it.
+	var orphans []model.LedgerEntry
+	if len(missing) > 0 {
+		orphans, err = s.ledgerRepo.WithContext(ctx).FindOrphanedByTransactionIDs(missing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look for orphaned entries: %w", err)
+		}
+	}
+
+	report := buildTransactionAuditReport(summaries, orphans)
+	report.NotFound = missing
+	return report, nil
+}
+
+// AuditTransactionsBetween audits the transactions and the orphaned entries
+// created in [from, to).
+func (s *TransactionAuditService) AuditTransactionsBetween(ctx context.Context, from, to time.Time) (*TransactionAuditReport, error) {
+	if !from.Before(to) {
+		return nil, errors.New("audit range start must be before its end")
+	}
+
+	summaries, err := s.transactionRepo.WithContext(ctx).LegSummariesBetween(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transactions: %w", err)
+	}
+
+	orphans, err := s.ledgerRepo.WithContext(ctx).FindOrphanedBetween(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look for orphaned entries: %w", err)
+	}
+
+	return buildTransactionAuditReport(summaries, orphans), nil
+}
+
+func buildTransactionAuditReport(summaries []repository.TransactionLegSummary, orphans []model.LedgerEntry) *TransactionAuditReport {
+	if orphans == nil {
+		orphans = []model.LedgerEntry{}
+	}
+
+	report := &TransactionAuditReport{
+		TransactionsChecked: len(summaries),
+		Results:             []TransactionAuditResult{},
+		OrphanedEntries:     orphans,
+		AuditedAt:           time.Now(),
+	}
+
+	for _, summary := range summaries {
+		result := auditTransactionLegs(summary)
+		if result.Status == AuditStatusValid {
+			report.ValidCount++
+			continue
+		}
+		report.FraudulentCount++
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
+
+func auditTransactionLegs(summary repository.TransactionLegSummary) TransactionAuditResult {
+	result := TransactionAuditResult{
+		TransactionID:        summary.TransactionID,
+		TransactionReference: summary.TransactionReference,
+		TransactionType:      summary.TransactionType,
+		Status:               AuditStatusValid,
+		Amount:               summary.Amount,
+		CurrencyCode:         summary.CurrencyCode,
+		LegCount:             summary.LegCount,
+		TotalCredits:         roundAmount(summary.CreditTotal),
+		TotalDebits:          roundAmount(summary.DebitTotal),
+		FraudTypes:           []FraudType{},
+		Details:              []string{},
+	}
+
+	flag := func(fraudType FraudType, format string, args ...any) {
+		result.Status = AuditStatusFraudulent
+		result.FraudTypes = append(result.FraudTypes, fraudType)
+		result.Details = append(result.Details, fmt.Sprintf(format, args...))
+	}
+
+	// Transactions that never completed have no legs yet.
+	if summary.LegCount == 0 {
+		if summary.Status == "completed" {
+			flag(FraudTypeUnbalancedTransaction, "Completed transaction has no ledger entries")
+		}
+		return result
+	}
+
+	if summary.CreditCount == 0 || summary.DebitCount == 0 {
+		flag(FraudTypeUnbalancedTransaction, "Transaction is one-sided: %d credit and %d debit legs", summary.CreditCount, summary.DebitCount)
+	} else if result.TotalCredits != result.TotalDebits {
+		flag(FraudTypeUnbalancedTransaction, "Legs do not net to zero: credits=%.4f, debits=%.4f", result.TotalCredits, result.TotalDebits)
+	}
+
+	if summary.MissingAccountLegs > 0 {
+		flag(FraudTypeOrphanedEntry, "%d legs are booked on accounts that do not exist", summary.MissingAccountLegs)
+	}
+
+	if summary.LegCurrencies > 1 {
+		flag(FraudTypeMixedCurrency, "Legs are booked in %d different currencies", summary.LegCurrencies)
+	}
+
+	if summary.ForeignCurrencyLegs > 0 {
+		flag(FraudTypeHeaderMismatch, "%d legs are booked in a currency other than the transaction's %s", summary.ForeignCurrencyLegs, summary.CurrencyCode)
+	}
+	if roundAmount(summary.Amount) != result.TotalCredits || roundAmount(summary.Amount) != result.TotalDebits {
+		flag(FraudTypeHeaderMismatch, "Transaction amount %.4f does not match its legs: credits=%.4f, debits=%.4f",
+			summary.Amount, result.TotalCredits, result.TotalDebits)
+	}
+
+	return result
+}
@@ -6,32 +6,82 @@ import (
 	"paygo/internal/domain/model"
 	"paygo/internal/domain/repository"
 	"paygo/internal/infra/database"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrTransferDenied     = errors.New("transfer denied by risk checks")
+	ErrTransferNotPending = errors.New("transfer is not pending review")
+	ErrDuplicateTransfer  = errors.New("possible duplicate transfer")
+	ErrSameAccount        = errors.New("cannot transfer to the same account")
+)
+
 type TransferService struct {
 	DB              database.DBManager
 	AccountRepo     *repository.AccountRepository
 	TransactionRepo *repository.TransactionRepository
+	PeriodRepo      *repository.PeriodRepository
+	// RiskScorer, when set, scores every TransferMoney call and wallet
+	// movement before balances change. Denied transfers are recorded but not
+	// booked; transfers sent to review hold the amount on the source account
+	// until approved or rejected.
+	RiskScorer RiskScorer
+	// DuplicateWindow, when positive, makes TransferMoney refuse a transfer
+	// with the same accounts, amount and description as a completed or held
+	// transfer made within the window, unless the caller confirms it.
+	DuplicateWindow time.Duration
+}
+
+// transferChecks are the pre-commit checks of a customer transfer. Wallet
+// movements are only risk scored.
+type transferChecks struct {
+	scorer           RiskScorer
+	duplicateWindow  time.Duration
+	confirmDuplicate bool
 }
 
 func NewTransferService(
 	db database.DBManager,
 	accountRepo *repository.AccountRepository,
 	transactionRepo *repository.TransactionRepository,
+	periodRepo *repository.PeriodRepository,
 ) *TransferService {
 	return &TransferService{
 		DB:              db,
 		AccountRepo:     accountRepo,
 		TransactionRepo: transactionRepo,
+		PeriodRepo:      periodRepo,
 	}
 }
 
-func (s *TransferService) TransferMoney(fromAccountID, toAccountID uuid.UUID, amount float64, description string) (*model.Transaction, *model.Account, *model.Account, error) {
+// TransferMoney moves money between two accounts. A suspected duplicate is
+// refused with ErrDuplicateTransfer and the earlier transfer is returned in
+// place of the transaction; confirmDuplicate books it anyway, linked to the
+// earlier transfer.
+func (s *TransferService) TransferMoney(fromAccountID, toAccountID uuid.UUID, amount float64, description string, confirmDuplicate bool) (*model.Transaction, *model.Account, *model.Account, error) {
+	return s.transfer(fromAccountID, toAccountID, amount, "transfer", description, transferChecks{
+		scorer:           s.RiskScorer,
+		duplicateWindow:  s.DuplicateWindow,
+		confirmDuplicate: confirmDuplicate,
+	})
+}
+
+// transfer moves money between two ledger accounts as a completed transaction
+// of the given type. Every movement between accounts, including wallet
+// movements, goes through it.
+func (s *TransferService) transfer(fromAccountID, toAccountID uuid.UUID, amount float64, transactionType, description string, checks transferChecks) (*model.Transaction, *model.Account, *model.Account, error) {
 	var fromAccount, toAccount *model.Account
 	var transaction model.Transaction
+	var duplicate *model.Transaction
+
+	// Both legs would share one locked account, so the debit leg's running
+	// balance would be recorded after the credit.
+	if fromAccountID == toAccountID {
+		return nil, nil, nil, ErrSameAccount
+	}
 
 	// TODO: context is not passed.
 	err := s.DB.WithTransaction(func(tx database.DB) error {
@@ -44,7 +94,46 @@ func (s *TransferService) TransferMoney(fromAccountID, toAccountID uuid.UUID, am
 			return err
 		}
 
-		transaction = s.createTransaction(fromAccount.CurrencyCode, amount, description)
+		if err := EnsurePeriodOpen(s.PeriodRepo.WithTx(tx), time.Now()); err != nil {
+			return err
+		}
+
+		transaction = s.createTransaction(transactionType, fromAccount.CurrencyCode, amount, description)
+		transaction.SourceAccountID = &fromAccount.ID
+		transaction.DestinationAccountID = &toAccount.ID
+
+		// The account locks taken above serialise concurrent submissions, so
+		// the second of two identical requests sees the first.
+		if checks.duplicateWindow > 0 {
+			if duplicate, err = txTransactionRepo.FindRecentDuplicate(fromAccount.ID, toAccount.ID, amount, description, time.Now().Add(-checks.duplicateWindow)); err != nil {
+				return err
+			}
+			if duplicate != nil {
+				if !checks.confirmDuplicate {
+					return fmt.Errorf("%w: same as %s made at %s", ErrDuplicateTransfer,
+						duplicate.TransactionReference, duplicate.CreatedAt.UTC().Format(time.RFC3339))
+				}
+				transaction.DuplicateOfID = &duplicate.ID
+			}
+		}
+
+		if scorer := checks.scorer; scorer != nil {
+			assessment, err := scorer.Score(tx, fromAccount, toAccount, amount, time.Now())
+			if err != nil {
+				return fmt.Errorf("risk scoring failed: %w", err)
+			}
+			transaction.RiskScore = &assessment.Score
+			transaction.RiskDecision = string(assessment.Decision)
+			transaction.RiskReasons = assessment.Reasons
+
+			switch assessment.Decision {
+			case RiskDeny:
+				transaction.Status = "denied"
+				return txTransactionRepo.Create(&transaction)
+			case RiskReview:
+				return s.holdForReview(txAccountRepo, txTransactionRepo, &transaction, fromAccount)
+			}
+		}
 
 		if err := txTransactionRepo.Create(&transaction); err != nil {
 			return err
@@ -63,29 +152,164 @@ func (s *TransferService) TransferMoney(fromAccountID, toAccountID uuid.UUID, am
 		return nil
 	})
 
+	if errors.Is(err, ErrDuplicateTransfer) {
+		return duplicate, nil, nil, err
+	}
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	if transaction.Status == "denied" {
+		return &transaction, fromAccount, toAccount, fmt.Errorf("%w: %s", ErrTransferDenied, strings.Join(transaction.RiskReasons, "; "))
+	}
+
 	return &transaction, fromAccount, toAccount, nil
 }
 
-func (s *TransferService) validateAccounts(repo *repository.AccountRepository, fromAccountID, toAccountID uuid.UUID, amount float64) (*model.Account, *model.Account, error) {
-	fromAccount, err := repo.FindByID(fromAccountID, true)
+// holdForReview records the transfer as pending review and reserves the
+// amount on the source account without booking anything.
+func (s *TransferService) holdForReview(accountRepo *repository.AccountRepository, transactionRepo *repository.TransactionRepository, transaction *model.Transaction, fromAccount *model.Account) error {
+	transaction.Status = "pending_review"
+	if err := transactionRepo.Create(transaction); err != nil {
+		return err
+	}
+
+	fromAccount.AvailableBalance -= transaction.Amount
+	fromAccount.UpdatedAt = time.Now()
+	_, err := accountRepo.Update(fromAccount)
+	return err
+}
+
+// ApproveTransfer books a transfer held for review, releasing its hold.
+func (s *TransferService) ApproveTransfer(transactionID uuid.UUID, reviewer string) (*model.Transaction, error) {
+	var transaction *model.Transaction
+
+	err := s.DB.WithTransaction(func(tx database.DB) error {
+		var err error
+
+		txAccountRepo := s.AccountRepo.WithTx(tx)
+		txTransactionRepo := s.TransactionRepo.WithTx(tx)
+
+		if transaction, err = s.findPendingReview(txTransactionRepo, transactionID); err != nil {
+			return err
+		}
+
+		accounts, err := lockAccountsInOrder(txAccountRepo, []uuid.UUID{*transaction.SourceAccountID, *transaction.DestinationAccountID})
+		if err != nil {
+			return err
+		}
+		fromAccount, toAccount := accounts[*transaction.SourceAccountID], accounts[*transaction.DestinationAccountID]
+
+		if fromAccount.Status != "active" {
+			return errors.New("source account is not active")
+		}
+		if !canReceive(toAccount) {
+			return errors.New("destination account is not active")
+		}
+
+		if err := EnsurePeriodOpen(s.PeriodRepo.WithTx(tx), time.Now()); err != nil {
+			return err
+		}
+
+		fromAccount.AvailableBalance += transaction.Amount
+		s.updateAccountBalances(fromAccount, toAccount, transaction.Amount)
+
+		if err := s.createLedgerEntries(txTransactionRepo, transaction, fromAccount, toAccount, transaction.Amount); err != nil {
+			return err
+		}
+
+		if err := s.updateAccounts(txAccountRepo, fromAccount, toAccount); err != nil {
+			return err
+		}
+
+		return s.completeReview(txTransactionRepo, transaction, "completed", reviewer)
+	})
+
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	toAccount, err := repo.FindByID(toAccountID, true)
+	return transaction, nil
+}
+
+// RejectTransfer cancels a transfer held for review and releases its hold.
+func (s *TransferService) RejectTransfer(transactionID uuid.UUID, reviewer string) (*model.Transaction, error) {
+	var transaction *model.Transaction
+
+	err := s.DB.WithTransaction(func(tx database.DB) error {
+		var err error
+
+		txAccountRepo := s.AccountRepo.WithTx(tx)
+		txTransactionRepo := s.TransactionRepo.WithTx(tx)
+
+		if transaction, err = s.findPendingReview(txTransactionRepo, transactionID); err != nil {
+			return err
+		}
+
+		accounts, err := lockAccountsInOrder(txAccountRepo, []uuid.UUID{*transaction.SourceAccountID})
+		if err != nil {
+			return err
+		}
+		fromAccount := accounts[*transaction.SourceAccountID]
+
+		fromAccount.AvailableBalance += transaction.Amount
+		fromAccount.UpdatedAt = time.Now()
+		if _, err := txAccountRepo.Update(fromAccount); err != nil {
+			return err
+		}
+
+		return s.completeReview(txTransactionRepo, transaction, "rejected", reviewer)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+func (s *TransferService) PendingReviews(limit int) ([]model.Transaction, error) {
+	return s.TransactionRepo.FindByStatus("pending_review", limit)
+}
+
+func (s *TransferService) findPendingReview(repo *repository.TransactionRepository, transactionID uuid.UUID) (*model.Transaction, error) {
+	transaction, err := repo.FindByIDForUpdate(transactionID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, err
+	}
+
+	if transaction.Status != "pending_review" || transaction.SourceAccountID == nil || transaction.DestinationAccountID == nil {
+		return nil, fmt.Errorf("%w: status is %q", ErrTransferNotPending, transaction.Status)
+	}
+
+	return transaction, nil
+}
+
+func (s *TransferService) completeReview(repo *repository.TransactionRepository, transaction *model.Transaction, status, reviewer string) error {
+	now := time.Now()
+	transaction.Status = status
+	transaction.ReviewedBy = reviewer
+	transaction.ReviewedAt = &now
+	transaction.UpdatedAt = now
+	return repo.Update(transaction)
+}
+
+func (s *TransferService) validateAccounts(repo *repository.AccountRepository, fromAccountID, toAccountID uuid.UUID, amount float64) (*model.Account, *model.Account, error) {
+	// Locking in a fixed order keeps opposing transfers from deadlocking.
+	accounts, err := lockAccountsInOrder(repo, []uuid.UUID{fromAccountID, toAccountID})
 	if err != nil {
 		return nil, nil, err
 	}
+	fromAccount, toAccount := accounts[fromAccountID], accounts[toAccountID]
 
 	if fromAccount.Status != "active" {
 		return nil, nil, errors.New("source account is not active")
 	}
 
-	if toAccount.Status != "active" {
+	if !canReceive(toAccount) {
 		return nil, nil, errors.New("destination account is not active")
 	}
 
@@ -93,17 +317,18 @@ func (s *TransferService) validateAccounts(repo *repository.AccountRepository, f
 		return nil, nil, errors.New("currency mismatch between accounts")
 	}
 
-	if fromAccount.Balance < amount {
+	// Amounts held for transfers under review are not available.
+	if fromAccount.AvailableBalance < amount {
 		return nil, nil, errors.New("insufficient funds")
 	}
 
 	return fromAccount, toAccount, nil
 }
 
-func (s *TransferService) createTransaction(currencyCode string, amount float64, description string) model.Transaction {
+func (s *TransferService) createTransaction(transactionType, currencyCode string, amount float64, description string) model.Transaction {
 	return model.Transaction{
 		TransactionReference: generateTransactionReference(),
-		TransactionType:      "transfer",
+		TransactionType:      transactionType,
 		Amount:               amount,
 		CurrencyCode:         currencyCode,
 		Status:               "completed",
@@ -124,12 +349,15 @@ func (s *TransferService) updateAccountBalances(fromAccount, toAccount *model.Ac
 }
 
 func (s *TransferService) createLedgerEntries(repo *repository.TransactionRepository, transaction *model.Transaction, fromAccount, toAccount *model.Account, amount float64) error {
+	valueDate := valueDateOf(time.Now())
+
 	debitEntry := model.LedgerEntry{
 		TransactionID:  transaction.ID,
 		AccountID:      fromAccount.ID,
 		EntryType:      "debit",
 		Amount:         amount,
 		RunningBalance: fromAccount.Balance,
+		ValueDate:      &valueDate,
 		CreatedAt:      time.Now(),
 	}
 
@@ -139,6 +367,7 @@ func (s *TransferService) createLedgerEntries(repo *repository.TransactionReposi
 		EntryType:      "credit",
 		Amount:         amount,
 		RunningBalance: toAccount.Balance,
+		ValueDate:      &valueDate,
 		CreatedAt:      time.Now(),
 	}
 
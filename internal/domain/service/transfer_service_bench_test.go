@@ -0,0 +1,182 @@
+//go:build integration
+
+package service_test
+
+import (
+	"fmt"
+	"paygo/internal/api/controller"
+	"paygo/internal/config"
+	"paygo/internal/domain/model"
+	"paygo/internal/infra/database"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BenchmarkTransferMoney measures a transfer between accounts with ledger
+// histories of growing size. The transfer service is wired as the API wires
+// it, with risk scoring and the duplicate lookup, and every transfer is large
+// enough to run the beneficiary check. ns/op should stay flat as the history
+// grows.
+//
+// It writes to the database configured through the DB_* variables and
+// removes its data afterwards; point it at a scratch database:
+//
+//	go test -tags integration -run '^$' -bench TransferMoney ./internal/domain/service
+func BenchmarkTransferMoney(b *testing.B) {
+	cfg := config.LoadConfig()
+	db, err := database.Setup(&cfg)
+	if err != nil {
+		b.Skipf("database not available: %v", err)
+	}
+	b.Cleanup(func() { _ = db.Close() })
+
+	transferService := controller.NewTransferController(db, &cfg).TransferService
+	amount := cfg.RiskLargeAmount
+
+	for _, size := range []int{0, 10_000, 100_000} {
+		b.Run(fmt.Sprintf("history=%d", size), func(b *testing.B) {
+			from, to := createBenchAccounts(b, db, size, amount)
+
+			b.ResetTimer()
+			for i := range b.N {
+				// Distinct descriptions keep the duplicate check from refusing
+				// the transfers while still running its lookup.
+				transaction, _, _, err := transferService.TransferMoney(from, to, amount, fmt.Sprintf("transfer benchmark %d", i), false)
+				if err != nil {
+					b.Fatalf("transfer failed: %v", err)
+				}
+				if transaction.Status != "completed" {
+					b.Fatalf("transfer was %s by risk scoring: %v", transaction.Status, transaction.RiskReasons)
+				}
+			}
+		})
+	}
+}
+
+// createBenchAccounts opens a funded source and a destination account. The
+// source gets a history of size debits of amount, one second apart and ending
+// an hour ago so the rapid-debit rule stays quiet, preceded by one payment to
+// the destination so it counts as a known beneficiary. The destination gets
+// size credits. Everything is deleted again when the benchmark ends.
+func createBenchAccounts(b *testing.B, db *database.Database, size int, amount float64) (uuid.UUID, uuid.UUID) {
+	b.Helper()
+	now := time.Now()
+	suffix := strings.ToUpper(uuid.NewString()[:8])
+
+	user := model.User{
+		ID:           uuid.New(),
+		Email:        fmt.Sprintf("bench+%s@example.com", strings.ToLower(suffix)),
+		PasswordHash: "-",
+		Status:       "active",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := db.Create(&user); err != nil {
+		b.Fatalf("failed to create user: %v", err)
+	}
+
+	var ids []uuid.UUID
+	for i, balance := range []float64{1e12, 0} {
+		account := model.Account{
+			ID:               uuid.New(),
+			UserID:           user.ID,
+			AccountNumber:    fmt.Sprintf("BENCH-%s-%d", suffix, i),
+			AccountType:      "checking",
+			CurrencyCode:     "USD",
+			Balance:          balance,
+			AvailableBalance: balance,
+			Status:           "active",
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}
+		if err := db.Create(&account); err != nil {
+			b.Fatalf("failed to create account: %v", err)
+		}
+		ids = append(ids, account.ID)
+	}
+	from, to := ids[0], ids[1]
+
+	history := model.Transaction{
+		ID:                   uuid.New(),
+		TransactionReference: "BENCH-HIST-" + suffix,
+		TransactionType:      "transfer_benchmark_history",
+		Amount:               amount * float64(size),
+		CurrencyCode:         "USD",
+		Status:               "completed",
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	payment := model.Transaction{
+		ID:                   uuid.New(),
+		TransactionReference: "BENCH-PAY-" + suffix,
+		TransactionType:      "transfer_benchmark_history",
+		Amount:               amount,
+		CurrencyCode:         "USD",
+		Status:               "completed",
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	for _, transaction := range []*model.Transaction{&history, &payment} {
+		if err := db.Create(transaction); err != nil {
+			b.Fatalf("failed to create history transaction: %v", err)
+		}
+	}
+
+	b.Cleanup(func() {
+		for _, statement := range []struct {
+			query string
+			args  []any
+		}{
+			{"DELETE FROM ledger_entries WHERE account_id IN ?", []any{ids}},
+			{"DELETE FROM transactions WHERE source_account_id IN ? OR id IN ?", []any{ids, []uuid.UUID{history.ID, payment.ID}}},
+			{"DELETE FROM accounts WHERE id IN ?", []any{ids}},
+			{"DELETE FROM users WHERE id = ?", []any{user.ID}},
+		} {
+			if _, err := db.Exec(statement.query, statement.args...); err != nil {
+				b.Logf("cleanup failed: %v", err)
+				return
+			}
+		}
+	})
+
+	end := now.Add(-time.Hour)
+	start := end.Add(-time.Duration(size+1) * time.Second)
+	for _, leg := range []struct {
+		account   uuid.UUID
+		entryType string
+	}{{from, "debit"}, {to, "credit"}} {
+		if _, err := db.Exec(`
+			INSERT INTO ledger_entries (id, transaction_id, account_id, entry_type, amount, running_balance, created_at)
+			VALUES (gen_random_uuid(), ?, ?, ?, ?, 0, ?)`,
+			payment.ID, leg.account, leg.entryType, amount, start,
+		); err != nil {
+			b.Fatalf("failed to write beneficiary payment: %v", err)
+		}
+	}
+
+	if size == 0 {
+		return from, to
+	}
+
+	for _, leg := range []struct {
+		account   uuid.UUID
+		entryType string
+	}{{from, "debit"}, {to, "credit"}} {
+		if _, err := db.Exec(`
+			INSERT INTO ledger_entries (id, transaction_id, account_id, entry_type, amount, running_balance, created_at)
+			SELECT gen_random_uuid(), ?, ?, ?, ?, 0, ?::timestamptz - (? - g) * interval '1 second'
+			FROM generate_series(1, ?) g`,
+			history.ID, leg.account, leg.entryType, amount, end, size, size,
+		); err != nil {
+			b.Fatalf("failed to write history: %v", err)
+		}
+	}
+	if _, err := db.Exec("ANALYZE ledger_entries"); err != nil {
+		b.Fatalf("failed to analyze ledger: %v", err)
+	}
+
+	return from, to
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"paygo/internal/domain/model"
+	"paygo/internal/domain/repository"
+	"paygo/internal/infra/database"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWalletNotFound = errors.New("wallet not found")
+	ErrWalletExists   = errors.New("user already has a wallet in this currency")
+)
+
+// WalletMovement is the outcome of a wallet top-up, withdrawal or transfer.
+// Account is set when money moved between a wallet and an account, ToWallet
+// when it moved between two wallets.
+type WalletMovement struct {
+	Transaction *model.Transaction `json:"transaction"`
+	Wallet      *model.Wallet      `json:"wallet"`
+	ToWallet    *model.Wallet      `json:"to_wallet,omitempty"`
+	Account     *model.Account     `json:"account,omitempty"`
+}
+
+type WalletService struct {
+	db              database.DBManager
+	transferService *TransferService
+	walletRepo      *repository.WalletRepository
+	accountRepo     *repository.AccountRepository
+}
+
+func NewWalletService(
+	db database.DBManager,
+	transferService *TransferService,
+	walletRepo *repository.WalletRepository,
+	accountRepo *repository.AccountRepository,
+) *WalletService {
+	return &WalletService{
+		db:              db,
+		transferService: transferService,
+		walletRepo:      walletRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// CreateWallet opens a wallet together with the ledger account that holds its money.
+func (s *WalletService) CreateWallet(userID uuid.UUID, currency string) (*model.Wallet, error) {
+	currency = strings.ToUpper(currency)
+	var wallet model.Wallet
+
+	err := s.db.WithTransaction(func(tx database.DB) error {
+		txWalletRepo := s.walletRepo.WithTx(tx)
+
+		exists, err := txWalletRepo.Exists(userID, currency)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrWalletExists
+		}
+
+		account := model.Account{
+			UserID:        userID,
+			AccountNumber: fmt.Sprintf("WAL-%s", strings.ToUpper(uuid.New().String()[:8])),
+			AccountType:   "wallet",
+			CurrencyCode:  currency,
+			Status:        "active",
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}
+		if err := s.accountRepo.WithTx(tx).Create(&account); err != nil {
+			return fmt.Errorf("failed to create wallet account: %w", err)
+		}
+
+		wallet = model.Wallet{
+			UserID:    userID,
+			AccountID: account.ID,
+			Currency:  currency,
+			Status:    "active",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		return txWalletRepo.Create(&wallet)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+func (s *WalletService) GetWallet(walletID uuid.UUID) (*model.Wallet, error) {
+	wallet, err := s.walletRepo.FindByID(walletID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, err
+	}
+	return wallet, nil
+}
+
+func (s *WalletService) ListWallets(userID uuid.UUID) ([]model.Wallet, error) {
+	return s.walletRepo.FindByUserID(userID)
+}
+
+// TopUp moves money from one of the wallet owner's accounts into the wallet.
+func (s *WalletService) TopUp(walletID, accountID uuid.UUID, amount float64, description string) (*WalletMovement, error) {
+	wallet, err := s.activeWallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwnAccount(wallet, accountID); err != nil {
+		return nil, err
+	}
+
+	transaction, account, walletAccount, err := s.transferService.transfer(accountID, wallet.AccountID, amount, "wallet_topup", description, s.riskChecks())
+	if err != nil {
+		return nil, err
+	}
+	wallet.Balance = walletAccount.Balance
+
+	return &WalletMovement{Transaction: transaction, Wallet: wallet, Account: account}, nil
+}
+
+// Withdraw moves money from the wallet to one of its owner's accounts.
+func (s *WalletService) Withdraw(walletID, accountID uuid.UUID, amount float64, description string) (*WalletMovement, error) {
+	wallet, err := s.activeWallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwnAccount(wallet, accountID); err != nil {
+		return nil, err
+	}
+
+	transaction, walletAccount, account, err := s.transferService.transfer(wallet.AccountID, accountID, amount, "wallet_withdrawal", description, s.riskChecks())
+	if err != nil {
+		return nil, err
+	}
+	wallet.Balance = walletAccount.Balance
+
+	return &WalletMovement{Transaction: transaction, Wallet: wallet, Account: account}, nil
+}
+
+// TransferBetweenWallets moves money between two wallets. A user holds one
+// wallet per currency, so the wallets always belong to different owners.
+func (s *WalletService) TransferBetweenWallets(fromWalletID, toWalletID uuid.UUID, amount float64, description string) (*WalletMovement, error) {
+	if fromWalletID == toWalletID {
+		return nil, errors.New("cannot transfer to the same wallet")
+	}
+
+	fromWallet, err := s.activeWallet(fromWalletID)
+	if err != nil {
+		return nil, err
+	}
+	toWallet, err := s.activeWallet(toWalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, fromAccount, toAccount, err := s.transferService.transfer(fromWallet.AccountID, toWallet.AccountID, amount, "wallet_transfer", description, s.riskChecks())
+	if err != nil {
+		return nil, err
+	}
+	fromWallet.Balance = fromAccount.Balance
+	toWallet.Balance = toAccount.Balance
+
+	return &WalletMovement{Transaction: transaction, Wallet: fromWallet, ToWallet: toWallet}, nil
+}
+
+// riskChecks scores wallet movements like customer transfers: a held movement
+// comes back pending review and a denied one fails with ErrTransferDenied.
+// Duplicate detection stays limited to plain transfers.
+func (s *WalletService) riskChecks() transferChecks {
+	return transferChecks{scorer: s.transferService.RiskScorer}
+}
+
+func (s *WalletService) activeWallet(walletID uuid.UUID) (*model.Wallet, error) {
+	wallet, err := s.GetWallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.Status != "active" {
+		return nil, fmt.Errorf("wallet %s is not active", walletID)
+	}
+	return wallet, nil
+}
+
+// checkOwnAccount only lets wallets exchange money with regular accounts of
+// the same user.
+func (s *WalletService) checkOwnAccount(wallet *model.Wallet, accountID uuid.UUID) error {
+	account, err := s.accountRepo.FindByIDWithoutEntries(accountID)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return ErrAccountNotFound
+		}
+		return err
+	}
+	if account.UserID != wallet.UserID {
+		return errors.New("account does not belong to the wallet owner")
+	}
+	if account.AccountType == "wallet" || account.AccountType == "system" {
+		return fmt.Errorf("cannot move wallet funds to or from a %s account", account.AccountType)
+	}
+	return nil
+}
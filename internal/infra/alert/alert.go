@@ -0,0 +1,70 @@
+// Package alert delivers audit alerts to external systems. Every sink gets
+// the same Alert; which sinks are used is decided by configuration.
+package alert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SinkLog     = "log"
+	SinkFile    = "file"
+	SinkWebhook = "webhook"
+)
+
+// Alert is a single fraudulent audit finding. Fingerprint identifies the
+// finding across audits, so receivers can deduplicate on it as well.
+type Alert struct {
+	Fingerprint   string    `json:"fingerprint"`
+	AccountID     uuid.UUID `json:"account_id"`
+	AccountNumber string    `json:"account_number"`
+	Detector      string    `json:"detector"`
+	FraudType     string    `json:"fraud_type"`
+	Severity      string    `json:"severity"`
+	Message       string    `json:"message"`
+	DetectedAt    time.Time `json:"detected_at"`
+}
+
+// Sink delivers alerts. Send must be safe for concurrent use.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, alert Alert) error
+}
+
+// Options configures the sinks built by NewSinks.
+type Options struct {
+	FilePath       string
+	WebhookURL     string
+	WebhookSecret  string
+	WebhookTimeout time.Duration
+}
+
+// NewSinks builds the named sinks. Unknown names and sinks missing their
+// settings are errors, so a typo does not silently drop alerts.
+func NewSinks(names []string, opts Options) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(names))
+	for _, name := range names {
+		switch strings.ToLower(name) {
+		case SinkLog:
+			sinks = append(sinks, NewLogSink())
+		case SinkFile:
+			if opts.FilePath == "" {
+				return nil, fmt.Errorf("alert sink %q needs a file path", name)
+			}
+			sinks = append(sinks, NewFileSink(opts.FilePath))
+		case SinkWebhook:
+			if opts.WebhookURL == "" || opts.WebhookSecret == "" {
+				return nil, fmt.Errorf("alert sink %q needs a URL and a secret", name)
+			}
+			sinks = append(sinks, NewWebhookSink(opts.WebhookURL, opts.WebhookSecret, opts.WebhookTimeout))
+		default:
+			return nil, fmt.Errorf("unknown alert sink %q", name)
+		}
+	}
+	return sinks, nil
+}
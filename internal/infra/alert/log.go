@@ -0,0 +1,23 @@
+package alert
+
+import (
+	"context"
+	"log"
+)
+
+// LogSink writes alerts to the server log.
+type LogSink struct{}
+
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+func (s *LogSink) Name() string {
+	return SinkLog
+}
+
+func (s *LogSink) Send(_ context.Context, alert Alert) error {
+	log.Printf("ALERT [%s] %s on account %s (%s): %s",
+		alert.Severity, alert.FraudType, alert.AccountNumber, alert.AccountID, alert.Message)
+	return nil
+}
@@ -0,0 +1,308 @@
+package bankstatement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CSVMapping tells ParseCSV which header columns hold which fields. Column
+// names are matched case-insensitively. Amounts come either from one signed
+// Amount column, optionally with a Direction column, or from separate Credit
+// and Debit columns.
+type CSVMapping struct {
+	Delimiter    rune
+	DateFormat   string
+	DecimalComma bool
+
+	Date          string
+	ValueDate     string
+	Amount        string
+	Direction     string
+	Credit        string
+	Debit         string
+	Reference     string
+	BankReference string
+	Description   string
+	Currency      string
+}
+
+// creditMarkers are the Direction column values read as a credit; any
+// other non-empty value is a debit.
+var creditMarkers = []string{"c", "cr", "crdt", "credit", "+"}
+
+func DefaultCSVMapping() CSVMapping {
+	return CSVMapping{
+		Delimiter:   ',',
+		DateFormat:  "2006-01-02",
+		Date:        "date",
+		Amount:      "amount",
+		Reference:   "reference",
+		Description: "description",
+	}
+}
+
+// CSVMappingFromMap overrides the default mapping with the given settings,
+// e.g. {"date": "Booking Date", "delimiter": ";", "decimal_comma": "true"}.
+func CSVMappingFromMap(settings map[string]string) (CSVMapping, error) {
+	mapping := DefaultCSVMapping()
+
+	for key, value := range settings {
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "delimiter":
+			// Named delimiters can be set where commas separate settings.
+			switch value {
+			case "comma":
+				value = ","
+			case "semicolon":
+				value = ";"
+			case "tab", `\t`:
+				value = "\t"
+			}
+			r, size := utf8.DecodeRuneInString(value)
+			if size == 0 || size != len(value) {
+				return mapping, fmt.Errorf("delimiter must be a single character, got %q", value)
+			}
+			mapping.Delimiter = r
+		case "date_format":
+			mapping.DateFormat = value
+		case "decimal_comma":
+			decimalComma, err := strconv.ParseBool(value)
+			if err != nil {
+				return mapping, fmt.Errorf("invalid decimal_comma %q", value)
+			}
+			mapping.DecimalComma = decimalComma
+		case "date":
+			mapping.Date = value
+		case "value_date":
+			mapping.ValueDate = value
+		case "amount":
+			mapping.Amount = value
+		case "direction":
+			mapping.Direction = value
+		case "credit":
+			mapping.Credit = value
+		case "debit":
+			mapping.Debit = value
+		case "reference":
+			mapping.Reference = value
+		case "bank_reference":
+			mapping.BankReference = value
+		case "description":
+			mapping.Description = value
+		case "currency":
+			mapping.Currency = value
+		default:
+			return mapping, fmt.Errorf("unknown CSV mapping setting %q", key)
+		}
+	}
+
+	// Separate credit and debit columns replace the default amount column.
+	if mapping.Credit != "" && settings["amount"] == "" {
+		mapping.Amount = ""
+	}
+
+	return mapping, mapping.Validate()
+}
+
+func (m CSVMapping) Validate() error {
+	if m.Date == "" {
+		return errors.New("CSV mapping needs a date column")
+	}
+	if m.DateFormat == "" {
+		return errors.New("CSV mapping needs a date format")
+	}
+	hasSplit := m.Credit != "" || m.Debit != ""
+	if hasSplit && (m.Credit == "" || m.Debit == "") {
+		return errors.New("CSV mapping needs both credit and debit columns")
+	}
+	if hasSplit == (m.Amount != "") {
+		return errors.New("CSV mapping needs either an amount column or credit and debit columns")
+	}
+	return nil
+}
+
+// ParseCSV reads a delimited statement whose first row names the columns.
+// Blank rows are skipped.
+func ParseCSV(r io.Reader, mapping CSVMapping) (*Statement, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = mapping.Delimiter
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("statement file is empty")
+		}
+		return nil, &ParseError{Line: 1, Err: err}
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	index := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		i, ok := columns[strings.ToLower(name)]
+		if !ok {
+			return -1, &ParseError{Line: 1, Err: fmt.Errorf("column %q not found", name)}
+		}
+		return i, nil
+	}
+
+	var (
+		idx   = map[string]int{}
+		names = map[string]string{
+			"date":           mapping.Date,
+			"value_date":     mapping.ValueDate,
+			"amount":         mapping.Amount,
+			"direction":      mapping.Direction,
+			"credit":         mapping.Credit,
+			"debit":          mapping.Debit,
+			"reference":      mapping.Reference,
+			"bank_reference": mapping.BankReference,
+			"description":    mapping.Description,
+			"currency":       mapping.Currency,
+		}
+	)
+	for field, name := range names {
+		if idx[field], err = index(name); err != nil {
+			return nil, err
+		}
+	}
+
+	statement := &Statement{}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		lineNumber, _ := reader.FieldPos(0)
+		if err != nil {
+			return nil, &ParseError{Line: lineNumber, Err: err}
+		}
+		if isBlank(record) {
+			continue
+		}
+
+		field := func(name string) string {
+			i := idx[name]
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		line, err := csvLine(field, mapping)
+		if err != nil {
+			return nil, &ParseError{Line: lineNumber, Err: err}
+		}
+		line.Number = len(statement.Lines) + 1
+
+		if currency := strings.ToUpper(field("currency")); currency != "" {
+			if statement.Currency != "" && statement.Currency != currency {
+				return nil, &ParseError{Line: lineNumber, Err: fmt.Errorf("currency %s differs from %s", currency, statement.Currency)}
+			}
+			statement.Currency = currency
+		}
+
+		statement.Lines = append(statement.Lines, line)
+	}
+
+	return statement, nil
+}
+
+func csvLine(field func(string) string, mapping CSVMapping) (Line, error) {
+	var line Line
+
+	bookingDate, err := time.Parse(mapping.DateFormat, field("date"))
+	if err != nil {
+		return line, fmt.Errorf("invalid date %q", field("date"))
+	}
+	line.BookingDate = bookingDate
+	line.ValueDate = bookingDate
+
+	if value := field("value_date"); value != "" {
+		if line.ValueDate, err = time.Parse(mapping.DateFormat, value); err != nil {
+			return line, fmt.Errorf("invalid value date %q", value)
+		}
+	}
+
+	var amount float64
+	if mapping.Amount != "" {
+		if amount, err = parseAmount(field("amount"), mapping.DecimalComma); err != nil {
+			return line, err
+		}
+		if direction := strings.ToLower(field("direction")); direction != "" {
+			amount = abs(amount)
+			if !slices.Contains(creditMarkers, direction) {
+				amount = -amount
+			}
+		}
+	} else {
+		credit, debit := field("credit"), field("debit")
+		switch {
+		case credit != "" && debit != "":
+			return line, errors.New("both credit and debit amounts are set")
+		case credit != "":
+			if amount, err = parseAmount(credit, mapping.DecimalComma); err != nil {
+				return line, err
+			}
+			amount = abs(amount)
+		case debit != "":
+			if amount, err = parseAmount(debit, mapping.DecimalComma); err != nil {
+				return line, err
+			}
+			amount = -abs(amount)
+		}
+	}
+
+	if amount == 0 {
+		return line, errors.New("amount is missing or zero")
+	}
+
+	line.Direction = DirectionCredit
+	if amount < 0 {
+		line.Direction = DirectionDebit
+	}
+	line.Amount = abs(amount)
+	line.Reference = field("reference")
+	line.BankReference = field("bank_reference")
+	line.Description = field("description")
+
+	return line, nil
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(amount float64) float64 {
+	if amount < 0 {
+		return -amount
+	}
+	return amount
+}
@@ -0,0 +1,221 @@
+package bankstatement
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// statementLinePattern splits the first line of an MT940 :61: field into
+// value date, optional entry date, debit/credit mark, funds code, amount,
+// transaction type, customer reference and bank reference.
+var statementLinePattern = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([NFS][A-Z0-9]{3})([^/]*(?:/[^/][^/]*)*)(?://(.*))?$`)
+
+// balancePattern matches the :60a: and :62a: balance fields.
+var balancePattern = regexp.MustCompile(`^(C|D)(\d{6})([A-Z]{3})(\d+,\d*)$`)
+
+type mt940Field struct {
+	tag   string
+	value string
+	line  int
+}
+
+// ParseMT940 reads the statements of an MT940 file. SWIFT block headers
+// around the text block are ignored, and a file may hold several messages.
+func ParseMT940(r io.Reader) ([]Statement, error) {
+	fields, err := readMT940Fields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		statements []Statement
+		current    *Statement
+		lastLine   *Line
+	)
+
+	for _, field := range fields {
+		if field.tag != "20" && current == nil {
+			return nil, &ParseError{Line: field.line, Err: fmt.Errorf("field :%s: before :20:", field.tag)}
+		}
+
+		switch field.tag {
+		case "20":
+			statements = append(statements, Statement{Reference: strings.TrimSpace(field.value)})
+			current = &statements[len(statements)-1]
+			lastLine = nil
+		case "25":
+			current.Account = strings.TrimSpace(field.value)
+		case "60F", "60M":
+			balance, currency, _, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, &ParseError{Line: field.line, Err: err}
+			}
+			current.OpeningBalance = &balance
+			current.Currency = currency
+		case "62F", "62M":
+			balance, currency, date, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, &ParseError{Line: field.line, Err: err}
+			}
+			if current.Currency != "" && current.Currency != currency {
+				return nil, &ParseError{Line: field.line, Err: fmt.Errorf("closing balance currency %s differs from %s", currency, current.Currency)}
+			}
+			current.ClosingBalance = &balance
+			current.Currency = currency
+			current.Date = date
+		case "61":
+			line, err := parseMT940Line(field.value)
+			if err != nil {
+				return nil, &ParseError{Line: field.line, Err: err}
+			}
+			line.Number = len(current.Lines) + 1
+			current.Lines = append(current.Lines, line)
+			lastLine = &current.Lines[len(current.Lines)-1]
+		case "86":
+			// Information to account owner belongs to the preceding :61:
+			// line; after the closing balance it describes the statement.
+			if lastLine != nil {
+				lastLine.Description = strings.Join(strings.Fields(field.value), " ")
+				lastLine = nil
+			}
+		}
+	}
+
+	if len(statements) == 0 {
+		return nil, errors.New("no MT940 statement found")
+	}
+
+	return statements, nil
+}
+
+func readMT940Fields(r io.Reader) ([]mt940Field, error) {
+	var fields []mt940Field
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		text := strings.TrimRight(scanner.Text(), "\r")
+
+		// Drop the basic, application and user header blocks in front of
+		// the text block, and the trailer after it.
+		if i := strings.Index(text, "{4:"); i >= 0 {
+			text = text[i+3:]
+		}
+		if strings.HasPrefix(text, "-}") || strings.HasPrefix(text, "{5:") {
+			continue
+		}
+		if strings.TrimSpace(text) == "" || text == "-" {
+			continue
+		}
+
+		if strings.HasPrefix(text, ":") {
+			end := strings.Index(text[1:], ":")
+			if end < 1 {
+				return nil, &ParseError{Line: lineNumber, Err: fmt.Errorf("malformed field %q", text)}
+			}
+			fields = append(fields, mt940Field{
+				tag:   text[1 : end+1],
+				value: text[end+2:],
+				line:  lineNumber,
+			})
+			continue
+		}
+
+		if len(fields) == 0 {
+			return nil, &ParseError{Line: lineNumber, Err: errors.New("text before the first field")}
+		}
+		fields[len(fields)-1].value += "\n" + text
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func parseMT940Balance(value string) (float64, string, time.Time, error) {
+	match := balancePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, "", time.Time{}, fmt.Errorf("malformed balance %q", value)
+	}
+
+	date, err := time.Parse("060102", match[2])
+	if err != nil {
+		return 0, "", time.Time{}, fmt.Errorf("invalid balance date %q", match[2])
+	}
+
+	amount, err := parseAmount(match[4], true)
+	if err != nil {
+		return 0, "", time.Time{}, err
+	}
+	if match[1] == "D" {
+		amount = -amount
+	}
+	return amount, match[3], date, nil
+}
+
+func parseMT940Line(value string) (Line, error) {
+	var line Line
+
+	first, supplementary, _ := strings.Cut(value, "\n")
+	match := statementLinePattern.FindStringSubmatch(strings.TrimSpace(first))
+	if match == nil {
+		return line, fmt.Errorf("malformed statement line %q", first)
+	}
+
+	valueDate, err := time.Parse("060102", match[1])
+	if err != nil {
+		return line, fmt.Errorf("invalid value date %q", match[1])
+	}
+	line.ValueDate = valueDate
+	line.BookingDate = valueDate
+
+	if match[2] != "" {
+		entryDate, err := time.Parse("0102", match[2])
+		if err != nil {
+			return line, fmt.Errorf("invalid entry date %q", match[2])
+		}
+		// The entry date has no year; it is the one closest to the value date.
+		line.BookingDate = closestYear(entryDate, valueDate)
+	}
+
+	// A reversal of a credit takes money out of the account and vice versa.
+	switch match[3] {
+	case "C", "RD":
+		line.Direction = DirectionCredit
+	default:
+		line.Direction = DirectionDebit
+	}
+
+	if line.Amount, err = parseAmount(match[5], true); err != nil {
+		return line, err
+	}
+
+	if reference := strings.TrimSpace(match[7]); reference != "NONREF" {
+		line.Reference = reference
+	}
+	line.BankReference = strings.TrimSpace(match[8])
+	line.Description = strings.TrimSpace(strings.ReplaceAll(supplementary, "\n", " "))
+
+	return line, nil
+}
+
+func closestYear(monthDay, near time.Time) time.Time {
+	candidate := time.Date(near.Year(), monthDay.Month(), monthDay.Day(), 0, 0, 0, 0, time.UTC)
+	switch {
+	case candidate.Sub(near) > 183*24*time.Hour:
+		return candidate.AddDate(-1, 0, 0)
+	case near.Sub(candidate) > 183*24*time.Hour:
+		return candidate.AddDate(1, 0, 0)
+	}
+	return candidate
+}
@@ -0,0 +1,80 @@
+// Package bankstatement reads the account statements partner banks send for
+// our settlement accounts: delimited files with a configurable column layout
+// and SWIFT MT940 messages. Both are parsed into the same Statement.
+package bankstatement
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatMT940 = "mt940"
+
+	DirectionCredit = "credit"
+	DirectionDebit  = "debit"
+)
+
+// Statement is one account statement. Balances are nil and Date is zero when
+// the file does not carry them.
+type Statement struct {
+	Reference      string
+	Account        string
+	Currency       string
+	Date           time.Time
+	OpeningBalance *float64
+	ClosingBalance *float64
+	Lines          []Line
+}
+
+// Line is one booking on the bank account. Amount is always positive; a
+// credit is money received on the bank account.
+type Line struct {
+	Number        int
+	BookingDate   time.Time
+	ValueDate     time.Time
+	Direction     string
+	Amount        float64
+	Reference     string
+	BankReference string
+	Description   string
+}
+
+// ParseError points at the line of the input that could not be read.
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseAmount reads a decimal amount with either a dot or a comma as the
+// decimal separator, ignoring thousands separators and spaces.
+func parseAmount(value string, decimalComma bool) (float64, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), " ", "")
+	if decimalComma {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, ",", ".", 1)
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return math.Round(amount*10000) / 10000, nil
+}
@@ -1,6 +1,9 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+
 	"gorm.io/gorm/clause"
 )
 
@@ -9,9 +12,18 @@ type DB interface {
 	Save(value any) error
 	Where(query any, args ...any) DB
 	Preload(query string, args ...any) DB
+	Order(value any) DB
+	Limit(limit int) DB
+	Offset(offset int) DB
 	First(dest any) error
 	Find(dest any) error
+	Raw(sql string, values ...any) DB
+	Exec(sql string, values ...any) (int64, error)
+	Scan(dest any) error
+	Rows() (*sql.Rows, error)
+	ScanRows(rows *sql.Rows, dest any) error
 	Clauses(clauses ...clause.Expression) DB
+	WithContext(ctx context.Context) DB
 	Error() error
 }
 
@@ -9,16 +9,40 @@ import (
 func (d *Database) Migrate() error {
 	err := d.DB.AutoMigrate(
 		&model.User{},
-		&model.Wallet{},
+		&model.RefreshToken{},
 		&model.Transaction{},
 		&model.LedgerEntry{},
 		&model.Account{},
+		&model.AccountStatusChange{},
+		&model.BalanceCheckpoint{},
+		&model.AccountingPeriod{},
+		&model.PeriodBalanceSnapshot{},
+		&model.SavingsProduct{},
+		&model.InterestAccrual{},
+		&model.AuditRun{},
+		&model.AuditFinding{},
+		&model.FraudCase{},
+		&model.FraudCaseEvent{},
+		&model.BankStatement{},
+		&model.BankStatementLine{},
+		&model.AuditAlert{},
+		&model.SuspiciousActivityReport{},
+		&model.AMLAlert{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	// Wallets come last: wallets from before they had ledger accounts are
+	// moved onto ledger accounts once the ledger tables are up to date.
+	if err := d.migrateLegacyWallets(); err != nil {
+		return fmt.Errorf("failed to migrate wallets: %w", err)
+	}
+	if err := d.DB.AutoMigrate(&model.Wallet{}); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
 	log.Println("Database migration completed")
 	return nil
 }
@@ -41,6 +41,29 @@ func (d *Database) Seed() error {
 			CreatedAt:    time.Now(),
 			UpdatedAt:    time.Now(),
 		},
+		{
+			ID:           uuid.New(),
+			Email:        "system@paygo.internal",
+			PasswordHash: "!", // cannot log in
+			FirstName:    "PayGo",
+			LastName:     "System",
+			Verified:     true,
+			Status:       "active",
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		},
+		{
+			ID:           uuid.New(),
+			Email:        "ops@example.com",
+			PasswordHash: "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", // password: "password123"
+			FirstName:    "Olivia",
+			LastName:     "Operations",
+			Verified:     true,
+			Status:       "active",
+			Role:         "staff",
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		},
 	}
 
 	for _, user := range users {
@@ -77,6 +100,31 @@ func (d *Database) Seed() error {
 			CreatedAt:        time.Now(),
 			UpdatedAt:        time.Now(),
 		},
+		{
+			// Funds the initial deposits so that every transaction has a debit
+			// and a credit leg and the trial balance nets to zero.
+			ID:               uuid.New(),
+			UserID:           users[2].ID,
+			AccountNumber:    "SYS-FUNDING-USD",
+			AccountType:      "system",
+			CurrencyCode:     "USD",
+			Balance:          -1500.00,
+			AvailableBalance: -1500.00,
+			Status:           "active",
+			CreatedAt:        time.Now(),
+			UpdatedAt:        time.Now(),
+		},
+		{
+			// Interest on savings accounts is paid from here.
+			ID:            uuid.New(),
+			UserID:        users[2].ID,
+			AccountNumber: "SYS-INTEREST-USD",
+			AccountType:   "system",
+			CurrencyCode:  "USD",
+			Status:        "active",
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		},
 	}
 
 	for _, account := range accounts {
@@ -88,6 +136,27 @@ func (d *Database) Seed() error {
 			account.AccountNumber, account.Balance, account.CurrencyCode)
 	}
 
+	savingsProduct := model.SavingsProduct{
+		ID:               uuid.New(),
+		Code:             "SAVINGS-USD",
+		Name:             "USD Savings",
+		CurrencyCode:     "USD",
+		AnnualRate:       0.02,
+		DayCount:         "ACT/365",
+		PostingFrequency: "monthly",
+		ExpenseAccountID: accounts[3].ID,
+		Status:           "active",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	if err := d.DB.Create(&savingsProduct).Error; err != nil {
+		log.Printf("Failed to create savings product %s: %v", savingsProduct.Code, err)
+		return err
+	}
+	log.Printf("Created savings product: %s (%.2f%% %s)",
+		savingsProduct.Code, savingsProduct.AnnualRate*100, savingsProduct.DayCount)
+
 	// Create initial transactions and ledger entries for account balances
 	transactions := []model.Transaction{
 		{
@@ -124,6 +193,16 @@ func (d *Database) Seed() error {
 
 	// Create ledger entries for the initial deposits
 	ledgerEntries := []model.LedgerEntry{
+		{
+			ID:             uuid.New(),
+			TransactionID:  transactions[0].ID,
+			AccountID:      accounts[2].ID,
+			EntryType:      "debit",
+			Amount:         1000.00,
+			RunningBalance: -1000.00,
+			ValueDate:      timePtr(time.Now().UTC().Truncate(24 * time.Hour)),
+			CreatedAt:      time.Now(),
+		},
 		{
 			ID:             uuid.New(),
 			TransactionID:  transactions[0].ID,
@@ -131,6 +210,7 @@ func (d *Database) Seed() error {
 			EntryType:      "credit",
 			Amount:         1000.00,
 			RunningBalance: 1000.00,
+			ValueDate:      timePtr(time.Now().UTC().Truncate(24 * time.Hour)),
 			CreatedAt:      time.Now(),
 		},
 		{
@@ -140,6 +220,17 @@ func (d *Database) Seed() error {
 			EntryType:      "credit",
 			Amount:         500.00,
 			RunningBalance: 500.00,
+			ValueDate:      timePtr(time.Now().UTC().Truncate(24 * time.Hour)),
+			CreatedAt:      time.Now(),
+		},
+		{
+			ID:             uuid.New(),
+			TransactionID:  transactions[1].ID,
+			AccountID:      accounts[2].ID,
+			EntryType:      "debit",
+			Amount:         500.00,
+			RunningBalance: -1500.00,
+			ValueDate:      timePtr(time.Now().UTC().Truncate(24 * time.Hour)),
 			CreatedAt:      time.Now(),
 		},
 	}
@@ -158,6 +249,7 @@ func (d *Database) Seed() error {
 	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	log.Printf("Alice's Account: %s (USD 1,000.00)", accounts[0].ID)
 	log.Printf("Bob's Account:   %s (USD 500.00)", accounts[1].ID)
+	log.Printf("Savings Product: %s (%s)", savingsProduct.ID, savingsProduct.Code)
 	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	log.Println("\nYou can now test transfers between these accounts!")
 
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 
 	"gorm.io/gorm/clause"
@@ -22,6 +24,18 @@ func (d *Database) Preload(query string, args ...any) DB {
 	return &Database{DB: d.DB.Preload(query, args...)}
 }
 
+func (d *Database) Order(value any) DB {
+	return &Database{DB: d.DB.Order(value)}
+}
+
+func (d *Database) Limit(limit int) DB {
+	return &Database{DB: d.DB.Limit(limit)}
+}
+
+func (d *Database) Offset(offset int) DB {
+	return &Database{DB: d.DB.Offset(offset)}
+}
+
 func (d *Database) First(dest any) error {
 	return d.DB.First(dest).Error
 }
@@ -30,10 +44,35 @@ func (d *Database) Find(dest any) error {
 	return d.DB.Find(dest).Error
 }
 
+func (d *Database) Raw(sql string, values ...any) DB {
+	return &Database{DB: d.DB.Raw(sql, values...)}
+}
+
+func (d *Database) Exec(sql string, values ...any) (int64, error) {
+	result := d.DB.Exec(sql, values...)
+	return result.RowsAffected, result.Error
+}
+
+func (d *Database) Scan(dest any) error {
+	return d.DB.Scan(dest).Error
+}
+
+func (d *Database) Rows() (*sql.Rows, error) {
+	return d.DB.Rows()
+}
+
+func (d *Database) ScanRows(rows *sql.Rows, dest any) error {
+	return d.DB.ScanRows(rows, dest)
+}
+
 func (d *Database) Clauses(expressions ...clause.Expression) DB {
 	return &Database{DB: d.DB.Clauses(expressions...)}
 }
 
+func (d *Database) WithContext(ctx context.Context) DB {
+	return &Database{DB: d.DB.WithContext(ctx)}
+}
+
 func (d *Database) Error() error {
 	return d.DB.Error
 }
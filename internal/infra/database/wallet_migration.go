@@ -0,0 +1,147 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"paygo/internal/domain/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// legacyWallet is a wallet row from before wallets had ledger accounts, when
+// the balance was stored on the wallet itself.
+type legacyWallet struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Balance   float64
+	Currency  string
+	CreatedAt time.Time
+}
+
+// migrateLegacyWallets gives every wallet without a ledger account one, and
+// books its stored balance into it against the currency's funding account,
+// so the balance is kept and the ledger stays balanced. The old balance
+// column is dropped once it has been booked. It does nothing once wallets
+// have an account_id column.
+func (d *Database) migrateLegacyWallets() error {
+	migrator := d.DB.Migrator()
+	if !migrator.HasTable("wallets") || migrator.HasColumn("wallets", "account_id") {
+		return nil
+	}
+
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		var duplicates int64
+		err := tx.Raw(`SELECT COUNT(*) FROM (
+			SELECT 1 FROM wallets GROUP BY user_id, currency HAVING COUNT(*) > 1
+		) d`).Scan(&duplicates).Error
+		if err != nil {
+			return err
+		}
+		if duplicates > 0 {
+			return fmt.Errorf("%d user(s) have several wallets in one currency; merge them before migrating", duplicates)
+		}
+
+		var wallets []legacyWallet
+		if err := tx.Raw("SELECT id, user_id, balance, currency, created_at FROM wallets ORDER BY created_at, id").Scan(&wallets).Error; err != nil {
+			return err
+		}
+
+		for _, statement := range []string{
+			"ALTER TABLE wallets ADD COLUMN account_id uuid",
+			"ALTER TABLE wallets ADD COLUMN IF NOT EXISTS status text DEFAULT 'active'",
+		} {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		for _, wallet := range wallets {
+			account := model.Account{
+				UserID:           wallet.UserID,
+				AccountNumber:    fmt.Sprintf("WAL-%s", strings.ToUpper(wallet.ID.String()[:8])),
+				AccountType:      "wallet",
+				CurrencyCode:     wallet.Currency,
+				Balance:          wallet.Balance,
+				AvailableBalance: wallet.Balance,
+				Status:           "active",
+				CreatedAt:        wallet.CreatedAt,
+				UpdatedAt:        now,
+			}
+			if err := tx.Create(&account).Error; err != nil {
+				return fmt.Errorf("failed to create account for wallet %s: %w", wallet.ID, err)
+			}
+
+			if wallet.Balance != 0 {
+				if err := bookLegacyWalletBalance(tx, wallet, &account, now); err != nil {
+					return fmt.Errorf("failed to book balance of wallet %s: %w", wallet.ID, err)
+				}
+			}
+
+			if err := tx.Exec("UPDATE wallets SET account_id = ? WHERE id = ?", account.ID, wallet.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, statement := range []string{
+			"ALTER TABLE wallets ALTER COLUMN account_id SET NOT NULL",
+			"ALTER TABLE wallets DROP COLUMN balance",
+		} {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		log.Printf("Migrated %d wallet(s) onto ledger accounts", len(wallets))
+		return nil
+	})
+}
+
+// bookLegacyWalletBalance records the wallet's balance as a transfer from the
+// funding account of its currency, the same way the seed funds accounts.
+func bookLegacyWalletBalance(tx *gorm.DB, wallet legacyWallet, account *model.Account, now time.Time) error {
+	var funding model.Account
+	err := tx.Where("account_number = ?", "SYS-FUNDING-"+wallet.Currency).First(&funding).Error
+	if err != nil {
+		return fmt.Errorf("no SYS-FUNDING-%s account to fund it: %w", wallet.Currency, err)
+	}
+
+	from, to, amount := &funding, account, wallet.Balance
+	if amount < 0 {
+		from, to, amount = account, &funding, -amount
+	}
+
+	transaction := model.Transaction{
+		TransactionReference: fmt.Sprintf("WAL-MIG-%s", strings.ToUpper(strings.ReplaceAll(wallet.ID.String(), "-", ""))),
+		TransactionType:      "wallet_migration",
+		Status:               "completed",
+		Amount:               amount,
+		CurrencyCode:         wallet.Currency,
+		Description:          "Balance migrated from wallet " + wallet.ID.String(),
+		SourceAccountID:      &from.ID,
+		DestinationAccountID: &to.ID,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	if err := tx.Create(&transaction).Error; err != nil {
+		return err
+	}
+
+	// The wallet account was created with its balance, so only the funding
+	// account's balance moves.
+	funding.Balance -= wallet.Balance
+	funding.AvailableBalance -= wallet.Balance
+	funding.UpdatedAt = now
+	if err := tx.Save(&funding).Error; err != nil {
+		return err
+	}
+
+	entries := []model.LedgerEntry{
+		{TransactionID: transaction.ID, AccountID: from.ID, EntryType: "debit", Amount: amount, RunningBalance: from.Balance, CreatedAt: now},
+		{TransactionID: transaction.ID, AccountID: to.ID, EntryType: "credit", Amount: amount, RunningBalance: to.Balance, CreatedAt: now},
+	}
+	return tx.Create(&entries).Error
+}
@@ -0,0 +1,200 @@
+// Package iso20022 implements the subset of ISO 20022 cash management messages
+// PayGo exchanges with customers, starting with the camt.053 bank-to-customer
+// statement (version 001.02).
+package iso20022
+
+import (
+	"encoding/xml"
+	"math"
+	"strconv"
+	"time"
+)
+
+const Camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+const (
+	CreditDebitCredit = "CRDT"
+	CreditDebitDebit  = "DBIT"
+
+	BalanceTypeOpeningBooked = "OPBD"
+	BalanceTypeClosingBooked = "CLBD"
+
+	EntryStatusBooked = "BOOK"
+)
+
+type Document struct {
+	XMLName       xml.Name                `xml:"urn:iso:std:iso:20022:tech:xsd:camt.053.001.02 Document"`
+	BkToCstmrStmt BankToCustomerStatement `xml:"BkToCstmrStmt"`
+}
+
+type BankToCustomerStatement struct {
+	GrpHdr GroupHeader `xml:"GrpHdr"`
+	Stmt   []Statement `xml:"Stmt"`
+}
+
+type GroupHeader struct {
+	MsgId   string      `xml:"MsgId"`
+	CreDtTm ISODateTime `xml:"CreDtTm"`
+}
+
+// Statement holds the statement header; its fields are encoded in schema
+// order followed by the entries.
+type Statement struct {
+	Id           string             `xml:"Id"`
+	CreDtTm      ISODateTime        `xml:"CreDtTm"`
+	FrToDt       *DateTimePeriod    `xml:"FrToDt,omitempty"`
+	Acct         CashAccount        `xml:"Acct"`
+	Bal          []CashBalance      `xml:"Bal"`
+	TxsSummry    *TotalTransactions `xml:"TxsSummry,omitempty"`
+	Ntry         []ReportEntry      `xml:"Ntry"`
+	AddtlStmtInf string             `xml:"AddtlStmtInf,omitempty"`
+}
+
+type DateTimePeriod struct {
+	FrDtTm ISODateTime `xml:"FrDtTm"`
+	ToDtTm ISODateTime `xml:"ToDtTm"`
+}
+
+type CashAccount struct {
+	Id  AccountIdentification `xml:"Id"`
+	Ccy string                `xml:"Ccy,omitempty"`
+	Nm  string                `xml:"Nm,omitempty"`
+}
+
+type AccountIdentification struct {
+	IBAN string                        `xml:"IBAN,omitempty"`
+	Othr *GenericAccountIdentification `xml:"Othr,omitempty"`
+}
+
+type GenericAccountIdentification struct {
+	Id string `xml:"Id"`
+}
+
+type CashBalance struct {
+	Tp        BalanceType           `xml:"Tp"`
+	Amt       Amount                `xml:"Amt"`
+	CdtDbtInd string                `xml:"CdtDbtInd"`
+	Dt        DateAndDateTimeChoice `xml:"Dt"`
+}
+
+type BalanceType struct {
+	CdOrPrtry BalanceTypeCode `xml:"CdOrPrtry"`
+}
+
+type BalanceTypeCode struct {
+	Cd string `xml:"Cd"`
+}
+
+type DateAndDateTimeChoice struct {
+	Dt   *ISODate     `xml:"Dt,omitempty"`
+	DtTm *ISODateTime `xml:"DtTm,omitempty"`
+}
+
+type TotalTransactions struct {
+	TtlNtries    *NumberAndSumOfTransactions `xml:"TtlNtries,omitempty"`
+	TtlCdtNtries *NumberAndSumOfTransactions `xml:"TtlCdtNtries,omitempty"`
+	TtlDbtNtries *NumberAndSumOfTransactions `xml:"TtlDbtNtries,omitempty"`
+}
+
+type NumberAndSumOfTransactions struct {
+	NbOfNtries    string `xml:"NbOfNtries,omitempty"`
+	Sum           string `xml:"Sum,omitempty"`
+	TtlNetNtryAmt string `xml:"TtlNetNtryAmt,omitempty"`
+	CdtDbtInd     string `xml:"CdtDbtInd,omitempty"`
+}
+
+type ReportEntry struct {
+	NtryRef      string                 `xml:"NtryRef,omitempty"`
+	Amt          Amount                 `xml:"Amt"`
+	CdtDbtInd    string                 `xml:"CdtDbtInd"`
+	Sts          string                 `xml:"Sts"`
+	BookgDt      *DateAndDateTimeChoice `xml:"BookgDt,omitempty"`
+	ValDt        *DateAndDateTimeChoice `xml:"ValDt,omitempty"`
+	AcctSvcrRef  string                 `xml:"AcctSvcrRef,omitempty"`
+	BkTxCd       BankTransactionCode    `xml:"BkTxCd"`
+	NtryDtls     []EntryDetails         `xml:"NtryDtls,omitempty"`
+	AddtlNtryInf string                 `xml:"AddtlNtryInf,omitempty"`
+}
+
+type BankTransactionCode struct {
+	Domn  *BankTransactionCodeDomain      `xml:"Domn,omitempty"`
+	Prtry *ProprietaryBankTransactionCode `xml:"Prtry,omitempty"`
+}
+
+type BankTransactionCodeDomain struct {
+	Cd   string                    `xml:"Cd"`
+	Fmly BankTransactionCodeFamily `xml:"Fmly"`
+}
+
+type BankTransactionCodeFamily struct {
+	Cd        string `xml:"Cd"`
+	SubFmlyCd string `xml:"SubFmlyCd"`
+}
+
+type ProprietaryBankTransactionCode struct {
+	Cd   string `xml:"Cd"`
+	Issr string `xml:"Issr,omitempty"`
+}
+
+type EntryDetails struct {
+	TxDtls []TransactionDetails `xml:"TxDtls"`
+}
+
+type TransactionDetails struct {
+	Refs      *TransactionReferences `xml:"Refs,omitempty"`
+	RltdPties *RelatedParties        `xml:"RltdPties,omitempty"`
+	RmtInf    *RemittanceInformation `xml:"RmtInf,omitempty"`
+}
+
+type TransactionReferences struct {
+	AcctSvcrRef string `xml:"AcctSvcrRef,omitempty"`
+	EndToEndId  string `xml:"EndToEndId,omitempty"`
+	TxId        string `xml:"TxId,omitempty"`
+}
+
+type RelatedParties struct {
+	DbtrAcct *CashAccount `xml:"DbtrAcct,omitempty"`
+	CdtrAcct *CashAccount `xml:"CdtrAcct,omitempty"`
+}
+
+type RemittanceInformation struct {
+	Ustrd []string `xml:"Ustrd"`
+}
+
+// Amount is an ActiveOrHistoricCurrencyAndAmount: a non-negative decimal with
+// its currency as an attribute. The sign lives in the sibling CdtDbtInd.
+type Amount struct {
+	Ccy   string `xml:"Ccy,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ISODateTime is an xs:dateTime rendered in UTC.
+type ISODateTime string
+
+// ISODate is an xs:date.
+type ISODate string
+
+func NewISODateTime(t time.Time) ISODateTime {
+	return ISODateTime(t.UTC().Format(time.RFC3339))
+}
+
+func NewISODate(t time.Time) ISODate {
+	return ISODate(t.Format(time.DateOnly))
+}
+
+// NewAmount splits a signed amount into a schema amount and its credit/debit
+// indicator. Zero is reported as a credit.
+func NewAmount(currency string, amount float64) (Amount, string) {
+	indicator := CreditDebitCredit
+	if amount < 0 {
+		indicator = CreditDebitDebit
+		amount = -amount
+	}
+	return Amount{Ccy: currency, Value: FormatDecimal(amount)}, indicator
+}
+
+// FormatDecimal renders an amount rounded to the ledger's four fraction digits
+// without trailing zeros, within the limits of the schema's decimal types.
+func FormatDecimal(amount float64) string {
+	return strconv.FormatFloat(math.Round(amount*10000)/10000, 'f', -1, 64)
+}
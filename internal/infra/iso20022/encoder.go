@@ -0,0 +1,109 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// StatementEncoder writes a camt.053 document holding a single statement
+// incrementally, so statements with many entries never have to be held in
+// memory. Every part is validated before it is written.
+type StatementEncoder struct {
+	w   io.Writer
+	enc *xml.Encoder
+}
+
+func NewStatementEncoder(w io.Writer) *StatementEncoder {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return &StatementEncoder{w: w, enc: enc}
+}
+
+var (
+	documentStart  = xml.StartElement{Name: xml.Name{Space: Camt053Namespace, Local: "Document"}}
+	bkToCstmrStart = xml.StartElement{Name: xml.Name{Local: "BkToCstmrStmt"}}
+	stmtStart      = xml.StartElement{Name: xml.Name{Local: "Stmt"}}
+)
+
+// Begin writes everything up to the first entry: the group header and the
+// statement header including balances and transaction totals. Entries in
+// stmt are ignored; write them with Entry.
+func (e *StatementEncoder) Begin(header GroupHeader, stmt *Statement) error {
+	if err := header.Validate(); err != nil {
+		return err
+	}
+	if err := stmt.ValidateHeader(); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(e.w, xml.Header); err != nil {
+		return err
+	}
+
+	for _, start := range []xml.StartElement{documentStart, bkToCstmrStart} {
+		if err := e.enc.EncodeToken(start); err != nil {
+			return err
+		}
+	}
+
+	if err := e.element("GrpHdr", header); err != nil {
+		return err
+	}
+
+	if err := e.enc.EncodeToken(stmtStart); err != nil {
+		return err
+	}
+
+	if err := e.element("Id", stmt.Id); err != nil {
+		return err
+	}
+	if err := e.element("CreDtTm", stmt.CreDtTm); err != nil {
+		return err
+	}
+	if stmt.FrToDt != nil {
+		if err := e.element("FrToDt", stmt.FrToDt); err != nil {
+			return err
+		}
+	}
+	if err := e.element("Acct", stmt.Acct); err != nil {
+		return err
+	}
+
+	for _, bal := range stmt.Bal {
+		if err := e.element("Bal", bal); err != nil {
+			return err
+		}
+	}
+
+	if stmt.TxsSummry != nil {
+		return e.element("TxsSummry", stmt.TxsSummry)
+	}
+
+	return nil
+}
+
+// Entry validates and writes a single Ntry.
+func (e *StatementEncoder) Entry(entry *ReportEntry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	return e.element("Ntry", entry)
+}
+
+// End closes the statement and the document and flushes the output.
+func (e *StatementEncoder) End() error {
+	for _, start := range []xml.StartElement{stmtStart, bkToCstmrStart, documentStart} {
+		if err := e.enc.EncodeToken(start.End()); err != nil {
+			return err
+		}
+	}
+	if err := e.enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, "\n")
+	return err
+}
+
+func (e *StatementEncoder) element(name string, value any) error {
+	return e.enc.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: name}})
+}
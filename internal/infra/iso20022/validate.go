@@ -0,0 +1,376 @@
+package iso20022
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The checks below mirror the facets of camt.053.001.02.xsd for the elements
+// PayGo emits: cardinalities, text lengths, code lists, patterns and the
+// totalDigits/fractionDigits of the decimal types. On top of the schema, the
+// transaction totals must agree with the entries and take the opening booked
+// balance to the closing one.
+
+var (
+	currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	numericTextPattern  = regexp.MustCompile(`^[0-9]{1,15}$`)
+	decimalPattern      = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+)
+
+var (
+	balanceTypeCodes = map[string]bool{
+		"CLAV": true, "CLBD": true, "FWAV": true, "INFO": true, "ITAV": true,
+		"ITBD": true, "OPAV": true, "OPBD": true, "PRCD": true, "XPCD": true,
+	}
+	entryStatusCodes = map[string]bool{"BOOK": true, "PDNG": true, "INFO": true}
+	creditDebitCodes = map[string]bool{CreditDebitCredit: true, CreditDebitDebit: true}
+)
+
+// ValidationError lists every schema violation found in a document.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("camt.053 document is not schema-valid: %s", strings.Join(e.Problems, "; "))
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+// Validate checks the whole document against the camt.053.001.02 schema constraints.
+func (d *Document) Validate() error {
+	v := &validator{}
+	path := "Document/BkToCstmrStmt"
+
+	d.BkToCstmrStmt.GrpHdr.validate(v, path+"/GrpHdr")
+
+	if len(d.BkToCstmrStmt.Stmt) == 0 {
+		v.fail(path, "at least one Stmt is required")
+	}
+	for i := range d.BkToCstmrStmt.Stmt {
+		stmt := &d.BkToCstmrStmt.Stmt[i]
+		stmtPath := fmt.Sprintf("%s/Stmt[%d]", path, i)
+		stmt.validateHeader(v, stmtPath)
+		for j := range stmt.Ntry {
+			stmt.Ntry[j].validate(v, fmt.Sprintf("%s/Ntry[%d]", stmtPath, j))
+		}
+		stmt.validateTotals(v, stmtPath)
+	}
+
+	return v.err()
+}
+
+// Validate checks the group header on its own, for documents written incrementally.
+func (h *GroupHeader) Validate() error {
+	v := &validator{}
+	h.validate(v, "GrpHdr")
+	return v.err()
+}
+
+// ValidateHeader checks every part of the statement except its entries, for
+// documents written incrementally.
+func (s *Statement) ValidateHeader() error {
+	v := &validator{}
+	s.validateHeader(v, "Stmt")
+	return v.err()
+}
+
+// Validate checks a single entry, for documents written incrementally.
+func (e *ReportEntry) Validate() error {
+	v := &validator{}
+	e.validate(v, "Ntry")
+	return v.err()
+}
+
+func (h *GroupHeader) validate(v *validator, path string) {
+	v.text(path+"/MsgId", h.MsgId, 35, true)
+	v.dateTime(path+"/CreDtTm", h.CreDtTm)
+}
+
+func (s *Statement) validateHeader(v *validator, path string) {
+	v.text(path+"/Id", s.Id, 35, true)
+	v.dateTime(path+"/CreDtTm", s.CreDtTm)
+
+	if s.FrToDt != nil {
+		v.dateTime(path+"/FrToDt/FrDtTm", s.FrToDt.FrDtTm)
+		v.dateTime(path+"/FrToDt/ToDtTm", s.FrToDt.ToDtTm)
+	}
+
+	s.Acct.validate(v, path+"/Acct")
+
+	if len(s.Bal) == 0 {
+		v.fail(path, "at least one Bal is required")
+	}
+	for i, bal := range s.Bal {
+		balPath := fmt.Sprintf("%s/Bal[%d]", path, i)
+		v.code(balPath+"/Tp/CdOrPrtry/Cd", bal.Tp.CdOrPrtry.Cd, balanceTypeCodes)
+		v.amount(balPath+"/Amt", bal.Amt)
+		v.code(balPath+"/CdtDbtInd", bal.CdtDbtInd, creditDebitCodes)
+		v.dateChoice(balPath+"/Dt", bal.Dt)
+	}
+
+	if s.TxsSummry != nil {
+		v.transactionTotals(path+"/TxsSummry/TtlNtries", s.TxsSummry.TtlNtries, true)
+		v.transactionTotals(path+"/TxsSummry/TtlCdtNtries", s.TxsSummry.TtlCdtNtries, false)
+		v.transactionTotals(path+"/TxsSummry/TtlDbtNtries", s.TxsSummry.TtlDbtNtries, false)
+	}
+	s.validateBalances(v, path)
+
+	v.text(path+"/AddtlStmtInf", s.AddtlStmtInf, 500, false)
+}
+
+// validateBalances checks that the net of all entries takes the opening
+// booked balance to the closing booked balance, when all three are given.
+func (s *Statement) validateBalances(v *validator, path string) {
+	var opening, closing *CashBalance
+	for i := range s.Bal {
+		switch s.Bal[i].Tp.CdOrPrtry.Cd {
+		case BalanceTypeOpeningBooked:
+			opening = &s.Bal[i]
+		case BalanceTypeClosingBooked:
+			closing = &s.Bal[i]
+		}
+	}
+	if opening == nil || closing == nil || s.TxsSummry == nil || s.TxsSummry.TtlNtries == nil || s.TxsSummry.TtlNtries.TtlNetNtryAmt == "" {
+		return
+	}
+
+	openingUnits, okOpening := signedUnits(opening.Amt.Value, opening.CdtDbtInd)
+	closingUnits, okClosing := signedUnits(closing.Amt.Value, closing.CdtDbtInd)
+	netUnits, okNet := signedUnits(s.TxsSummry.TtlNtries.TtlNetNtryAmt, s.TxsSummry.TtlNtries.CdtDbtInd)
+	if okOpening && okClosing && okNet && openingUnits+netUnits != closingUnits {
+		v.fail(path+"/Bal", "opening balance plus net entries does not equal the closing balance")
+	}
+}
+
+// validateTotals checks the transaction totals against the entries.
+func (s *Statement) validateTotals(v *validator, path string) {
+	if s.TxsSummry == nil {
+		return
+	}
+
+	var count, credits, debits, creditUnits, debitUnits int64
+	for _, entry := range s.Ntry {
+		units, ok := signedUnits(entry.Amt.Value, entry.CdtDbtInd)
+		if !ok {
+			return
+		}
+		count++
+		if units < 0 {
+			debits++
+			debitUnits -= units
+		} else {
+			credits++
+			creditUnits += units
+		}
+	}
+
+	path += "/TxsSummry"
+	v.totalsMatch(path+"/TtlNtries", s.TxsSummry.TtlNtries, count, creditUnits+debitUnits)
+	v.totalsMatch(path+"/TtlCdtNtries", s.TxsSummry.TtlCdtNtries, credits, creditUnits)
+	v.totalsMatch(path+"/TtlDbtNtries", s.TxsSummry.TtlDbtNtries, debits, debitUnits)
+
+	if totals := s.TxsSummry.TtlNtries; totals != nil && totals.TtlNetNtryAmt != "" {
+		if net, ok := signedUnits(totals.TtlNetNtryAmt, totals.CdtDbtInd); ok && net != creditUnits-debitUnits {
+			v.fail(path+"/TtlNtries/TtlNetNtryAmt", "%s %s does not match the entries", totals.TtlNetNtryAmt, totals.CdtDbtInd)
+		}
+	}
+}
+
+func (a *CashAccount) validate(v *validator, path string) {
+	hasIBAN, hasOther := a.Id.IBAN != "", a.Id.Othr != nil
+	if hasIBAN == hasOther {
+		v.fail(path+"/Id", "exactly one of IBAN or Othr is required")
+	}
+	if hasOther {
+		v.text(path+"/Id/Othr/Id", a.Id.Othr.Id, 34, true)
+	}
+	if a.Ccy != "" && !currencyCodePattern.MatchString(a.Ccy) {
+		v.fail(path+"/Ccy", "%q is not an ISO 4217 currency code", a.Ccy)
+	}
+	v.text(path+"/Nm", a.Nm, 70, false)
+}
+
+func (e *ReportEntry) validate(v *validator, path string) {
+	v.text(path+"/NtryRef", e.NtryRef, 35, false)
+	v.amount(path+"/Amt", e.Amt)
+	v.code(path+"/CdtDbtInd", e.CdtDbtInd, creditDebitCodes)
+	v.code(path+"/Sts", e.Sts, entryStatusCodes)
+	if e.BookgDt != nil {
+		v.dateChoice(path+"/BookgDt", *e.BookgDt)
+	}
+	if e.ValDt != nil {
+		v.dateChoice(path+"/ValDt", *e.ValDt)
+	}
+	v.text(path+"/AcctSvcrRef", e.AcctSvcrRef, 35, false)
+
+	if e.BkTxCd.Domn == nil && e.BkTxCd.Prtry == nil {
+		v.fail(path+"/BkTxCd", "Domn or Prtry is required")
+	}
+	if d := e.BkTxCd.Domn; d != nil {
+		v.text(path+"/BkTxCd/Domn/Cd", d.Cd, 4, true)
+		v.text(path+"/BkTxCd/Domn/Fmly/Cd", d.Fmly.Cd, 4, true)
+		v.text(path+"/BkTxCd/Domn/Fmly/SubFmlyCd", d.Fmly.SubFmlyCd, 4, true)
+	}
+	if p := e.BkTxCd.Prtry; p != nil {
+		v.text(path+"/BkTxCd/Prtry/Cd", p.Cd, 35, true)
+		v.text(path+"/BkTxCd/Prtry/Issr", p.Issr, 35, false)
+	}
+
+	for i, details := range e.NtryDtls {
+		for j, tx := range details.TxDtls {
+			txPath := fmt.Sprintf("%s/NtryDtls[%d]/TxDtls[%d]", path, i, j)
+			if tx.Refs != nil {
+				v.text(txPath+"/Refs/AcctSvcrRef", tx.Refs.AcctSvcrRef, 35, false)
+				v.text(txPath+"/Refs/EndToEndId", tx.Refs.EndToEndId, 35, false)
+				v.text(txPath+"/Refs/TxId", tx.Refs.TxId, 35, false)
+			}
+			if tx.RltdPties != nil {
+				if tx.RltdPties.DbtrAcct != nil {
+					tx.RltdPties.DbtrAcct.validate(v, txPath+"/RltdPties/DbtrAcct")
+				}
+				if tx.RltdPties.CdtrAcct != nil {
+					tx.RltdPties.CdtrAcct.validate(v, txPath+"/RltdPties/CdtrAcct")
+				}
+			}
+			if tx.RmtInf != nil {
+				for k, line := range tx.RmtInf.Ustrd {
+					v.text(fmt.Sprintf("%s/RmtInf/Ustrd[%d]", txPath, k), line, 140, true)
+				}
+			}
+		}
+	}
+
+	v.text(path+"/AddtlNtryInf", e.AddtlNtryInf, 500, false)
+}
+
+func (v *validator) text(path, value string, max int, required bool) {
+	if value == "" {
+		if required {
+			v.fail(path, "is required")
+		}
+		return
+	}
+	if n := len([]rune(value)); n > max {
+		v.fail(path, "length %d exceeds maximum %d", n, max)
+	}
+}
+
+func (v *validator) code(path, value string, allowed map[string]bool) {
+	if !allowed[value] {
+		v.fail(path, "%q is not an allowed code", value)
+	}
+}
+
+func (v *validator) dateTime(path string, value ISODateTime) {
+	if _, err := time.Parse(time.RFC3339, string(value)); err != nil {
+		v.fail(path, "%q is not a valid ISODateTime", value)
+	}
+}
+
+func (v *validator) dateChoice(path string, choice DateAndDateTimeChoice) {
+	if (choice.Dt == nil) == (choice.DtTm == nil) {
+		v.fail(path, "exactly one of Dt or DtTm is required")
+		return
+	}
+	if choice.Dt != nil {
+		if _, err := time.Parse(time.DateOnly, string(*choice.Dt)); err != nil {
+			v.fail(path+"/Dt", "%q is not a valid ISODate", *choice.Dt)
+		}
+		return
+	}
+	v.dateTime(path+"/DtTm", *choice.DtTm)
+}
+
+// amount checks an ActiveOrHistoricCurrencyAndAmount: fractionDigits 5,
+// totalDigits 18, minInclusive 0.
+func (v *validator) amount(path string, amount Amount) {
+	if !currencyCodePattern.MatchString(amount.Ccy) {
+		v.fail(path+"/@Ccy", "%q is not an ISO 4217 currency code", amount.Ccy)
+	}
+	v.decimal(path, amount.Value, 18, 5)
+}
+
+// decimal checks a non-negative decimal against its totalDigits and fractionDigits facets.
+func (v *validator) decimal(path, value string, totalDigits, fractionDigits int) {
+	if !decimalPattern.MatchString(value) {
+		v.fail(path, "%q is not a non-negative decimal", value)
+		return
+	}
+	integer, fraction, _ := strings.Cut(value, ".")
+	integer = strings.TrimLeft(integer, "0")
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > fractionDigits {
+		v.fail(path, "%q has more than %d fraction digits", value, fractionDigits)
+	}
+	if len(integer)+len(fraction) > totalDigits {
+		v.fail(path, "%q has more than %d total digits", value, totalDigits)
+	}
+}
+
+func (v *validator) totalsMatch(path string, totals *NumberAndSumOfTransactions, count, units int64) {
+	if totals == nil {
+		return
+	}
+	if totals.NbOfNtries != "" && totals.NbOfNtries != strconv.FormatInt(count, 10) {
+		v.fail(path+"/NbOfNtries", "%s does not match the %d entries", totals.NbOfNtries, count)
+	}
+	if totals.Sum != "" {
+		if sum, ok := signedUnits(totals.Sum, CreditDebitCredit); ok && sum != units {
+			v.fail(path+"/Sum", "%s does not match the entries", totals.Sum)
+		}
+	}
+}
+
+// signedUnits converts a decimal amount and its credit/debit indicator to
+// signed hundred-thousandths, the finest fraction an amount may carry.
+func signedUnits(value, indicator string) (int64, bool) {
+	if !decimalPattern.MatchString(value) {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	units := int64(math.Round(f * 1e5))
+	if indicator == CreditDebitDebit {
+		units = -units
+	}
+	return units, true
+}
+
+func (v *validator) transactionTotals(path string, totals *NumberAndSumOfTransactions, withNet bool) {
+	if totals == nil {
+		return
+	}
+	if totals.NbOfNtries != "" && !numericTextPattern.MatchString(totals.NbOfNtries) {
+		v.fail(path+"/NbOfNtries", "%q is not a Max15NumericText", totals.NbOfNtries)
+	}
+	if totals.Sum != "" {
+		v.decimal(path+"/Sum", totals.Sum, 18, 17)
+	}
+	if !withNet && (totals.TtlNetNtryAmt != "" || totals.CdtDbtInd != "") {
+		v.fail(path, "TtlNetNtryAmt and CdtDbtInd are not allowed here")
+	}
+	if totals.TtlNetNtryAmt != "" {
+		v.decimal(path+"/TtlNetNtryAmt", totals.TtlNetNtryAmt, 18, 17)
+		v.code(path+"/CdtDbtInd", totals.CdtDbtInd, creditDebitCodes)
+	}
+}
@@ -0,0 +1,185 @@
+package iso20022
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testEntries are the signed amounts of the statement built by testDocument.
+var testEntries = []float64{250, -75.5, 1000.1234, -0.0001}
+
+const testOpeningBalance = 120.25
+
+// testDocument builds a camt.053 document the way the statement export does,
+// from fixed entries.
+func testDocument() *Document {
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	var credits, debits float64
+	var creditCount, debitCount int
+	entries := make([]ReportEntry, 0, len(testEntries))
+	for i, signed := range testEntries {
+		amount, indicator := NewAmount("EUR", signed)
+		if signed < 0 {
+			debits -= signed
+			debitCount++
+		} else {
+			credits += signed
+			creditCount++
+		}
+
+		booked := NewISODateTime(from.Add(time.Duration(i+1) * time.Hour))
+		valueDate := NewISODate(from)
+		entries = append(entries, ReportEntry{
+			NtryRef:   "NTRY" + strconv.Itoa(i),
+			Amt:       amount,
+			CdtDbtInd: indicator,
+			Sts:       EntryStatusBooked,
+			BookgDt:   &DateAndDateTimeChoice{DtTm: &booked},
+			ValDt:     &DateAndDateTimeChoice{Dt: &valueDate},
+			BkTxCd: BankTransactionCode{
+				Domn: &BankTransactionCodeDomain{Cd: "PMNT", Fmly: BankTransactionCodeFamily{Cd: "RCDT", SubFmlyCd: "BOOK"}},
+			},
+			NtryDtls: []EntryDetails{{TxDtls: []TransactionDetails{{
+				Refs:   &TransactionReferences{EndToEndId: "E2E" + strconv.Itoa(i)},
+				RmtInf: &RemittanceInformation{Ustrd: []string{"Invoice " + strconv.Itoa(i)}},
+			}}}},
+		})
+	}
+
+	opening, openingIndicator := NewAmount("EUR", testOpeningBalance)
+	closing, closingIndicator := NewAmount("EUR", testOpeningBalance+credits-debits)
+	net, netIndicator := NewAmount("EUR", credits-debits)
+	openingDate := NewISODate(from)
+	closingDate := NewISODate(to.Add(-time.Nanosecond))
+
+	return &Document{BkToCstmrStmt: BankToCustomerStatement{
+		GrpHdr: GroupHeader{MsgId: "MSG-1", CreDtTm: NewISODateTime(to)},
+		Stmt: []Statement{{
+			Id:      "ACC-1-20260901-20261001",
+			CreDtTm: NewISODateTime(to),
+			FrToDt:  &DateTimePeriod{FrDtTm: NewISODateTime(from), ToDtTm: NewISODateTime(to)},
+			Acct:    CashAccount{Id: AccountIdentification{Othr: &GenericAccountIdentification{Id: "ACC-1"}}, Ccy: "EUR"},
+			Bal: []CashBalance{
+				{
+					Tp:        BalanceType{CdOrPrtry: BalanceTypeCode{Cd: BalanceTypeOpeningBooked}},
+					Amt:       opening,
+					CdtDbtInd: openingIndicator,
+					Dt:        DateAndDateTimeChoice{Dt: &openingDate},
+				},
+				{
+					Tp:        BalanceType{CdOrPrtry: BalanceTypeCode{Cd: BalanceTypeClosingBooked}},
+					Amt:       closing,
+					CdtDbtInd: closingIndicator,
+					Dt:        DateAndDateTimeChoice{Dt: &closingDate},
+				},
+			},
+			TxsSummry: &TotalTransactions{
+				TtlNtries: &NumberAndSumOfTransactions{
+					NbOfNtries:    strconv.Itoa(len(entries)),
+					Sum:           FormatDecimal(credits + debits),
+					TtlNetNtryAmt: net.Value,
+					CdtDbtInd:     netIndicator,
+				},
+				TtlCdtNtries: &NumberAndSumOfTransactions{NbOfNtries: strconv.Itoa(creditCount), Sum: FormatDecimal(credits)},
+				TtlDbtNtries: &NumberAndSumOfTransactions{NbOfNtries: strconv.Itoa(debitCount), Sum: FormatDecimal(debits)},
+			},
+			Ntry: entries,
+		}},
+	}}
+}
+
+func TestValidateAcceptsStatement(t *testing.T) {
+	if err := testDocument().Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestValidateRejectsBrokenFacets(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(stmt *Statement)
+		problem string
+	}{
+		{
+			name:    "missing entry currency",
+			mutate:  func(stmt *Statement) { stmt.Ntry[1].Amt.Ccy = "" },
+			problem: "Ntry[1]/Amt/@Ccy",
+		},
+		{
+			name:    "missing balance currency",
+			mutate:  func(stmt *Statement) { stmt.Bal[0].Amt.Ccy = "" },
+			problem: "Bal[0]/Amt/@Ccy",
+		},
+		{
+			name:    "bad statement ISODateTime",
+			mutate:  func(stmt *Statement) { stmt.CreDtTm = "2026-10-01 00:00:00" },
+			problem: "Stmt[0]/CreDtTm",
+		},
+		{
+			name: "bad booking ISODateTime",
+			mutate: func(stmt *Statement) {
+				booked := ISODateTime("2026-09-31T10:00:00Z")
+				stmt.Ntry[0].BookgDt = &DateAndDateTimeChoice{DtTm: &booked}
+			},
+			problem: "Ntry[0]/BookgDt/DtTm",
+		},
+		{
+			name:    "entry count off",
+			mutate:  func(stmt *Statement) { stmt.TxsSummry.TtlNtries.NbOfNtries = "5" },
+			problem: "TtlNtries/NbOfNtries",
+		},
+		{
+			name:    "credit sum off",
+			mutate:  func(stmt *Statement) { stmt.TxsSummry.TtlCdtNtries.Sum = "1250.1233" },
+			problem: "TtlCdtNtries/Sum",
+		},
+		{
+			name:    "net amount off",
+			mutate:  func(stmt *Statement) { stmt.TxsSummry.TtlNtries.CdtDbtInd = CreditDebitDebit },
+			problem: "TtlNtries/TtlNetNtryAmt",
+		},
+		{
+			name:    "closing balance off",
+			mutate:  func(stmt *Statement) { stmt.Bal[1].Amt.Value = "1294.8732" },
+			problem: "Stmt[0]/Bal:",
+		},
+		{
+			name:    "entry dropped",
+			mutate:  func(stmt *Statement) { stmt.Ntry = stmt.Ntry[:len(stmt.Ntry)-1] },
+			problem: "TtlDbtNtries/Sum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := testDocument()
+			tt.mutate(&doc.BkToCstmrStmt.Stmt[0])
+
+			err := doc.Validate()
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() = %v, want a *ValidationError", err)
+			}
+			if !strings.Contains(err.Error(), tt.problem) {
+				t.Errorf("Validate() = %v, want a problem at %s", err, tt.problem)
+			}
+		})
+	}
+}
+
+func TestStatementHeaderChecksBalances(t *testing.T) {
+	stmt := testDocument().BkToCstmrStmt.Stmt[0]
+	if err := stmt.ValidateHeader(); err != nil {
+		t.Fatalf("ValidateHeader() = %v, want nil", err)
+	}
+
+	stmt.Bal[0].CdtDbtInd = CreditDebitDebit
+	if err := stmt.ValidateHeader(); err == nil {
+		t.Fatal("ValidateHeader() = nil, want an error for an unbalanced statement")
+	}
+}
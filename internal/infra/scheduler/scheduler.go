@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Job is a unit of background work. Jobs must return promptly once ctx is cancelled.
+type Job func(ctx context.Context) error
+
+type scheduledJob struct {
+	name string
+	// next returns the first run time after t.
+	next func(t time.Time) time.Time
+	run  Job
+}
+
+// Scheduler runs registered jobs periodically in the background. Each job runs
+// in its own goroutine, so a run never overlaps with the previous run of the same job.
+type Scheduler struct {
+	jobs   []scheduledJob
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every registers a job that runs once per interval. Jobs with a non-positive
+// interval are disabled.
+func (s *Scheduler) Every(name string, interval time.Duration, job Job) {
+	if interval <= 0 {
+		log.Printf("Scheduler: job %q disabled", name)
+		return
+	}
+	s.jobs = append(s.jobs, scheduledJob{
+		name: name,
+		next: func(t time.Time) time.Time { return t.Add(interval) },
+		run:  job,
+	})
+}
+
+// Cron registers a job that runs on a standard five-field cron schedule, such
+// as "*/15 * * * *", in the server's local time. An empty spec disables the job.
+func (s *Scheduler) Cron(name, spec string, job Job) error {
+	if spec == "" {
+		log.Printf("Scheduler: job %q disabled", name)
+		return nil
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return fmt.Errorf("invalid schedule %q for job %q: %w", spec, name, err)
+	}
+	s.jobs = append(s.jobs, scheduledJob{name: name, next: schedule.Next, run: job})
+	return nil
+}
+
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job scheduledJob) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+
+	log.Printf("Scheduler started with %d job(s)", len(s.jobs))
+}
+
+// Stop cancels running jobs and waits for them to return.
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job scheduledJob) {
+	at := job.next(time.Now())
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			start := time.Now()
+			if err := job.run(ctx); err != nil {
+				log.Printf("Scheduler: job %q failed after %v: %v", job.name, time.Since(start), err)
+			} else {
+				log.Printf("Scheduler: job %q completed in %v", job.name, time.Since(start))
+			}
+
+			// Runs missed while the job was busy are skipped, not queued.
+			for at = job.next(at); !at.After(time.Now()); {
+				at = job.next(time.Now())
+			}
+			timer.Reset(time.Until(at))
+		}
+	}
+}